@@ -0,0 +1,310 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.21.11
+// source: app/harmoni/api/mq/v1/comment/comment.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ObjectType int32
+
+const (
+	ObjectType_ObjectNo      ObjectType = 0
+	ObjectType_ObjectPost    ObjectType = 1
+	ObjectType_ObjectComment ObjectType = 2
+	ObjectType_ObjectTag     ObjectType = 3
+	ObjectType_ObjectUser    ObjectType = 4
+)
+
+// Enum value maps for ObjectType.
+var (
+	ObjectType_name = map[int32]string{
+		0: "ObjectNo",
+		1: "ObjectPost",
+		2: "ObjectComment",
+		3: "ObjectTag",
+		4: "ObjectUser",
+	}
+	ObjectType_value = map[string]int32{
+		"ObjectNo":      0,
+		"ObjectPost":    1,
+		"ObjectComment": 2,
+		"ObjectTag":     3,
+		"ObjectUser":    4,
+	}
+)
+
+func (x ObjectType) Enum() *ObjectType {
+	p := new(ObjectType)
+	*p = x
+	return p
+}
+
+func (x ObjectType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ObjectType) Descriptor() protoreflect.EnumDescriptor {
+	return file_app_harmoni_api_mq_v1_comment_comment_proto_enumTypes[0].Descriptor()
+}
+
+func (ObjectType) Type() protoreflect.EnumType {
+	return &file_app_harmoni_api_mq_v1_comment_comment_proto_enumTypes[0]
+}
+
+func (x ObjectType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ObjectType.Descriptor instead.
+func (ObjectType) EnumDescriptor() ([]byte, []int) {
+	return file_app_harmoni_api_mq_v1_comment_comment_proto_rawDescGZIP(), []int{0}
+}
+
+type CommentCreatedMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CommentID      int64                  `protobuf:"varint,1,opt,name=commentID,proto3" json:"commentID,omitempty"`
+	ObjectID       int64                  `protobuf:"varint,2,opt,name=objectID,proto3" json:"objectID,omitempty"`
+	ObjectAuthorID int64                  `protobuf:"varint,3,opt,name=objectAuthorID,proto3" json:"objectAuthorID,omitempty"`
+	AuthorID       int64                  `protobuf:"varint,4,opt,name=authorID,proto3" json:"authorID,omitempty"`
+	ParentID       int64                  `protobuf:"varint,5,opt,name=parentID,proto3" json:"parentID,omitempty"`
+	ParentAuthorID int64                  `protobuf:"varint,6,opt,name=parentAuthorID,proto3" json:"parentAuthorID,omitempty"`
+	RootID         int64                  `protobuf:"varint,7,opt,name=rootID,proto3" json:"rootID,omitempty"`
+	ToUserIDs      []int64                `protobuf:"varint,8,rep,packed,name=toUserIDs,proto3" json:"toUserIDs,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	ObjectType     ObjectType             `protobuf:"varint,10,opt,name=objectType,proto3,enum=mq.v1.comment.ObjectType" json:"objectType,omitempty"`
+}
+
+func (x *CommentCreatedMessage) Reset() {
+	*x = CommentCreatedMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_app_harmoni_api_mq_v1_comment_comment_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommentCreatedMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommentCreatedMessage) ProtoMessage() {}
+
+func (x *CommentCreatedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_app_harmoni_api_mq_v1_comment_comment_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommentCreatedMessage.ProtoReflect.Descriptor instead.
+func (*CommentCreatedMessage) Descriptor() ([]byte, []int) {
+	return file_app_harmoni_api_mq_v1_comment_comment_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CommentCreatedMessage) GetCommentID() int64 {
+	if x != nil {
+		return x.CommentID
+	}
+	return 0
+}
+
+func (x *CommentCreatedMessage) GetObjectID() int64 {
+	if x != nil {
+		return x.ObjectID
+	}
+	return 0
+}
+
+func (x *CommentCreatedMessage) GetObjectAuthorID() int64 {
+	if x != nil {
+		return x.ObjectAuthorID
+	}
+	return 0
+}
+
+func (x *CommentCreatedMessage) GetAuthorID() int64 {
+	if x != nil {
+		return x.AuthorID
+	}
+	return 0
+}
+
+func (x *CommentCreatedMessage) GetParentID() int64 {
+	if x != nil {
+		return x.ParentID
+	}
+	return 0
+}
+
+func (x *CommentCreatedMessage) GetParentAuthorID() int64 {
+	if x != nil {
+		return x.ParentAuthorID
+	}
+	return 0
+}
+
+func (x *CommentCreatedMessage) GetRootID() int64 {
+	if x != nil {
+		return x.RootID
+	}
+	return 0
+}
+
+func (x *CommentCreatedMessage) GetToUserIDs() []int64 {
+	if x != nil {
+		return x.ToUserIDs
+	}
+	return nil
+}
+
+func (x *CommentCreatedMessage) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *CommentCreatedMessage) GetObjectType() ObjectType {
+	if x != nil {
+		return x.ObjectType
+	}
+	return ObjectType_ObjectNo
+}
+
+var File_app_harmoni_api_mq_v1_comment_comment_proto protoreflect.FileDescriptor
+
+var file_app_harmoni_api_mq_v1_comment_comment_proto_rawDesc = []byte{
+	0x0a, 0x2b, 0x61, 0x70, 0x70, 0x2f, 0x68, 0x61, 0x72, 0x6d, 0x6f, 0x6e, 0x69, 0x2f, 0x61, 0x70,
+	0x69, 0x2f, 0x6d, 0x71, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x2f,
+	0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x6d,
+	0x71, 0x2e, 0x76, 0x31, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x1a, 0x1f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x84, 0x03,
+	0x0a, 0x15, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d,
+	0x65, 0x6e, 0x74, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49,
+	0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49,
+	0x44, 0x12, 0x26, 0x0a, 0x0e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x41, 0x75, 0x74, 0x68, 0x6f,
+	0x72, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x6f, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x61, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49,
+	0x44, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49,
+	0x44, 0x12, 0x26, 0x0a, 0x0e, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x41, 0x75, 0x74, 0x68, 0x6f,
+	0x72, 0x49, 0x44, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x70, 0x61, 0x72, 0x65, 0x6e,
+	0x74, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6f,
+	0x74, 0x49, 0x44, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x74, 0x49,
+	0x44, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x6f, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x73, 0x18, 0x08,
+	0x20, 0x03, 0x28, 0x03, 0x52, 0x09, 0x74, 0x6f, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x73, 0x12,
+	0x38, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x6f, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e,
+	0x6d, 0x71, 0x2e, 0x76, 0x31, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x4f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x2a, 0x5c, 0x0a, 0x0a, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4e, 0x6f, 0x10, 0x00,
+	0x12, 0x0e, 0x0a, 0x0a, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x10, 0x01,
+	0x12, 0x11, 0x0a, 0x0d, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x54, 0x61, 0x67,
+	0x10, 0x03, 0x12, 0x0e, 0x0a, 0x0a, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x55, 0x73, 0x65, 0x72,
+	0x10, 0x04, 0x42, 0x2a, 0x5a, 0x28, 0x68, 0x61, 0x72, 0x6d, 0x6f, 0x6e, 0x69, 0x2f, 0x61, 0x70,
+	0x70, 0x2f, 0x68, 0x61, 0x72, 0x6d, 0x6f, 0x6e, 0x69, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6d, 0x71,
+	0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x3b, 0x76, 0x31, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_app_harmoni_api_mq_v1_comment_comment_proto_rawDescOnce sync.Once
+	file_app_harmoni_api_mq_v1_comment_comment_proto_rawDescData = file_app_harmoni_api_mq_v1_comment_comment_proto_rawDesc
+)
+
+func file_app_harmoni_api_mq_v1_comment_comment_proto_rawDescGZIP() []byte {
+	file_app_harmoni_api_mq_v1_comment_comment_proto_rawDescOnce.Do(func() {
+		file_app_harmoni_api_mq_v1_comment_comment_proto_rawDescData = protoimpl.X.CompressGZIP(file_app_harmoni_api_mq_v1_comment_comment_proto_rawDescData)
+	})
+	return file_app_harmoni_api_mq_v1_comment_comment_proto_rawDescData
+}
+
+var file_app_harmoni_api_mq_v1_comment_comment_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_app_harmoni_api_mq_v1_comment_comment_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_app_harmoni_api_mq_v1_comment_comment_proto_goTypes = []interface{}{
+	(ObjectType)(0),               // 0: mq.v1.comment.ObjectType
+	(*CommentCreatedMessage)(nil), // 1: mq.v1.comment.CommentCreatedMessage
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+}
+var file_app_harmoni_api_mq_v1_comment_comment_proto_depIdxs = []int32{
+	2, // 0: mq.v1.comment.CommentCreatedMessage.CreatedAt:type_name -> google.protobuf.Timestamp
+	0, // 1: mq.v1.comment.CommentCreatedMessage.objectType:type_name -> mq.v1.comment.ObjectType
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_app_harmoni_api_mq_v1_comment_comment_proto_init() }
+func file_app_harmoni_api_mq_v1_comment_comment_proto_init() {
+	if File_app_harmoni_api_mq_v1_comment_comment_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_app_harmoni_api_mq_v1_comment_comment_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommentCreatedMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_app_harmoni_api_mq_v1_comment_comment_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_app_harmoni_api_mq_v1_comment_comment_proto_goTypes,
+		DependencyIndexes: file_app_harmoni_api_mq_v1_comment_comment_proto_depIdxs,
+		EnumInfos:         file_app_harmoni_api_mq_v1_comment_comment_proto_enumTypes,
+		MessageInfos:      file_app_harmoni_api_mq_v1_comment_comment_proto_msgTypes,
+	}.Build()
+	File_app_harmoni_api_mq_v1_comment_comment_proto = out.File
+	file_app_harmoni_api_mq_v1_comment_comment_proto_rawDesc = nil
+	file_app_harmoni_api_mq_v1_comment_comment_proto_goTypes = nil
+	file_app_harmoni_api_mq_v1_comment_comment_proto_depIdxs = nil
+}
@@ -131,6 +131,10 @@ type LikeCreatedMessage struct {
 	LikingID     int64                  `protobuf:"varint,4,opt,name=likingID,proto3" json:"likingID,omitempty"`
 	IsCancel     bool                   `protobuf:"varint,5,opt,name=isCancel,proto3" json:"isCancel,omitempty"`
 	CreatedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	IsDown       bool                   `protobuf:"varint,7,opt,name=isDown,proto3" json:"isDown,omitempty"`
+	Switched     bool                   `protobuf:"varint,8,opt,name=switched,proto3" json:"switched,omitempty"`
+	Reaction     string                 `protobuf:"bytes,9,opt,name=reaction,proto3" json:"reaction,omitempty"`
+	Replaced     string                 `protobuf:"bytes,10,opt,name=replaced,proto3" json:"replaced,omitempty"`
 }
 
 func (x *LikeCreatedMessage) Reset() {
@@ -207,6 +211,34 @@ func (x *LikeCreatedMessage) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *LikeCreatedMessage) GetIsDown() bool {
+	if x != nil {
+		return x.IsDown
+	}
+	return false
+}
+
+func (x *LikeCreatedMessage) GetSwitched() bool {
+	if x != nil {
+		return x.Switched
+	}
+	return false
+}
+
+func (x *LikeCreatedMessage) GetReaction() string {
+	if x != nil {
+		return x.Reaction
+	}
+	return ""
+}
+
+func (x *LikeCreatedMessage) GetReplaced() string {
+	if x != nil {
+		return x.Replaced
+	}
+	return ""
+}
+
 var File_app_harmoni_api_mq_v1_like_like_proto protoreflect.FileDescriptor
 
 var file_app_harmoni_api_mq_v1_like_like_proto_rawDesc = []byte{
@@ -219,7 +251,7 @@ var file_app_harmoni_api_mq_v1_like_like_proto_rawDesc = []byte{
 	0x61, 0x67, 0x65, 0x12, 0x30, 0x0a, 0x08, 0x6c, 0x69, 0x6b, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x6d, 0x71, 0x2e, 0x76, 0x31, 0x2e, 0x6c, 0x69,
 	0x6b, 0x65, 0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x6c, 0x69, 0x6b,
-	0x65, 0x54, 0x79, 0x70, 0x65, 0x22, 0xfd, 0x01, 0x0a, 0x12, 0x4c, 0x69, 0x6b, 0x65, 0x43, 0x72,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x22, 0xe9, 0x02, 0x0a, 0x12, 0x4c, 0x69, 0x6b, 0x65, 0x43, 0x72,
 	0x65, 0x61, 0x74, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x39, 0x0a, 0x0b,
 	0x62, 0x61, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x17, 0x2e, 0x6d, 0x71, 0x2e, 0x76, 0x31, 0x2e, 0x6c, 0x69, 0x6b, 0x65, 0x2e, 0x42,
@@ -235,14 +267,21 @@ var file_app_harmoni_api_mq_v1_like_like_proto_rawDesc = []byte{
 	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
 	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
 	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x64, 0x41, 0x74, 0x2a, 0x43, 0x0a, 0x08, 0x4c, 0x69, 0x6b, 0x65, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x0a, 0x0a, 0x06, 0x4c, 0x69, 0x6b, 0x65, 0x4e, 0x6f, 0x10, 0x00, 0x12, 0x0c, 0x0a,
-	0x08, 0x4c, 0x69, 0x6b, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x4c,
-	0x69, 0x6b, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08,
-	0x4c, 0x69, 0x6b, 0x65, 0x55, 0x73, 0x65, 0x72, 0x10, 0x03, 0x42, 0x27, 0x5a, 0x25, 0x68, 0x61,
-	0x72, 0x6d, 0x6f, 0x6e, 0x69, 0x2f, 0x61, 0x70, 0x70, 0x2f, 0x68, 0x61, 0x72, 0x6d, 0x6f, 0x6e,
-	0x69, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6d, 0x71, 0x2f, 0x76, 0x31, 0x2f, 0x6c, 0x69, 0x6b, 0x65,
-	0x3b, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x73, 0x44, 0x6f, 0x77, 0x6e, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x69, 0x73, 0x44, 0x6f, 0x77, 0x6e, 0x12, 0x1a, 0x0a,
+	0x08, 0x73, 0x77, 0x69, 0x74, 0x63, 0x68, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x73, 0x77, 0x69, 0x74, 0x63, 0x68, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65,
+	0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65,
+	0x64, 0x2a, 0x43, 0x0a, 0x08, 0x4c, 0x69, 0x6b, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0a, 0x0a,
+	0x06, 0x4c, 0x69, 0x6b, 0x65, 0x4e, 0x6f, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x4c, 0x69, 0x6b,
+	0x65, 0x50, 0x6f, 0x73, 0x74, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x4c, 0x69, 0x6b, 0x65, 0x43,
+	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x4c, 0x69, 0x6b, 0x65,
+	0x55, 0x73, 0x65, 0x72, 0x10, 0x03, 0x42, 0x27, 0x5a, 0x25, 0x68, 0x61, 0x72, 0x6d, 0x6f, 0x6e,
+	0x69, 0x2f, 0x61, 0x70, 0x70, 0x2f, 0x68, 0x61, 0x72, 0x6d, 0x6f, 0x6e, 0x69, 0x2f, 0x61, 0x70,
+	0x69, 0x2f, 0x6d, 0x71, 0x2f, 0x76, 0x31, 0x2f, 0x6c, 0x69, 0x6b, 0x65, 0x3b, 0x76, 0x31, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
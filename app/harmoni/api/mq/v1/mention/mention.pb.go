@@ -0,0 +1,252 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.21.11
+// source: app/harmoni/api/mq/v1/mention/mention.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ObjectType int32
+
+const (
+	ObjectType_ObjectNo      ObjectType = 0
+	ObjectType_ObjectPost    ObjectType = 1
+	ObjectType_ObjectComment ObjectType = 2
+)
+
+// Enum value maps for ObjectType.
+var (
+	ObjectType_name = map[int32]string{
+		0: "ObjectNo",
+		1: "ObjectPost",
+		2: "ObjectComment",
+	}
+	ObjectType_value = map[string]int32{
+		"ObjectNo":      0,
+		"ObjectPost":    1,
+		"ObjectComment": 2,
+	}
+)
+
+func (x ObjectType) Enum() *ObjectType {
+	p := new(ObjectType)
+	*p = x
+	return p
+}
+
+func (x ObjectType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ObjectType) Descriptor() protoreflect.EnumDescriptor {
+	return file_app_harmoni_api_mq_v1_mention_mention_proto_enumTypes[0].Descriptor()
+}
+
+func (ObjectType) Type() protoreflect.EnumType {
+	return &file_app_harmoni_api_mq_v1_mention_mention_proto_enumTypes[0]
+}
+
+func (x ObjectType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ObjectType.Descriptor instead.
+func (ObjectType) EnumDescriptor() ([]byte, []int) {
+	return file_app_harmoni_api_mq_v1_mention_mention_proto_rawDescGZIP(), []int{0}
+}
+
+type MentionCreatedMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserID           int64                  `protobuf:"varint,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	MentionedUserIDs []int64                `protobuf:"varint,2,rep,packed,name=mentionedUserIDs,proto3" json:"mentionedUserIDs,omitempty"`
+	ObjectType       ObjectType             `protobuf:"varint,3,opt,name=objectType,proto3,enum=mq.v1.mention.ObjectType" json:"objectType,omitempty"`
+	ObjectID         int64                  `protobuf:"varint,4,opt,name=objectID,proto3" json:"objectID,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+}
+
+func (x *MentionCreatedMessage) Reset() {
+	*x = MentionCreatedMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_app_harmoni_api_mq_v1_mention_mention_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MentionCreatedMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MentionCreatedMessage) ProtoMessage() {}
+
+func (x *MentionCreatedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_app_harmoni_api_mq_v1_mention_mention_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MentionCreatedMessage.ProtoReflect.Descriptor instead.
+func (*MentionCreatedMessage) Descriptor() ([]byte, []int) {
+	return file_app_harmoni_api_mq_v1_mention_mention_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MentionCreatedMessage) GetUserID() int64 {
+	if x != nil {
+		return x.UserID
+	}
+	return 0
+}
+
+func (x *MentionCreatedMessage) GetMentionedUserIDs() []int64 {
+	if x != nil {
+		return x.MentionedUserIDs
+	}
+	return nil
+}
+
+func (x *MentionCreatedMessage) GetObjectType() ObjectType {
+	if x != nil {
+		return x.ObjectType
+	}
+	return ObjectType_ObjectNo
+}
+
+func (x *MentionCreatedMessage) GetObjectID() int64 {
+	if x != nil {
+		return x.ObjectID
+	}
+	return 0
+}
+
+func (x *MentionCreatedMessage) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+var File_app_harmoni_api_mq_v1_mention_mention_proto protoreflect.FileDescriptor
+
+var file_app_harmoni_api_mq_v1_mention_mention_proto_rawDesc = []byte{
+	0x0a, 0x2b, 0x61, 0x70, 0x70, 0x2f, 0x68, 0x61, 0x72, 0x6d, 0x6f, 0x6e, 0x69, 0x2f, 0x61, 0x70,
+	0x69, 0x2f, 0x6d, 0x71, 0x2f, 0x76, 0x31, 0x2f, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x2f,
+	0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x6d,
+	0x71, 0x2e, 0x76, 0x31, 0x2e, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x1f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xec, 0x01,
+	0x0a, 0x15, 0x4d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12,
+	0x2a, 0x0a, 0x10, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x65, 0x64, 0x55, 0x73, 0x65, 0x72,
+	0x49, 0x44, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x10, 0x6d, 0x65, 0x6e, 0x74, 0x69,
+	0x6f, 0x6e, 0x65, 0x64, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x6f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x19, 0x2e, 0x6d, 0x71, 0x2e, 0x76, 0x31, 0x2e, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x6f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x49, 0x44, 0x12, 0x38, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x2a, 0x3d, 0x0a, 0x0a,
+	0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x4f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x4e, 0x6f, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x4f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x4f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x10, 0x02, 0x42, 0x2a, 0x5a, 0x28, 0x68,
+	0x61, 0x72, 0x6d, 0x6f, 0x6e, 0x69, 0x2f, 0x61, 0x70, 0x70, 0x2f, 0x68, 0x61, 0x72, 0x6d, 0x6f,
+	0x6e, 0x69, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6d, 0x71, 0x2f, 0x76, 0x31, 0x2f, 0x6d, 0x65, 0x6e,
+	0x74, 0x69, 0x6f, 0x6e, 0x3b, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_app_harmoni_api_mq_v1_mention_mention_proto_rawDescOnce sync.Once
+	file_app_harmoni_api_mq_v1_mention_mention_proto_rawDescData = file_app_harmoni_api_mq_v1_mention_mention_proto_rawDesc
+)
+
+func file_app_harmoni_api_mq_v1_mention_mention_proto_rawDescGZIP() []byte {
+	file_app_harmoni_api_mq_v1_mention_mention_proto_rawDescOnce.Do(func() {
+		file_app_harmoni_api_mq_v1_mention_mention_proto_rawDescData = protoimpl.X.CompressGZIP(file_app_harmoni_api_mq_v1_mention_mention_proto_rawDescData)
+	})
+	return file_app_harmoni_api_mq_v1_mention_mention_proto_rawDescData
+}
+
+var file_app_harmoni_api_mq_v1_mention_mention_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_app_harmoni_api_mq_v1_mention_mention_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_app_harmoni_api_mq_v1_mention_mention_proto_goTypes = []interface{}{
+	(ObjectType)(0),               // 0: mq.v1.mention.ObjectType
+	(*MentionCreatedMessage)(nil), // 1: mq.v1.mention.MentionCreatedMessage
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+}
+var file_app_harmoni_api_mq_v1_mention_mention_proto_depIdxs = []int32{
+	0, // 0: mq.v1.mention.MentionCreatedMessage.objectType:type_name -> mq.v1.mention.ObjectType
+	2, // 1: mq.v1.mention.MentionCreatedMessage.CreatedAt:type_name -> google.protobuf.Timestamp
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_app_harmoni_api_mq_v1_mention_mention_proto_init() }
+func file_app_harmoni_api_mq_v1_mention_mention_proto_init() {
+	if File_app_harmoni_api_mq_v1_mention_mention_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_app_harmoni_api_mq_v1_mention_mention_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MentionCreatedMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_app_harmoni_api_mq_v1_mention_mention_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_app_harmoni_api_mq_v1_mention_mention_proto_goTypes,
+		DependencyIndexes: file_app_harmoni_api_mq_v1_mention_mention_proto_depIdxs,
+		EnumInfos:         file_app_harmoni_api_mq_v1_mention_mention_proto_enumTypes,
+		MessageInfos:      file_app_harmoni_api_mq_v1_mention_mention_proto_msgTypes,
+	}.Build()
+	File_app_harmoni_api_mq_v1_mention_mention_proto = out.File
+	file_app_harmoni_api_mq_v1_mention_mention_proto_rawDesc = nil
+	file_app_harmoni_api_mq_v1_mention_mention_proto_goTypes = nil
+	file_app_harmoni_api_mq_v1_mention_mention_proto_depIdxs = nil
+}
@@ -65,6 +65,12 @@ func main() {
 		cfg.Auth,
 		cfg.Email,
 		cfg.Like,
+		cfg.Post,
+		cfg.Search,
+		cfg.Collection,
+		cfg.Comment,
+		cfg.Timeline,
+		cfg.Follow,
 		cfg.MessageQueue,
 		cfg.FileStorage,
 		cfg.ETCD,
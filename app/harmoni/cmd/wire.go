@@ -40,6 +40,12 @@ func initApplication(
 	authConf *config.Auth,
 	emailConf *config.Email,
 	likeConf *config.Like,
+	postConf *config.Post,
+	searchConf *config.Search,
+	collectionConf *config.Collection,
+	commentConf *config.Comment,
+	timelineConf *config.Timeline,
+	followConf *config.Follow,
 	messageConf *config.MessageQueue,
 	fileConf *config.FileStorage,
 	etcdConf *conf.ETCD,
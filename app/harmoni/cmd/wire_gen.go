@@ -18,6 +18,8 @@ import (
 	"harmoni/app/harmoni/internal/pkg/middleware"
 	"harmoni/app/harmoni/internal/pkg/snowflakex"
 	"harmoni/app/harmoni/internal/repository/auth"
+	"harmoni/app/harmoni/internal/repository/block"
+	"harmoni/app/harmoni/internal/repository/collection"
 	"harmoni/app/harmoni/internal/repository/comment"
 	"harmoni/app/harmoni/internal/repository/email"
 	file2 "harmoni/app/harmoni/internal/repository/file"
@@ -25,6 +27,7 @@ import (
 	"harmoni/app/harmoni/internal/repository/like"
 	"harmoni/app/harmoni/internal/repository/post"
 	"harmoni/app/harmoni/internal/repository/tag"
+	timeline2 "harmoni/app/harmoni/internal/repository/timeline"
 	"harmoni/app/harmoni/internal/repository/unique"
 	user2 "harmoni/app/harmoni/internal/repository/user"
 	"harmoni/app/harmoni/internal/server/grpc"
@@ -32,6 +35,8 @@ import (
 	"harmoni/app/harmoni/internal/server/mq"
 	"harmoni/app/harmoni/internal/service"
 	user3 "harmoni/app/harmoni/internal/service/user"
+	block2 "harmoni/app/harmoni/internal/usecase/block"
+	collection2 "harmoni/app/harmoni/internal/usecase/collection"
 	comment2 "harmoni/app/harmoni/internal/usecase/comment"
 	"harmoni/app/harmoni/internal/usecase/comment/events"
 	email2 "harmoni/app/harmoni/internal/usecase/email"
@@ -41,18 +46,22 @@ import (
 	events2 "harmoni/app/harmoni/internal/usecase/like/events"
 	post2 "harmoni/app/harmoni/internal/usecase/post"
 	events3 "harmoni/app/harmoni/internal/usecase/post/events"
+	"harmoni/app/harmoni/internal/usecase/search"
+	events5 "harmoni/app/harmoni/internal/usecase/search/events"
 	tag2 "harmoni/app/harmoni/internal/usecase/tag"
 	"harmoni/app/harmoni/internal/usecase/timeline"
+	events6 "harmoni/app/harmoni/internal/usecase/timeline/events"
 	"harmoni/app/harmoni/internal/usecase/user"
 	events4 "harmoni/app/harmoni/internal/usecase/user/events"
 	"harmoni/internal/conf"
 	"harmoni/internal/pkg/etcdx"
 	"harmoni/internal/pkg/logger"
+	server2 "harmoni/internal/pkg/server"
 )
 
 // Injectors from wire.go:
 
-func initApplication(conf2 *config.Config, appConf *config.App, dbconf *conf.Database, rdbconf *config.Redis, authConf *config.Auth, emailConf *config.Email, likeConf *config.Like, messageConf *config.MessageQueue, fileConf *config.FileStorage, etcdConf *conf.ETCD, serverConf *conf.ServerCommon, logConf *conf.Log) (*kratos.App, func(), error) {
+func initApplication(conf2 *config.Config, appConf *config.App, dbconf *conf.Database, rdbconf *config.Redis, authConf *config.Auth, emailConf *config.Email, likeConf *config.Like, postConf *config.Post, searchConf *config.Search, collectionConf *config.Collection, commentConf *config.Comment, timelineConf *config.Timeline, followConf *config.Follow, messageConf *config.MessageQueue, fileConf *config.FileStorage, etcdConf *conf.ETCD, serverConf *conf.ServerCommon, logConf *conf.Log) (*kratos.App, func(), error) {
 	zapLogger, err := logger.NewZapLogger(logConf)
 	if err != nil {
 		return nil, nil, err
@@ -98,6 +107,13 @@ func initApplication(conf2 *config.Config, appConf *config.App, dbconf *conf.Dat
 	}
 	fileRepo := file2.NewFileRepository(db, redisClient, uniqueIDRepo, sugaredLogger)
 	fileUseCase := file.NewFileUseCase(appConf, redisClient, fileConf, fileSystem, fileRepo, sugaredLogger)
+	messagePublisher, err := publisher.NewPublisher(messageConf, zapLogger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	jsonPublisher := publisher.NewJSONPublisher(messagePublisher)
 	userUseCase := user.NewUserUseCase(likeRepo, userRepo, authUseCase, fileUseCase, sugaredLogger)
 	accountUsecase := user.NewAccountUsecase(emailConf, authUseCase, userRepo, emailUsecase, userUseCase, zapLogger)
 	accountService := service.NewAccountService(accountUsecase, sugaredLogger)
@@ -105,59 +121,68 @@ func initApplication(conf2 *config.Config, appConf *config.App, dbconf *conf.Dat
 	accountHandler := handler.NewAccountHandler(accountService, jwtAuthMiddleware, sugaredLogger)
 	tagRepo := tag.NewTagRepo(db, redisClient, uniqueIDRepo, sugaredLogger)
 	followRepo := follow.NewFollowRepo(db, userRepo, tagRepo, uniqueIDRepo, sugaredLogger)
+	blockRepo := block.NewBlockRepo(db, sugaredLogger)
 	tagUseCase := tag2.NewTagUseCase(tagRepo, sugaredLogger)
-	followUseCase := follow2.NewFollowUseCase(followRepo, userUseCase, tagUseCase, sugaredLogger)
-	followService := service.NewFollowService(followUseCase, userUseCase, sugaredLogger)
-	followHandler := handler.NewFollowHandler(followService, sugaredLogger)
 	fileService := service.NewFileService(fileUseCase, userUseCase, sugaredLogger)
 	fileHandler := handler.NewFileHandler(fileService, sugaredLogger)
 	userService := user3.NewUserService(userUseCase, authUseCase, accountUsecase, sugaredLogger)
 	userHandler := handler.NewUserHandler(userService)
+	commentRepo := comment.NewCommentRepo(commentConf, db, redisClient, uniqueIDRepo, sugaredLogger)
+	collectionRepo := collection.NewCollectionRepo(collectionConf, db, redisClient, uniqueIDRepo, sugaredLogger)
 	postRepo := post.NewPostRepo(db, redisClient, tagRepo, uniqueIDRepo, sugaredLogger)
-	postUseCase := post2.NewPostUseCase(postRepo, likeRepo, userUseCase, tagUseCase, sugaredLogger)
+	postUseCase := post2.NewPostUseCase(postConf, postRepo, likeRepo, blockRepo, commentRepo, collectionRepo, userUseCase, tagUseCase, jsonPublisher, sugaredLogger)
+	timelineRepo := timeline2.NewTimelineRepo(timelineConf, redisClient, sugaredLogger)
+	timeLinePullUsecase := timeline.NewTimeLineUsecase(timelineConf, followRepo, blockRepo, postRepo, userRepo, timelineRepo, postUseCase, sugaredLogger)
+	followUseCase := follow2.NewFollowUseCase(followRepo, blockRepo, userUseCase, tagUseCase, timeLinePullUsecase, jsonPublisher, sugaredLogger)
+	suggestionRepo := follow.NewSuggestionRepo(followConf, redisClient, sugaredLogger)
+	suggestionUseCase := follow2.NewSuggestionUseCase(followConf, suggestionRepo, followRepo, blockRepo, likeRepo, postRepo, userRepo, sugaredLogger)
+	followService := service.NewFollowService(followUseCase, suggestionUseCase, userUseCase, sugaredLogger)
+	followHandler := handler.NewFollowHandler(followService, sugaredLogger)
 	postService := service.NewPostService(postUseCase, tagUseCase, sugaredLogger)
 	postHandler := handler.NewPostHandler(postService)
 	tagService := service.NewTagService(tagUseCase, sugaredLogger)
 	tagHandler := handler.NewTagHandler(tagService)
-	commentRepo := comment.NewCommentRepo(db, redisClient, uniqueIDRepo, sugaredLogger)
-	commentUseCase := comment2.NewCommentUseCase(commentRepo, likeRepo, userRepo, fileUseCase, sugaredLogger)
+	commentUseCase := comment2.NewCommentUseCase(commentConf, commentRepo, likeRepo, blockRepo, postRepo, tagRepo, followRepo, userRepo, userUseCase, fileUseCase, jsonPublisher, sugaredLogger)
 	commentService := service.NewCommentService(commentUseCase, sugaredLogger)
 	commentHandler := handler.NewCommentHandler(commentService)
-	messagePublisher, err := publisher.NewPublisher(messageConf, zapLogger)
-	if err != nil {
-		cleanup2()
-		cleanup()
-		return nil, nil, err
-	}
-	jsonPublisher := publisher.NewJSONPublisher(messagePublisher)
-	likeUsecase, cleanup3, err := like2.NewLikeUsecase(messageConf, likeRepo, postUseCase, commentRepo, userRepo, sugaredLogger, jsonPublisher)
+	likeUsecase, cleanup3, err := like2.NewLikeUsecase(messageConf, likeConf, likeRepo, blockRepo, postUseCase, commentUseCase, userUseCase, commentRepo, postRepo, userRepo, sugaredLogger, jsonPublisher)
 	if err != nil {
 		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	likeService := service.NewLikeUsecase(likeUsecase, sugaredLogger)
+	likeService := service.NewLikeUsecase(likeUsecase, userUseCase, sugaredLogger)
 	likeHandler := handler.NewLikeHandler(likeService, sugaredLogger)
-	timeLinePullUsecase := timeline.NewTimeLineUsecase(followRepo, postUseCase, sugaredLogger)
 	timeLineService := service.NewTimeLineService(timeLinePullUsecase, sugaredLogger)
 	timeLineHandler := handler.NewTimeLineHandler(timeLineService)
-	harmoniAPIRouter := http.NewHarmoniAPIRouter(accountHandler, followHandler, fileHandler, userHandler, postHandler, tagHandler, commentHandler, likeHandler, timeLineHandler)
+	searchUseCase := search.NewSearchUseCase(searchConf, appConf, postRepo, commentRepo, userUseCase, jsonPublisher, sugaredLogger)
+	searchService := service.NewSearchService(searchUseCase, userUseCase, sugaredLogger)
+	searchHandler := handler.NewSearchHandler(searchService)
+	collectionUseCase := collection2.NewCollectionUseCase(collectionRepo, postUseCase, userUseCase, sugaredLogger)
+	collectionService := service.NewCollectionService(collectionUseCase, sugaredLogger)
+	collectionHandler := handler.NewCollectionHandler(collectionService)
+	blockUseCase := block2.NewBlockUseCase(blockRepo, followRepo, userRepo, followUseCase, jsonPublisher, sugaredLogger)
+	blockService := service.NewBlockService(blockUseCase, userUseCase, sugaredLogger)
+	blockHandler := handler.NewBlockHandler(blockService)
+	harmoniAPIRouter := http.NewHarmoniAPIRouter(accountHandler, followHandler, fileHandler, userHandler, postHandler, tagHandler, commentHandler, likeHandler, timeLineHandler, searchHandler, collectionHandler, blockHandler)
 	fiberServer := http.NewHTTPServer(serverConf, zapLogger, harmoniAPIRouter, jwtAuthMiddleware)
 	loggerLogger := logger.NewLogger(zapLogger)
 	userGRPCService := user3.NewUserGRPCService(authUseCase, fileUseCase, userUseCase)
 	server := grpc.NewGrpcServer(serverConf, loggerLogger, userGRPCService)
-	scheduledTaskManager, cleanup4, err := cron.NewScheduledTaskManager(likeConf, jsonPublisher, likeUsecase, sugaredLogger)
+	scheduledTaskManager, cleanup4, err := cron.NewScheduledTaskManager(likeConf, postConf, jsonPublisher, likeUsecase, postUseCase, searchConf, searchUseCase, collectionConf, collectionUseCase, commentConf, followConf, suggestionUseCase, blockUseCase, sugaredLogger)
 	if err != nil {
 		cleanup3()
 		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	commentEventsHandler := events.NewCommentEventsHandler(commentRepo)
+	commentEventsHandler := events.NewCommentEventsHandler(commentConf, commentRepo, likeRepo)
 	likeEventsHandler := events2.NewLikeEventsHandler(likeRepo)
-	postEventsHandler := events3.NewPostEventsHandler(postRepo)
+	postEventsHandler := events3.NewPostEventsHandler(postConf, postRepo)
 	userEventsHandler := events4.NewUserEventsHandler(userRepo)
-	router, err := mq.NewMQRouter(messageConf, commentEventsHandler, likeEventsHandler, postEventsHandler, userEventsHandler, zapLogger)
+	searchEventsHandler := events5.NewSearchEventsHandler(searchUseCase)
+	timelineEventsHandler := events6.NewTimelineEventsHandler(timeLinePullUsecase)
+	router, err := mq.NewMQRouter(messageConf, appConf, commentEventsHandler, likeEventsHandler, postEventsHandler, userEventsHandler, searchEventsHandler, timelineEventsHandler, zapLogger)
 	if err != nil {
 		cleanup4()
 		cleanup3()
@@ -165,7 +190,7 @@ func initApplication(conf2 *config.Config, appConf *config.App, dbconf *conf.Dat
 		cleanup()
 		return nil, nil, err
 	}
-	mqServer := mq.NewExecutor(router)
+	mqServer := server2.NewMQServer(router)
 	app := newApplication(conf2, client, fiberServer, server, scheduledTaskManager, mqServer, sugaredLogger, loggerLogger)
 	return app, func() {
 		cleanup4()
@@ -4,9 +4,16 @@ import (
 	"context"
 	"harmoni/app/harmoni/internal/entity/like"
 	"harmoni/app/harmoni/internal/infrastructure/config"
+	eventcollection "harmoni/app/harmoni/internal/types/events/collection"
+	eventcomment "harmoni/app/harmoni/internal/types/events/comment"
 	eventlike "harmoni/app/harmoni/internal/types/events/like"
 	"harmoni/app/harmoni/internal/types/iface"
+	blockusecase "harmoni/app/harmoni/internal/usecase/block"
+	collectionusecase "harmoni/app/harmoni/internal/usecase/collection"
+	followusecase "harmoni/app/harmoni/internal/usecase/follow"
 	likeusecase "harmoni/app/harmoni/internal/usecase/like"
+	postusecase "harmoni/app/harmoni/internal/usecase/post"
+	searchusecase "harmoni/app/harmoni/internal/usecase/search"
 	"time"
 
 	"github.com/go-co-op/gocron"
@@ -17,27 +24,58 @@ import (
 var _ transport.Server = (*ScheduledTaskManager)(nil)
 
 type ScheduledTaskManager struct {
-	conf        *config.Like
-	publisher   iface.Publisher
-	scheduler   *gocron.Scheduler
-	likeUsecase *likeusecase.LikeUsecase
-	logger      *zap.SugaredLogger
+	conf          *config.Like
+	postConf      *config.Post
+	publisher     iface.Publisher
+	scheduler     *gocron.Scheduler
+	likeUsecase   *likeusecase.LikeUsecase
+	postUsecase   *postusecase.PostUseCase
+	searchConf    *config.Search
+	searchUsecase *searchusecase.SearchUseCase
+	// 收藏数和点赞数一样先写入缓存，定期同步到数据库
+	collectionConf    *config.Collection
+	collectionUsecase *collectionusecase.CollectionUseCase
+	commentConf       *config.Comment
+	followConf        *config.Follow
+	suggestionUsecase *followusecase.SuggestionUseCase
+	blockUsecase      *blockusecase.BlockUseCase
+	logger            *zap.SugaredLogger
 }
 
 // NewScheduledTaskManager new scheduled task manager
 func NewScheduledTaskManager(
 	conf *config.Like,
+	postConf *config.Post,
 	publisher iface.Publisher,
 	likeUsecase *likeusecase.LikeUsecase,
+	postUsecase *postusecase.PostUseCase,
+	searchConf *config.Search,
+	searchUsecase *searchusecase.SearchUseCase,
+	collectionConf *config.Collection,
+	collectionUsecase *collectionusecase.CollectionUseCase,
+	commentConf *config.Comment,
+	followConf *config.Follow,
+	suggestionUsecase *followusecase.SuggestionUseCase,
+	blockUsecase *blockusecase.BlockUseCase,
 	logger *zap.SugaredLogger,
 ) (*ScheduledTaskManager, func(), error) {
 	s := gocron.NewScheduler(time.Local)
 	manager := &ScheduledTaskManager{
-		conf:        conf,
-		scheduler:   s,
-		publisher:   publisher,
-		likeUsecase: likeUsecase,
-		logger:      logger,
+		conf:              conf,
+		postConf:          postConf,
+		scheduler:         s,
+		publisher:         publisher,
+		likeUsecase:       likeUsecase,
+		postUsecase:       postUsecase,
+		searchConf:        searchConf,
+		searchUsecase:     searchUsecase,
+		collectionConf:    collectionConf,
+		collectionUsecase: collectionUsecase,
+		commentConf:       commentConf,
+		followConf:        followConf,
+		suggestionUsecase: suggestionUsecase,
+		blockUsecase:      blockUsecase,
+		logger:            logger,
 	}
 
 	return manager, func() { manager.Stop(context.Background()) }, nil
@@ -52,7 +90,12 @@ func (s *ScheduledTaskManager) likeCountTask() {
 			s.logger.Errorf("send like count msg to mq failed: %s", err)
 			return
 		}
-		if len(counts) == 0 {
+		dislikeCounts, err := s.likeUsecase.BatchDislikeCount(ctx, likeType)
+		if err != nil {
+			s.logger.Errorf("send like count msg to mq failed: %s", err)
+			return
+		}
+		if len(counts) == 0 && len(dislikeCounts) == 0 {
 			continue
 		}
 
@@ -60,7 +103,8 @@ func (s *ScheduledTaskManager) likeCountTask() {
 			BaseMessage: eventlike.BaseMessage{
 				LikeType: likeType.ToInterEventLikeType(),
 			},
-			Counts: counts,
+			Counts:        counts,
+			DislikeCounts: dislikeCounts,
 		}
 		err = s.publisher.Publish(ctx, eventlike.TopicLikeStore, likeMsg)
 		if err != nil {
@@ -69,11 +113,195 @@ func (s *ScheduledTaskManager) likeCountTask() {
 	}
 }
 
+func (s *ScheduledTaskManager) reconcileLikeCountTask() {
+	s.logger.Debug("start reconcile like counts")
+	drifts, err := s.likeUsecase.Reconcile(context.Background())
+	if err != nil {
+		s.logger.Errorf("reconcile like counts failed: %s", err)
+	}
+	for _, drift := range drifts {
+		if drift.CacheDrifted != 0 || drift.DatabaseDrifted != 0 {
+			s.logger.Infof("reconciled like counts of type %d: scanned %d, cache drifted %d, database drifted %d, total drift %d",
+				drift.Type, drift.Scanned, drift.CacheDrifted, drift.DatabaseDrifted, drift.Drift)
+		}
+	}
+}
+
+// rebuildObjectFilterTask 启动时立即执行，在此之前所有对象都按可能存在处理
+func (s *ScheduledTaskManager) rebuildObjectFilterTask() {
+	s.logger.Debug("start rebuild object filter")
+	err := s.likeUsecase.RebuildObjectFilter(context.Background())
+	if err != nil {
+		s.logger.Errorf("rebuild object filter failed: %s", err)
+	}
+}
+
+func (s *ScheduledTaskManager) collectCountTask() {
+	s.logger.Debug("start save collect counts to DB")
+	ctx := context.Background()
+	counts, err := s.collectionUsecase.BatchCollectCount(ctx)
+	if err != nil {
+		s.logger.Errorf("send collect count msg to mq failed: %s", err)
+		return
+	}
+	if len(counts) == 0 {
+		return
+	}
+
+	err = s.publisher.Publish(ctx, eventcollection.TopicCollectStore, &eventcollection.CollectStoreMessage{
+		Counts: counts,
+	})
+	if err != nil {
+		s.logger.Errorf("send collect count msg to mq failed: %s", err)
+	}
+}
+
+func (s *ScheduledTaskManager) commentCountTask() {
+	s.logger.Debug("start save comment counts to DB")
+	ctx := context.Background()
+	counts, err := s.postUsecase.BatchCommentCount(ctx)
+	if err != nil {
+		s.logger.Errorf("send comment count msg to mq failed: %s", err)
+		return
+	}
+	if len(counts) == 0 {
+		return
+	}
+
+	err = s.publisher.Publish(ctx, eventcomment.TopicCommentStore, &eventcomment.CommentStoreMessage{
+		Counts: counts,
+	})
+	if err != nil {
+		s.logger.Errorf("send comment count msg to mq failed: %s", err)
+	}
+}
+
+func (s *ScheduledTaskManager) reconcileCommentCountTask() {
+	s.logger.Debug("start reconcile comment counts")
+	reconciled, err := s.postUsecase.ReconcileCommentCount(context.Background())
+	if err != nil {
+		s.logger.Errorf("reconcile comment counts failed: %s", err)
+		return
+	}
+	if reconciled != 0 {
+		s.logger.Infof("reconciled comment counts of %d posts", reconciled)
+	}
+}
+
+func (s *ScheduledTaskManager) purgeTrashTask() {
+	s.logger.Debug("start purge expired posts in trash")
+	purged, err := s.postUsecase.PurgeTrash(context.Background())
+	if err != nil {
+		s.logger.Errorf("purge trash failed: %s", err)
+	}
+	if purged != 0 {
+		s.logger.Infof("purged %d posts from trash", purged)
+	}
+}
+
+func (s *ScheduledTaskManager) publishScheduledTask() {
+	published, err := s.postUsecase.PublishScheduled(context.Background())
+	if err != nil {
+		s.logger.Errorf("publish scheduled posts failed: %s", err)
+	}
+	if published != 0 {
+		s.logger.Infof("published %d scheduled posts", published)
+	}
+}
+
+// hotRankTask 启动时立即执行一次，将已有的帖子放入热榜
+func (s *ScheduledTaskManager) hotRankTask() {
+	s.logger.Debug("start rebuild hot rank")
+	rebuilt, err := s.postUsecase.RebuildHotRank(context.Background())
+	if err != nil {
+		s.logger.Errorf("rebuild hot rank failed: %s", err)
+		return
+	}
+	s.logger.Infof("hot rank rebuilt with %d posts", rebuilt)
+}
+
+// reindexTask 启动时立即执行一次，构建初始的搜索索引
+func (s *ScheduledTaskManager) reindexTask() {
+	s.logger.Debug("start rebuild search index")
+	reply, err := s.searchUsecase.Reindex(context.Background())
+	if err != nil {
+		s.logger.Errorf("rebuild search index failed: %s", err)
+		return
+	}
+	s.logger.Infof("search index rebuilt with %d posts and %d comments", reply.Posts, reply.Comments)
+}
+
+// suggestionTask 启动时立即执行一次，在此之前所有用户都推荐粉丝最多的用户
+func (s *ScheduledTaskManager) suggestionTask() {
+	s.logger.Debug("start rebuild follow suggestions")
+	rebuilt, err := s.suggestionUsecase.Rebuild(context.Background())
+	if err != nil {
+		s.logger.Errorf("rebuild follow suggestions failed after %d users: %s", rebuilt, err)
+		return
+	}
+	s.logger.Infof("follow suggestions rebuilt for %d users", rebuilt)
+}
+
+func (s *ScheduledTaskManager) muteRelayTask() {
+	relayed, err := s.blockUsecase.RelayMuteEvents(context.Background())
+	if err != nil {
+		s.logger.Errorf("relay mute events failed after %d events: %s", relayed, err)
+		return
+	}
+	if relayed > 0 {
+		s.logger.Infof("relayed %d mute events", relayed)
+	}
+}
+
 func (s *ScheduledTaskManager) Start(context.Context) error {
 	_, err := s.scheduler.Every(s.conf.DatabaseSyncInterval).Do(s.likeCountTask)
 	if err != nil {
 		return err
 	}
+	_, err = s.scheduler.Every(s.conf.ReconcileInterval).Do(s.reconcileLikeCountTask)
+	if err != nil {
+		return err
+	}
+	_, err = s.scheduler.Every(s.conf.ObjectFilterRebuildInterval).Do(s.rebuildObjectFilterTask)
+	if err != nil {
+		return err
+	}
+	_, err = s.scheduler.Every(s.collectionConf.DatabaseSyncInterval).Do(s.collectCountTask)
+	if err != nil {
+		return err
+	}
+	_, err = s.scheduler.Every(s.commentConf.DatabaseSyncInterval).Do(s.commentCountTask)
+	if err != nil {
+		return err
+	}
+	_, err = s.scheduler.Every(s.commentConf.ReconcileInterval).Do(s.reconcileCommentCountTask)
+	if err != nil {
+		return err
+	}
+	_, err = s.scheduler.Every(s.postConf.TrashPurgeInterval).Do(s.purgeTrashTask)
+	if err != nil {
+		return err
+	}
+	_, err = s.scheduler.Every(s.postConf.SchedulePublishInterval).Do(s.publishScheduledTask)
+	if err != nil {
+		return err
+	}
+	_, err = s.scheduler.Every(s.postConf.HotRebuildInterval).Do(s.hotRankTask)
+	if err != nil {
+		return err
+	}
+	_, err = s.scheduler.Every(s.searchConf.ReindexInterval).Do(s.reindexTask)
+	if err != nil {
+		return err
+	}
+	_, err = s.scheduler.Every(s.followConf.SuggestionInterval).Do(s.suggestionTask)
+	if err != nil {
+		return err
+	}
+	_, err = s.scheduler.Every(s.followConf.MuteRelayInterval).Do(s.muteRelayTask)
+	if err != nil {
+		return err
+	}
 	s.scheduler.StartAsync()
 	return nil
 }
@@ -0,0 +1,35 @@
+package block
+
+import (
+	"harmoni/app/harmoni/internal/entity"
+	"harmoni/app/harmoni/internal/entity/paginator"
+	userentity "harmoni/app/harmoni/internal/entity/user"
+)
+
+type BlockRequest struct {
+	UserID   int64 `json:"-"`
+	TargetID int64 `json:"target_id,string" validate:"required"`
+	IsCancel bool  `json:"is_cancel,omitempty"`
+}
+
+type BlockReply struct {
+}
+
+type MuteRequest struct {
+	UserID   int64 `json:"-"`
+	TargetID int64 `json:"target_id,string" validate:"required"`
+	IsCancel bool  `json:"is_cancel,omitempty"`
+}
+
+type MuteReply struct {
+}
+
+type GetBlocksRequest struct {
+	entity.PageCond
+	UserID int64     `json:"-"`
+	Type   BlockType `query:"type" validate:"required,oneof=1 2" label:"类型"`
+}
+
+type GetBlocksReply struct {
+	paginator.Page[userentity.UserBasicInfo]
+}
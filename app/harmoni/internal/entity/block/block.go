@@ -0,0 +1,79 @@
+package block
+
+import (
+	"context"
+	"harmoni/app/harmoni/internal/entity/paginator"
+	"time"
+)
+
+type BlockType uint8
+
+const (
+	// BlockUser 拉黑，对方不能关注、评论、点赞和提及自己，已有的关注双向解除
+	BlockUser BlockType = iota + 1
+	// MuteUser 静音，不再看到对方的帖子、评论和提醒，对方不会察觉
+	MuteUser
+)
+
+type Block struct {
+	ID        uint `gorm:"primarykey;type:BIGINT UNSIGNED not NULL AUTO_INCREMENT;"`
+	CreatedAt time.Time
+	UserID    int64     `gorm:"not null;uniqueIndex:idx_block_user,priority:1"`
+	BlockType BlockType `gorm:"not null;type:TINYINT UNSIGNED;uniqueIndex:idx_block_user,priority:2;index:idx_block_target,priority:2"`
+	TargetID  int64     `gorm:"not null;uniqueIndex:idx_block_user,priority:3;index:idx_block_target,priority:1"`
+}
+
+func (*Block) TableName() string {
+	return "block"
+}
+
+// MuteEvent 静音变化的发件箱，和静音关系在同一事务中写入，由定时任务按顺序发送给通知服务
+type MuteEvent struct {
+	ID        uint64 `gorm:"primarykey"`
+	CreatedAt time.Time
+	UserID    int64 `gorm:"not null"`
+	TargetID  int64 `gorm:"not null"`
+	IsCancel  bool  `gorm:"not null"`
+}
+
+func (*MuteEvent) TableName() string {
+	return "block_mute_outbox"
+}
+
+// ExcludeUsers 去掉 userIDs 中被屏蔽的用户
+func ExcludeUsers(userIDs []int64, excluded []int64) []int64 {
+	if len(excluded) == 0 {
+		return userIDs
+	}
+	set := make(map[int64]bool, len(excluded))
+	for _, userID := range excluded {
+		set[userID] = true
+	}
+	kept := make([]int64, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if !set[userID] {
+			kept = append(kept, userID)
+		}
+	}
+	return kept
+}
+
+type BlockRepository interface {
+	Create(ctx context.Context, block *Block) error
+	Delete(ctx context.Context, block *Block) error
+	// Exists userID 是否以 blockType 屏蔽了 targetID
+	Exists(ctx context.Context, userID, targetID int64, blockType BlockType) (bool, error)
+	// ListBlockers userIDs 中以 blockType 屏蔽了 targetID 的用户
+	ListBlockers(ctx context.Context, userIDs []int64, targetID int64, blockType BlockType) ([]int64, error)
+	// ListTargetIDs 用户以 blockType 屏蔽的所有用户
+	ListTargetIDs(ctx context.Context, userID int64, blockType BlockType) ([]int64, error)
+	// BatchListTargetIDs 每个用户以 blockType 屏蔽的所有用户
+	BatchListTargetIDs(ctx context.Context, userIDs []int64, blockType BlockType) (map[int64][]int64, error)
+	// BatchListBlockersOf 以 blockType 屏蔽了每个用户的所有用户
+	BatchListBlockersOf(ctx context.Context, targetIDs []int64, blockType BlockType) (map[int64][]int64, error)
+	GetPage(ctx context.Context, query *BlockQuery) (paginator.Page[int64], error)
+	// ListMuteEvents 按写入顺序列出还未发送的静音变化
+	ListMuteEvents(ctx context.Context, limit int) ([]*MuteEvent, error)
+	// DeleteMuteEvents 删除已发送的静音变化
+	DeleteMuteEvents(ctx context.Context, ids []uint64) error
+}
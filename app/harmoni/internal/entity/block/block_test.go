@@ -0,0 +1,29 @@
+package block
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExcludeUsers(t *testing.T) {
+	tests := []struct {
+		name     string
+		userIDs  []int64
+		excluded []int64
+		want     []int64
+	}{
+		{"nothing excluded", []int64{1, 2, 3}, nil, []int64{1, 2, 3}},
+		{"no users", nil, []int64{1}, []int64{}},
+		{"some excluded", []int64{1, 2, 3, 4}, []int64{2, 4}, []int64{1, 3}},
+		{"all excluded", []int64{1, 2}, []int64{1, 2, 3}, []int64{}},
+		{"excluded not present", []int64{1, 2}, []int64{5}, []int64{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExcludeUsers(tt.userIDs, tt.excluded)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExcludeUsers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package block
+
+import "harmoni/app/harmoni/internal/entity"
+
+type BlockQuery struct {
+	entity.PageCond
+	UserID    int64
+	BlockType BlockType
+}
@@ -0,0 +1,85 @@
+package collection
+
+import (
+	"harmoni/app/harmoni/internal/entity"
+	"harmoni/app/harmoni/internal/entity/paginator"
+	postentity "harmoni/app/harmoni/internal/entity/post"
+)
+
+type CreateCollectionRequest struct {
+	UserID      int64  `json:"-"`
+	Name        string `json:"name" validate:"required,lte=64" label:"收藏夹名称"`
+	Description string `json:"description" validate:"lte=256" label:"收藏夹描述"`
+	Public      bool   `json:"public"`
+}
+
+type CreateCollectionReply struct {
+	CollectionInfo
+}
+
+type UpdateCollectionRequest struct {
+	CollectionID int64  `params:"id" validate:"required" label:"收藏夹ID"`
+	UserID       int64  `json:"-"`
+	Name         string `json:"name" validate:"required,lte=64" label:"收藏夹名称"`
+	Description  string `json:"description" validate:"lte=256" label:"收藏夹描述"`
+	Public       bool   `json:"public"`
+}
+
+type UpdateCollectionReply struct {
+	CollectionInfo
+}
+
+type DeleteCollectionRequest struct {
+	CollectionID int64 `params:"id" validate:"required" label:"收藏夹ID"`
+	UserID       int64 `json:"-"`
+}
+
+type DeleteCollectionReply struct {
+}
+
+type GetCollectionRequest struct {
+	CollectionID int64 `params:"id" validate:"required" label:"收藏夹ID"`
+	UserID       int64 `json:"-"`
+}
+
+type GetCollectionReply struct {
+	CollectionInfo
+}
+
+type GetCollectionsRequest struct {
+	entity.PageCond
+	UserID       int64 `json:"-"`
+	TargetUserID int64 `query:"user_id" validate:"required" label:"用户ID"`
+}
+
+type GetCollectionsReply struct {
+	*paginator.Page[CollectionInfo] `json:"collections"`
+}
+
+type CollectPostRequest struct {
+	CollectionID int64 `params:"id" validate:"required" label:"收藏夹ID"`
+	UserID       int64 `json:"-"`
+	PostID       int64 `json:"post_id,string" validate:"required" label:"帖子ID"`
+}
+
+type CollectPostReply struct {
+}
+
+type UncollectPostRequest struct {
+	CollectionID int64 `params:"id" validate:"required" label:"收藏夹ID"`
+	UserID       int64 `json:"-"`
+	PostID       int64 `params:"post_id" validate:"required" label:"帖子ID"`
+}
+
+type UncollectPostReply struct {
+}
+
+type GetCollectionPostsRequest struct {
+	entity.PageCond
+	CollectionID int64 `params:"id" validate:"required" label:"收藏夹ID"`
+	UserID       int64 `json:"-"`
+}
+
+type GetCollectionPostsReply struct {
+	*paginator.Page[postentity.PostBasicInfo] `json:"posts"`
+}
@@ -0,0 +1,83 @@
+package collection
+
+import (
+	"context"
+	"harmoni/app/harmoni/internal/entity"
+	"harmoni/app/harmoni/internal/entity/paginator"
+	"harmoni/app/harmoni/internal/entity/user"
+	"time"
+)
+
+// Collection 用户创建的收藏夹，公开的收藏夹其他用户也可以查看
+type Collection struct {
+	entity.BaseModelWithNoSoftDelete
+	CollectionID int64  `gorm:"uniqueIndex"`
+	UserID       int64  `gorm:"not null;index"`
+	Name         string `gorm:"type:varchar(64);not null"`
+	Description  string `gorm:"type:varchar(256)"`
+	Public       bool   `gorm:"not null;default:0"`
+	PostCount    int64  `gorm:"not null;default:0"`
+}
+
+func (Collection) TableName() string {
+	return "collection"
+}
+
+// CollectionItem 收藏夹中的帖子，同一帖子可以放入同一用户的多个收藏夹
+type CollectionItem struct {
+	entity.BaseModelWithNoSoftDelete
+	CollectionID int64 `gorm:"not null;uniqueIndex:idx_collection_post"`
+	PostID       int64 `gorm:"not null;uniqueIndex:idx_collection_post;index:idx_post_user"`
+	UserID       int64 `gorm:"not null;index:idx_post_user"`
+}
+
+func (CollectionItem) TableName() string {
+	return "collection_item"
+}
+
+type CollectionInfo struct {
+	CollectionID int64               `json:"collection_id,string"`
+	User         *user.UserBasicInfo `json:"user_info"`
+	Name         string              `json:"name"`
+	Description  string              `json:"description"`
+	Public       bool                `json:"public"`
+	PostCount    int64               `json:"post_count"`
+	CreatedAt    time.Time           `json:"created_at"`
+	UpdatedAt    time.Time           `json:"updated_at"`
+}
+
+func (c *Collection) ToInfo() CollectionInfo {
+	return CollectionInfo{
+		CollectionID: c.CollectionID,
+		User: &user.UserBasicInfo{
+			UserID: c.UserID,
+		},
+		Name:        c.Name,
+		Description: c.Description,
+		Public:      c.Public,
+		PostCount:   c.PostCount,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
+
+type CollectionRepository interface {
+	Create(ctx context.Context, collection *Collection) error
+	Update(ctx context.Context, collection *Collection) error
+	// Delete 删除收藏夹及其中的帖子
+	Delete(ctx context.Context, collection *Collection) error
+	GetByCollectionID(ctx context.Context, collectionID int64) (*Collection, bool, error)
+	GetPageByUserID(ctx context.Context, userID int64, publicOnly bool, pageCond *entity.PageCond) (paginator.Page[Collection], error)
+	AddPost(ctx context.Context, item *CollectionItem) error
+	RemovePost(ctx context.Context, item *CollectionItem) error
+	// GetPostIDPage 收藏夹中的帖子ID，最近收藏的在前
+	GetPostIDPage(ctx context.Context, collectionID int64, pageCond *entity.PageCond) (paginator.Page[int64], error)
+	// BatchIsCollected 帖子是否在用户的任一收藏夹中
+	BatchIsCollected(ctx context.Context, userID int64, postIDs []int64) (map[int64]bool, error)
+	// BatchCollectCountByIDs 收藏帖子的用户数，优先从缓存读取
+	BatchCollectCountByIDs(ctx context.Context, postIDs []int64) (map[int64]int64, error)
+	// BatchCollectCount 缓存中所有帖子的收藏数，用于同步到数据库
+	BatchCollectCount(ctx context.Context) (map[int64]int64, error)
+	// DeleteByPostIDs 从所有收藏夹中移除帖子
+	DeleteByPostIDs(ctx context.Context, postIDs []int64) error
+}
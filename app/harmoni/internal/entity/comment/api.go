@@ -4,26 +4,30 @@ import (
 	"harmoni/app/harmoni/internal/entity"
 	"harmoni/app/harmoni/internal/entity/paginator"
 	"harmoni/app/harmoni/internal/entity/user"
+	"harmoni/internal/types/object"
 )
 
 type CreateCommentRequest struct {
 	UserID int64 `json:"-"`
 	// object id
-	ObjectID  int64   `json:"oid,string" validate:"required" label:"对象ID"`
-	ParentID  int64   `json:"pid,string"`
-	RootID    int64   `json:"rid,string"`
-	Content   string  `json:"content" validate:"required,gte=10,lte=512" label:"评论内容"`
-	ToMembers []int64 `json:"to_members"`
+	ObjectID int64 `json:"oid,string" validate:"required" label:"对象ID"`
+	// object type, posts by default
+	ObjectType object.ObjectType `json:"otype" validate:"omitempty,oneof=1 3 4" label:"对象类型"`
+	ParentID   int64             `json:"pid,string"`
+	RootID     int64             `json:"rid,string"`
+	Content    string            `json:"content" validate:"required,gte=10,lte=512" label:"评论内容"`
+	ToMembers  []int64           `json:"to_members"`
 }
 
 func (r *CreateCommentRequest) ToDomain() *Comment {
 	comment := Comment{
-		Author:    &user.UserBasicInfo{UserID: r.UserID},
-		ParentID:  r.ParentID,
-		RootID:    r.RootID,
-		Content:   r.Content,
-		ObjectID:  r.ObjectID,
-		ToMembers: make([]*user.UserBasicInfo, 0, len(r.ToMembers)),
+		Author:     &user.UserBasicInfo{UserID: r.UserID},
+		ParentID:   r.ParentID,
+		RootID:     r.RootID,
+		Content:    r.Content,
+		ObjectID:   r.ObjectID,
+		ObjectType: orPost(r.ObjectType),
+		ToMembers:  make([]*user.UserBasicInfo, 0, len(r.ToMembers)),
 	}
 	toMemberMap := map[int64]bool{}
 	for _, toMember := range r.ToMembers {
@@ -44,11 +48,14 @@ type GetCommentsRequest struct {
 	Page     int64 `query:"page"`
 	PageSize int64 `query:"page_size"`
 	// object id
-	ObjectID string `query:"oid" validate:"required" label:"对象ID"`
+	ObjectID int64 `query:"oid" validate:"required" label:"对象ID"`
+	// object type, posts by default
+	ObjectType object.ObjectType `query:"otype" validate:"omitempty,oneof=1 3 4" label:"对象类型"`
 	// root id
 	RootID int64 `query:"rid" label:"根ID"`
 	// query condition
-	QueryCond string `query:"cond" validate:"omitempty,oneof=newest" label:"排序"`
+	QueryCond string `query:"cond" validate:"omitempty,oneof=newest hot best" label:"排序"`
+	UserID    int64  `json:"-"`
 }
 
 type GetCommentsReply struct {
@@ -61,8 +68,86 @@ func ConvertPageReqToCommentQuery(req *GetCommentsRequest) CommentQuery {
 			Page:     req.Page,
 			PageSize: req.PageSize,
 		},
-		ObjectID:  req.ObjectID,
-		RootID:    req.RootID,
-		QueryCond: req.QueryCond,
+		ObjectID:   req.ObjectID,
+		ObjectType: orPost(req.ObjectType),
+		RootID:     req.RootID,
+		QueryCond:  req.QueryCond,
+		UserID:     req.UserID,
 	}
 }
+
+type GetCommentRepliesRequest struct {
+	// root comment id
+	CommentID int64 `params:"id" validate:"required" label:"评论ID"`
+	Cursor    int64 `query:"cursor"`
+	Limit     int   `query:"limit" validate:"omitempty,lte=50" label:"每页数量"`
+	UserID    int64 `json:"-"`
+}
+
+type GetCommentRepliesReply struct {
+	paginator.CursorPage[*Comment]
+}
+
+type GetCommentDialogRequest struct {
+	// 对话中任意一条评论的ID
+	CommentID int64 `params:"id" validate:"required" label:"评论ID"`
+	Cursor    int64 `query:"cursor"`
+	Limit     int   `query:"limit" validate:"omitempty,lte=50" label:"每页数量"`
+	UserID    int64 `json:"-"`
+}
+
+type GetCommentDialogReply struct {
+	paginator.CursorPage[*Comment]
+}
+
+type UpdateCommentRequest struct {
+	CommentID int64  `params:"id" validate:"required" label:"评论ID"`
+	UserID    int64  `json:"-"`
+	Content   string `json:"content" validate:"required,gte=10,lte=512" label:"评论内容"`
+}
+
+type UpdateCommentReply struct {
+	Comment
+}
+
+type DeleteCommentRequest struct {
+	CommentID int64 `params:"id" validate:"required" label:"评论ID"`
+	UserID    int64 `json:"-"`
+	// 彻底删除，仅版主可用
+	Hard bool `query:"hard"`
+}
+
+type DeleteCommentReply struct {
+}
+
+type GetCommentAreaRequest struct {
+	ObjectID   int64             `query:"oid" validate:"required" label:"对象ID"`
+	ObjectType object.ObjectType `query:"otype" validate:"omitempty,oneof=1 3 4" label:"对象类型"`
+}
+
+func (r *GetCommentAreaRequest) ToObjectType() object.ObjectType {
+	return orPost(r.ObjectType)
+}
+
+type GetCommentAreaReply struct {
+	Area
+}
+
+type UpdateCommentAreaRequest struct {
+	UserID     int64             `json:"-"`
+	ObjectID   int64             `json:"oid,string" validate:"required" label:"对象ID"`
+	ObjectType object.ObjectType `json:"otype" validate:"omitempty,oneof=1 3 4" label:"对象类型"`
+	Status     AreaStatus        `json:"status" validate:"oneof=0 1 2" label:"评论区状态"`
+}
+
+func (r *UpdateCommentAreaRequest) ToDomain() *Area {
+	return &Area{
+		ObjectID:   r.ObjectID,
+		ObjectType: orPost(r.ObjectType),
+		Status:     r.Status,
+	}
+}
+
+type UpdateCommentAreaReply struct {
+	Area
+}
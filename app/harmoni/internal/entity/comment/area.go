@@ -0,0 +1,35 @@
+package comment
+
+import "harmoni/internal/types/object"
+
+type AreaStatus int8
+
+const (
+	AreaOpen          AreaStatus = iota // 所有人可以评论
+	AreaClosed                          // 关闭评论
+	AreaFollowersOnly                   // 只有关注对象作者的用户可以评论
+)
+
+// Area 评论区，由所属对象的ID和类型确定
+type Area struct {
+	ObjectID   int64             `json:"oid,string"`
+	ObjectType object.ObjectType `json:"otype"`
+	Status     AreaStatus        `json:"status"`
+}
+
+// orPost 没有指定对象类型的请求沿用只有帖子评论区时的行为
+func orPost(objectType object.ObjectType) object.ObjectType {
+	if objectType == 0 {
+		return object.ObjectTypePost
+	}
+	return objectType
+}
+
+// IsAreaObjectType 可以拥有评论区的对象，评论下的讨论使用回复
+func IsAreaObjectType(objectType object.ObjectType) bool {
+	switch objectType {
+	case object.ObjectTypePost, object.ObjectTypeTag, object.ObjectTypeUser:
+		return true
+	}
+	return false
+}
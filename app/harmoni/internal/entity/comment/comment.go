@@ -4,24 +4,40 @@ import (
 	"context"
 	"harmoni/app/harmoni/internal/entity/paginator"
 	"harmoni/app/harmoni/internal/entity/user"
+	"harmoni/internal/types/object"
 	"html"
 	"time"
 )
 
 type Comment struct {
-	CommentID int64                 `json:"cid,string"`
-	ObjectID  int64                 `json:"oid,string"`
-	Author    *user.UserBasicInfo   `json:"author"`
-	ToMembers []*user.UserBasicInfo `json:"toMembers"`
-	ParentID  int64                 `json:"pid,string"`
-	RootID    int64                 `json:"rid,string"`
-	Content   string                `json:"content"`
-	LikeCount int64                 `json:"like_count"`
-	CreatedAt time.Time             `json:"created_at"`
-	UpdatedAt time.Time             `json:"updated_at"`
-	Children  []*Comment            `json:"replies"`
+	CommentID int64 `json:"cid,string"`
+	ObjectID  int64 `json:"oid,string"`
+	// ObjectType 评论区所属对象的类型
+	ObjectType object.ObjectType     `json:"otype"`
+	Author     *user.UserBasicInfo   `json:"author"`
+	ToMembers  []*user.UserBasicInfo `json:"toMembers"`
+	ParentID   int64                 `json:"pid,string"`
+	RootID     int64                 `json:"rid,string"`
+	DialogID   int64                 `json:"did,string"`
+	Content    string                `json:"content"`
+	// RenderedContent 提及的用户替换为用户主页链接后的内容
+	RenderedContent string                `json:"rendered_content,omitempty"`
+	Mentions        []*user.UserBasicInfo `json:"mentions"`
+	LikeCount       int64                 `json:"like_count"`
+	DislikeCount    int64                 `json:"dislike_count"`
+	// Score 赞数减去踩数
+	Score int64 `json:"score"`
+	// Vote 当前用户的投票，1 为赞，-1 为踩，0 为未投票
+	Vote      int8       `json:"vote"`
+	Deleted   bool       `json:"deleted"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	Children  []*Comment `json:"replies"`
 }
 
+// NewDialog 创建评论时作为 DialogID，表示以评论自身的ID开启新对话
+const NewDialog int64 = -1
+
 type CommentList []*Comment
 
 func (l CommentList) ToRooIDMap() map[int64][]*Comment {
@@ -36,11 +52,61 @@ func (c *Comment) EscapeContent() {
 	c.Content = html.EscapeString(c.Content)
 }
 
+// Hide 已删除的评论只保留在回复关系中的位置，不展示内容和作者
+func (c *Comment) Hide() {
+	c.Author = &user.UserBasicInfo{}
+	c.ToMembers = []*user.UserBasicInfo{}
+	c.Mentions = []*user.UserBasicInfo{}
+	c.Content = ""
+	c.RenderedContent = ""
+}
+
 type CommentRepository interface {
 	Create(ctx context.Context, comment *Comment) error
 	GetByCommentID(ctx context.Context, commentID int64) (*Comment, bool, error)
+	// Update 修改评论内容和提及的用户
+	Update(ctx context.Context, comment *Comment) error
+	// Tombstone 删除评论内容并保留占位，评论已删除时返回 false
+	Tombstone(ctx context.Context, comment *Comment) (bool, error)
+	// HardDelete 彻底删除评论，根评论连同整个回复串一起删除，返回被删除的评论
+	HardDelete(ctx context.Context, comment *Comment) ([]*Comment, error)
+	// ListByObjectID 列出帖子下的所有评论
+	ListByObjectID(ctx context.Context, objectID int64) ([]*Comment, error)
+	// ListAfter 按评论ID顺序遍历所有评论
+	ListAfter(ctx context.Context, afterCommentID int64, limit int) ([]*Comment, error)
+	// CountByObjectIDs 统计每个帖子下未删除的评论数
+	CountByObjectIDs(ctx context.Context, objectIDs []int64) (map[int64]int64, error)
+	// BatchCommentCountByObjectIDs 优先从缓存读取评论数，未缓存的从数据库统计后写入缓存
+	BatchCommentCountByObjectIDs(ctx context.Context, objectIDs []int64) (map[int64]int64, error)
+	// BatchCommentCount 缓存中的所有评论数，用于同步到数据库
+	BatchCommentCount(ctx context.Context) (map[int64]int64, error)
+	// ResetCommentCounts 用重新统计的结果覆盖缓存中的评论数
+	ResetCommentCounts(ctx context.Context, counts map[int64]int64) error
+	// CountReplies 统计每个根评论下未删除的回复数
+	CountReplies(ctx context.Context, rootIDs []int64) (map[int64]int64, error)
+	GetByCommentIDs(ctx context.Context, commentIDs []int64) ([]*Comment, error)
+	// ListByObjectAndRootID 列出对象下同一层级的所有评论，用于构建排序
+	ListByObjectAndRootID(ctx context.Context, objectID int64, objectType object.ObjectType, rootID int64) ([]*Comment, error)
+	// RankExists 对象下同一层级评论的排序是否已经建立
+	RankExists(ctx context.Context, objectID int64, objectType object.ObjectType, rootID int64) (bool, error)
+	// ResetRanks 用完整的排序分覆盖对象下同一层级评论的排序
+	ResetRanks(ctx context.Context, objectID int64, objectType object.ObjectType, rootID int64, ranks []Rank) error
+	// UpdateRank 更新评论在已建立的排序中的分数
+	UpdateRank(ctx context.Context, comment *Comment, rank Rank) error
+	// ListRankedIDs 按分数从高到低读取排序中 [start, stop] 范围的评论ID和排序中的评论总数
+	ListRankedIDs(ctx context.Context, order string, objectID int64, objectType object.ObjectType, rootID int64, start, stop int64) ([]int64, int64, error)
 	ListNSubComments(ctx context.Context, rootID []int64) ([]*Comment, error)
+	// ListByRootID 按评论ID顺序列出根评论下 afterCommentID 之后的回复
+	ListByRootID(ctx context.Context, rootID int64, afterCommentID int64, limit int) ([]*Comment, error)
+	// ListByDialogID 按评论ID顺序列出对话中 afterCommentID 之后的评论，包括对话第一条回复所回复的评论
+	ListByDialogID(ctx context.Context, dialogID int64, afterCommentID int64, limit int) ([]*Comment, error)
 	GetLikeCount(ctx context.Context, commentID int64) (int64, bool, error)
 	UpdateLikeCount(ctx context.Context, commentID int64, count int64) error
+	UpdateDislikeCount(ctx context.Context, commentID int64, count int64) error
 	List(ctx context.Context, commentQuery *CommentQuery) (paginator.Page[*Comment], error)
+	// DeleteByObjectIDs 彻底删除帖子下的所有评论，返回被删除的评论ID
+	DeleteByObjectIDs(ctx context.Context, objectIDs []int64) ([]int64, error)
+	// GetArea 评论区的设置，没有设置过的评论区对所有人开放
+	GetArea(ctx context.Context, objectID int64, objectType object.ObjectType) (*Area, error)
+	SaveArea(ctx context.Context, area *Area) error
 }
@@ -1,15 +1,27 @@
 package comment
 
-import "harmoni/app/harmoni/internal/entity"
+import (
+	"harmoni/app/harmoni/internal/entity"
+	"harmoni/internal/types/object"
+)
+
+const (
+	CommentOrderByNewest = "newest"
+	// CommentOrderByHot 按互动分值和发布时间排序
+	CommentOrderByHot = "hot"
+	// CommentOrderByBest 按好评率的置信下界排序
+	CommentOrderByBest = "best"
+)
 
 type CommentQuery struct {
 	entity.PageCond
 	// object id
-	ObjectID string
+	ObjectID   int64
+	ObjectType object.ObjectType
 	// root id
 	RootID int64
 	// query condition
 	QueryCond string
-	// user id
+	// UserID 当前用户，用于返回投票状态
 	UserID int64
 }
@@ -0,0 +1,60 @@
+package comment
+
+import (
+	postentity "harmoni/app/harmoni/internal/entity/post"
+	"math"
+	"time"
+)
+
+const (
+	// HotLikePoints 每个点赞贡献的热度分值
+	HotLikePoints = 1.0
+	// HotReplyPoints 每条回复贡献的热度分值
+	HotReplyPoints = 2.0
+
+	// wilsonZ 95% 置信度对应的正态分布分位数
+	wilsonZ = 1.96
+)
+
+// Rank 评论在热度和好评排序中的分数
+type Rank struct {
+	CommentID int64
+	Hot       float64
+	Best      float64
+}
+
+// BestScore 好评率 Wilson 置信区间的下界，互动越少下界越低，
+// 只有少量点赞的评论不会排在大量互动且好评率相近的评论前面
+func BestScore(up, down int64) float64 {
+	n := float64(up + down)
+	if n == 0 {
+		return 0
+	}
+
+	p := float64(up) / n
+	z2 := wilsonZ * wilsonZ
+	return (p + z2/(2*n) - wilsonZ*math.Sqrt((p*(1-p)+z2/(4*n))/n)) / (1 + z2/n)
+}
+
+// NewRanks 计算评论的排序分，热度的计算方式与帖子相同。
+// likes 和 dislikes 中没有的评论使用数据库中同步的计数
+func NewRanks(comments []*Comment, likes, dislikes, replies map[int64]int64, decay time.Duration) []Rank {
+	ranks := make([]Rank, len(comments))
+	for i, comment := range comments {
+		likeCount, ok := likes[comment.CommentID]
+		if !ok {
+			likeCount = comment.LikeCount
+		}
+		dislikeCount, ok := dislikes[comment.CommentID]
+		if !ok {
+			dislikeCount = comment.DislikeCount
+		}
+		points := float64(likeCount)*HotLikePoints + float64(replies[comment.CommentID])*HotReplyPoints
+		ranks[i] = Rank{
+			CommentID: comment.CommentID,
+			Hot:       postentity.HotScore(points, comment.CreatedAt, decay),
+			Best:      BestScore(likeCount, dislikeCount),
+		}
+	}
+	return ranks
+}
@@ -0,0 +1,18 @@
+package comment
+
+import "testing"
+
+func TestBestScore(t *testing.T) {
+	if got := BestScore(0, 0); got != 0 {
+		t.Errorf("BestScore(0, 0) = %v, want 0", got)
+	}
+	if BestScore(1, 0) >= BestScore(10, 0) {
+		t.Error("more likes with the same ratio should rank higher")
+	}
+	if BestScore(10, 0) <= BestScore(12, 8) {
+		t.Error("a higher ratio should rank higher with similar votes")
+	}
+	if got := BestScore(1000, 0); got <= 0.99 || got > 1 {
+		t.Errorf("BestScore(1000, 0) = %v, want close to 1", got)
+	}
+}
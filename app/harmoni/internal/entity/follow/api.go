@@ -14,6 +14,8 @@ type FollowRequest struct {
 }
 
 type FollowReply struct {
+	// Pending 关注的是私密账号，需要等待对方批准
+	Pending bool `json:"pending"`
 }
 
 type GetFollowingsRequest struct {
@@ -62,3 +64,39 @@ type AreFollowEachOtherRequest struct {
 type AreFollowEachOtherReply struct {
 	FollowedEachOther bool `json:"followed_each_other"`
 }
+
+type GetSuggestionsRequest struct {
+	UserID int64 `json:"-"`
+	Limit  int   `query:"limit" validate:"omitempty,lte=50" label:"推荐数量"`
+}
+
+type GetSuggestionsReply struct {
+	Users []*userentity.UserBasicInfo `json:"users"`
+}
+
+type GetFollowRequestsRequest struct {
+	entity.PageCond
+	UserID int64 `json:"-"`
+}
+
+type GetFollowRequestsReply struct {
+	paginator.Page[userentity.UserBasicInfo]
+}
+
+type HandleFollowRequestRequest struct {
+	UserID     int64 `json:"-"`
+	FollowerID int64 `json:"follower_id,string" validate:"required"`
+	// Approve 为 false 时拒绝
+	Approve bool `json:"approve"`
+}
+
+type HandleFollowRequestReply struct {
+}
+
+type SetPrivacyRequest struct {
+	UserID  int64 `json:"-"`
+	Private bool  `json:"private"`
+}
+
+type SetPrivacyReply struct {
+}
@@ -19,6 +19,18 @@ func (*Follow) TableName() string {
 	return "follow"
 }
 
+// PendingFollow 私密账号收到的关注请求，批准后转为关注
+type PendingFollow struct {
+	ID          uint64 `gorm:"primarykey"`
+	CreatedAt   time.Time
+	FollowerID  int64 `gorm:"not null;uniqueIndex:idx_pending_follow,priority:2"` // 请求关注的用户 ID
+	FollowingID int64 `gorm:"not null;uniqueIndex:idx_pending_follow,priority:1"` // 私密账号的用户 ID
+}
+
+func (*PendingFollow) TableName() string {
+	return "follow_pending"
+}
+
 type FollowBasicInfo struct {
 	FollowerID   int64        `json:"follower_id,omitempty,string"`  // 关注者用户 ID
 	FollowingID  int64        `json:"following_id,omitempty,string"` // 被关注对象 ID
@@ -48,6 +60,27 @@ type FollowRepository interface {
 	GetFollowersPage(ctx context.Context, followQuery *FollowQuery) (paginator.Page[int64], error)
 	GetFollowingsPage(ctx context.Context, followQuery *FollowQuery) (paginator.Page[int64], error)
 	GetFollowingUsersAll(ctx context.Context, userID int64) ([]int64, error)
+	GetFollowingTagsAll(ctx context.Context, userID int64) ([]int64, error)
+	// GetFollowingUsersFollowers 关注的所有用户以及他们的粉丝数
+	GetFollowingUsersFollowers(ctx context.Context, userID int64) (map[int64]int64, error)
+	// ListPopularFollowings 关注的用户中粉丝数不少于 minFollowers 的用户
+	ListPopularFollowings(ctx context.Context, userID int64, minFollowers int64) ([]int64, error)
+	// ListFollowersAfter 按ID顺序列出ID大于 after 的粉丝，用于分批遍历
+	ListFollowersAfter(ctx context.Context, userID, after int64, limit int) ([]int64, error)
+	// BatchGetFollowingUsers 每个用户关注的所有用户
+	BatchGetFollowingUsers(ctx context.Context, userIDs []int64) (map[int64][]int64, error)
+	// BatchCountFollowingsOfFollowings 每个用户关注的用户又关注了哪些用户，以及共同关注的人数，每个用户取人数最多的 limit 个
+	BatchCountFollowingsOfFollowings(ctx context.Context, userIDs []int64, limit int) (map[int64]map[int64]int64, error)
 	IsFollowing(ctx context.Context, follow *Follow) (bool, error)
+	// CreatePending 创建关注请求，已经关注或已经请求过时返回错误
+	CreatePending(ctx context.Context, pending *PendingFollow) error
+	// DeletePending 撤回或拒绝关注请求，返回请求是否存在
+	DeletePending(ctx context.Context, followerID, followingID int64) (bool, error)
+	// ApprovePending 在同一个事务中删除关注请求并关注
+	ApprovePending(ctx context.Context, followerID, followingID int64) error
+	// GetPendingPage 按请求时间倒序列出向 UserID 发出请求的用户
+	GetPendingPage(ctx context.Context, followQuery *FollowQuery) (paginator.Page[int64], error)
+	// ListPendingAfter 按ID顺序列出ID大于 after 的请求者，用于分批遍历
+	ListPendingAfter(ctx context.Context, userID, after int64, limit int) ([]int64, error)
 	AreFollowEachOther(ctx context.Context, userIDx int64, userIDy int64) (bool, error)
 }
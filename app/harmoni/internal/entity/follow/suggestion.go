@@ -0,0 +1,19 @@
+package follow
+
+import "context"
+
+// Suggestion 推荐关注的用户及其排序分数
+type Suggestion struct {
+	UserID int64
+	Score  float64
+}
+
+type SuggestionRepository interface {
+	// SetSuggestions 覆盖用户的推荐列表，列表为空时删除
+	SetSuggestions(ctx context.Context, userID int64, suggestions []Suggestion) error
+	// GetSuggestions 按分数从高到低列出推荐的用户，没有计算过推荐时为空
+	GetSuggestions(ctx context.Context, userID int64) ([]int64, error)
+	// SetPopular 覆盖全站粉丝最多的用户，用于没有推荐的用户
+	SetPopular(ctx context.Context, userIDs []int64) error
+	GetPopular(ctx context.Context) ([]int64, error)
+}
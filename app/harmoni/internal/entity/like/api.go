@@ -2,7 +2,11 @@ package like
 
 import (
 	"harmoni/app/harmoni/internal/entity"
+	commententity "harmoni/app/harmoni/internal/entity/comment"
 	"harmoni/app/harmoni/internal/entity/paginator"
+	postentity "harmoni/app/harmoni/internal/entity/post"
+	"harmoni/app/harmoni/internal/entity/user"
+	"time"
 )
 
 type LikeRequest struct {
@@ -11,11 +15,39 @@ type LikeRequest struct {
 	ObjectID            int64    `json:"oid,omitempty,string" validate:"required"`
 	ObjectCreatorUserID int64    `json:"-"`
 	IsCancel            bool     `json:"is_cancel,omitempty"`
+	// Direction 1 为赞，-1 为踩，默认为赞
+	Direction LikeDirection `json:"direction,omitempty" validate:"omitempty,oneof=1 -1"`
 }
 
 type LikeReply struct {
 }
 
+type ReactRequest struct {
+	Type     LikeType `json:"type,omitempty" validate:"required,oneof=1 2"`
+	UserID   int64    `json:"-"`
+	ObjectID int64    `json:"oid,omitempty,string" validate:"required"`
+	Reaction string   `json:"reaction" validate:"required" label:"表情回应"`
+	IsCancel bool     `json:"is_cancel,omitempty"`
+}
+
+type ReactReply struct {
+}
+
+type GetReactionsRequest struct {
+	Type     LikeType `query:"type" validate:"required,oneof=1 2"`
+	UserID   int64    `json:"-"`
+	ObjectID int64    `query:"oid" validate:"required"`
+}
+
+type GetReactionsReply struct {
+	// Available 可用的表情回应
+	Available []string `json:"available"`
+	// Counts 每个表情回应的数量
+	Counts map[string]int64 `json:"counts"`
+	// Reacted 当前用户保留的表情回应
+	Reacted []string `json:"reacted"`
+}
+
 type GetLikingsRequest struct {
 	entity.PageCond
 	Type         LikeType `query:"type,omitempty" validate:"required"`
@@ -27,6 +59,61 @@ type GetLikingsReply[T any] struct {
 	paginator.Page[T]
 }
 
+// LikedComment 点赞的评论，帖子下的评论附带所在帖子
+type LikedComment struct {
+	*commententity.Comment
+	Post *postentity.PostBasicInfo `json:"post,omitempty"`
+}
+
+type GetReceivedLikesRequest struct {
+	UserID int64    `json:"-"`
+	Type   LikeType `query:"type" validate:"omitempty,oneof=1 2 3" label:"类型"`
+	// Since 和 Until 为秒级时间戳
+	Since  int64 `query:"since"`
+	Until  int64 `query:"until"`
+	Cursor int64 `query:"cursor"`
+	// CursorID 与 Cursor 一起组成游标，取上一页返回的 next_cursor_id
+	CursorID uint `query:"cursor_id"`
+	Limit    int  `query:"limit" validate:"omitempty,lte=50" label:"每页数量"`
+}
+
+// ReceivedLike 谁在什么时候赞了哪个对象
+type ReceivedLike struct {
+	ID       uint                `json:"-"`
+	User     *user.UserBasicInfo `json:"user_info"`
+	Type     LikeType            `json:"type"`
+	LikingID int64               `json:"oid,string"`
+	Reaction string              `json:"reaction,omitempty"`
+	LikedAt  time.Time           `json:"liked_at"`
+}
+
+type GetReceivedLikesReply struct {
+	paginator.CursorPage[*ReceivedLike]
+	NextCursorID uint `json:"next_cursor_id,string"`
+}
+
+type GetReceivedLikeStatsRequest struct {
+	UserID int64 `json:"-"`
+	// Date 统计的日期，默认为昨天
+	Date string `query:"date" validate:"omitempty,datetime=2006-01-02" label:"日期"`
+}
+
+type GetReceivedLikeStatsReply struct {
+	Date  string `json:"date"`
+	Total int64  `json:"total"`
+	// Objects 按赞数从多到少排列
+	Objects []ReceivedLikeCount `json:"objects"`
+}
+
+type ReconcileLikesRequest struct {
+	UserID int64 `json:"-"`
+}
+
+// ReconcileLikesReply 对账在后台进行，结果见日志
+type ReconcileLikesReply struct {
+	Started bool `json:"started"`
+}
+
 type IsLikingRequest struct {
 	Type     LikeType `query:"type,omitempty" validate:"required"`
 	UserID   int64    `json:"user_id,omitempty"`
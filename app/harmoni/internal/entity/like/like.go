@@ -4,10 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	v1 "harmoni/app/harmoni/api/mq/v1/like"
-	"harmoni/app/harmoni/internal/entity"
 	"harmoni/app/harmoni/internal/entity/paginator"
 	"harmoni/app/harmoni/internal/pkg/common"
+	"harmoni/app/harmoni/internal/pkg/counter"
 	"harmoni/app/harmoni/internal/types/events/like"
+	"time"
 )
 
 type LikeType uint8
@@ -42,14 +43,28 @@ func (t *LikeType) ToInterEventLikeType() like.LikeType {
 	return like.LikePost
 }
 
+// LikeDirection 投票方向，赞为 1，踩为 -1
+type LikeDirection int8
+
+const (
+	LikeUp   LikeDirection = 1
+	LikeDown LikeDirection = -1
+)
+
 type Like struct {
-	ID uint `gorm:"primarykey;type:BIGINT UNSIGNED not NULL AUTO_INCREMENT;"`
-	entity.TimeMixin
-	UserID       int64    `gorm:"not null"`
-	TargetUserID int64    `gorm:"not null"`
-	LikingID     int64    `gorm:"not null;uniqueIndex"`
-	LikeType     LikeType `gorm:"not null;type:TINYINT UNSIGNED"`
-	Canceled     bool     `gorm:"not null;default:0;"`
+	ID        uint `gorm:"primarykey;type:BIGINT UNSIGNED not NULL AUTO_INCREMENT;"`
+	CreatedAt time.Time
+	// UpdatedAt 最后一次点赞或取消的时间
+	UpdatedAt    time.Time `gorm:"index:idx_like_target,priority:2"`
+	UserID       int64     `gorm:"not null;index:idx_like_user;uniqueIndex:idx_like_object,priority:3"`
+	TargetUserID int64     `gorm:"not null;index:idx_like_target,priority:1"`
+	LikingID     int64     `gorm:"not null;uniqueIndex:idx_like_object,priority:1"`
+	LikeType     LikeType  `gorm:"not null;type:TINYINT UNSIGNED;uniqueIndex:idx_like_object,priority:2"`
+	Canceled     bool      `gorm:"not null;default:0;"`
+	// Direction 早期只有点赞，默认为赞
+	Direction LikeDirection `gorm:"not null;default:1;type:TINYINT"`
+	// Reaction 表情回应，为空时是普通的赞或踩
+	Reaction string `gorm:"not null;default:'';type:varchar(32);uniqueIndex:idx_like_object,priority:4"`
 }
 
 func (*Like) TableName() string {
@@ -70,18 +85,74 @@ func (r *LikeCacheInfo) FromJSONString(data string) error {
 	return json.Unmarshal(common.StringToBytes(data), r)
 }
 
+// ObjectMeta 被赞对象的作者和是否存在，帖子只有发布后才算存在
+type ObjectMeta struct {
+	AuthorID int64
+	Exist    bool
+}
+
+// LikeDrift 一种对象的点赞数对账结果
+type LikeDrift struct {
+	Type    LikeType `json:"type"`
+	Scanned int      `json:"scanned"`
+	// CacheDrifted 缓存中的计数与点赞表不一致的对象数
+	CacheDrifted int `json:"cache_drifted"`
+	// DatabaseDrifted 数据库中的计数与点赞表不一致的对象数
+	DatabaseDrifted int `json:"database_drifted"`
+	// Drift 所有偏差的绝对值之和
+	Drift int64 `json:"drift"`
+}
+
 var (
 	LikeTypeList = []LikeType{LikeUser, LikePost, LikeComment}
 )
 
 type LikeRepository interface {
-	Like(ctx context.Context, like *Like, targetUserID int64, isCancel bool) error
+	// Like 按 like.Direction 投票或取消投票，返回是否从相反的方向改投
+	Like(ctx context.Context, like *Like, targetUserID int64, isCancel bool) (bool, error)
 	Save(ctx context.Context, like *Like) error
 	LikeCount(ctx context.Context, like *Like) (int64, bool, error)
 	BatchLikeCount(ctx context.Context, likeType LikeType) (map[int64]int64, error)
 	BatchLikeCountByIDs(ctx context.Context, likingIDs []int64, likeType LikeType) (map[int64]int64, error)
+	// BatchDislikeCount 缓存中的所有踩数，用于同步到数据库
+	BatchDislikeCount(ctx context.Context, likeType LikeType) (map[int64]int64, error)
+	BatchDislikeCountByIDs(ctx context.Context, likingIDs []int64, likeType LikeType) (map[int64]int64, error)
+	// BatchVotes 用户对每个对象的投票方向，没有投票的对象不在结果中
+	BatchVotes(ctx context.Context, userID int64, likeType LikeType, likingIDs []int64) (map[int64]LikeDirection, error)
+	// React 添加或取消表情回应，返回被新回应替换掉的回应，多个时以逗号分隔
+	React(ctx context.Context, like *Like, isCancel bool) (string, error)
+	// BatchReactionCountByIDs 每个对象收到的各个表情回应的数量
+	BatchReactionCountByIDs(ctx context.Context, likingIDs []int64, likeType LikeType) (map[int64]map[string]int64, error)
+	// BatchUserReactions 用户对每个对象保留的表情回应
+	BatchUserReactions(ctx context.Context, userID int64, likeType LikeType, likingIDs []int64) (map[int64][]string, error)
 	// UpdateLikeCount(ctx context.Context, like *Like, count int8) error
 	ListLikingIDs(ctx context.Context, query *LikeQuery) (paginator.Page[int64], error)
 	IsLiking(ctx context.Context, like *Like) (bool, error)
 	CacheLikeCount(ctx context.Context, like *Like, count int64) error
+	// ListReceived 按时间倒序列出用户收到的赞，不包括已取消的赞和踩
+	ListReceived(ctx context.Context, query *ReceivedLikeQuery) ([]*Like, error)
+	// CountReceivedByObject 统计用户每个对象在时间范围内收到的赞
+	CountReceivedByObject(ctx context.Context, targetUserID int64, since, until time.Time) ([]ReceivedLikeCount, error)
+	// CountByLikingIDs 从点赞表统计每个对象的赞数和踩数
+	CountByLikingIDs(ctx context.Context, likeType LikeType, likingIDs []int64) (map[int64]int64, map[int64]int64, error)
+	// CountByTargetUserIDs 从点赞表统计每个用户收到的赞数
+	CountByTargetUserIDs(ctx context.Context, userIDs []int64) (map[int64]int64, error)
+	// CachedCounts 缓存中的赞数和踩数，未缓存的对象不在结果中
+	CachedCounts(ctx context.Context, likeType LikeType, likingIDs []int64) (map[int64]int64, map[int64]int64, error)
+	// ResetCounts 用重新统计的结果覆盖缓存中的赞数和踩数，缓存在统计期间有变化的不覆盖
+	ResetCounts(ctx context.Context, likeType LikeType, likes, dislikes []counter.Swap) error
+	// DeleteByLikingIDs 删除对象收到的所有点赞记录以及缓存中的点赞数
+	DeleteByLikingIDs(ctx context.Context, likeType LikeType, likingIDs []int64) error
+	// GetObjectMeta 依次查询布隆过滤器和缓存，都未命中时合并并发请求调用 load
+	GetObjectMeta(ctx context.Context, likeType LikeType, likingID int64, load func(ctx context.Context) (*ObjectMeta, error)) (*ObjectMeta, error)
+	// AddObject 对象创建或恢复后加入布隆过滤器并缓存作者
+	AddObject(ctx context.Context, likeType LikeType, likingID, authorID int64) error
+	// RemoveObject 对象删除后清除缓存，布隆过滤器在下次重建时清除
+	RemoveObject(ctx context.Context, likeType LikeType, likingIDs ...int64) error
+	// BatchListLikedTagIDs 每个用户赞过的帖子所属的话题，按赞过的帖子数从多到少取 limit 个
+	BatchListLikedTagIDs(ctx context.Context, userIDs []int64, limit int) (map[int64][]int64, error)
+	// ListTopLikersByTags 每个话题下赞过的帖子最多的 limit 个用户
+	ListTopLikersByTags(ctx context.Context, tagIDs []int64, limit int) (map[int64][]int64, error)
+	// RebuildObjectFilter 用 list 分批列出的所有对象重建布隆过滤器
+	RebuildObjectFilter(ctx context.Context, likeType LikeType, list func(after int64) ([]int64, error)) error
 }
@@ -1,6 +1,9 @@
 package like
 
-import "harmoni/app/harmoni/internal/entity"
+import (
+	"harmoni/app/harmoni/internal/entity"
+	"time"
+)
 
 type LikeQuery struct {
 	entity.PageCond
@@ -10,3 +13,22 @@ type LikeQuery struct {
 	UserID int64
 	Type   LikeType
 }
+
+// ReceivedLikeQuery 用户收到的赞，Since 和 Until 为零值时不限制
+type ReceivedLikeQuery struct {
+	TargetUserID int64
+	Type         LikeType
+	Since        time.Time
+	Until        time.Time
+	// Before 和 BeforeID 组成游标，只返回 (updated_at, id) 在游标之前的赞，同一时间的赞按ID区分
+	Before   time.Time
+	BeforeID uint
+	Limit    int
+}
+
+// ReceivedLikeCount 对象在一段时间内收到的赞数
+type ReceivedLikeCount struct {
+	LikeType LikeType `json:"type"`
+	LikingID int64    `json:"oid,string"`
+	Count    int64    `json:"count"`
+}
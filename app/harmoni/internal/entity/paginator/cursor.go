@@ -0,0 +1,37 @@
+package paginator
+
+// CursorPage 游标分页结构体，NextCursor 为本页最后一条记录的ID，下一页从它之后开始查询
+type CursorPage[T any] struct {
+	Data       []T   `json:"data"`
+	NextCursor int64 `json:"next_cursor,string"`
+	HasMore    bool  `json:"has_more"`
+}
+
+const (
+	defaultCursorLimit = 20
+	maxCursorLimit     = 50
+)
+
+// CursorLimit 修正每页数量，查询时应多取一条用于判断是否还有下一页
+func CursorLimit(limit int) int {
+	switch {
+	case limit <= 0:
+		return defaultCursorLimit
+	case limit > maxCursorLimit:
+		return maxCursorLimit
+	}
+	return limit
+}
+
+// NewCursorPage 根据多取一条的查询结果生成分页，cursor 取自每条记录的ID
+func NewCursorPage[T any](data []T, limit int, cursor func(T) int64) CursorPage[T] {
+	page := CursorPage[T]{Data: data}
+	if len(data) > limit {
+		page.Data = data[:limit]
+		page.HasMore = true
+	}
+	if len(page.Data) != 0 {
+		page.NextCursor = cursor(page.Data[len(page.Data)-1])
+	}
+	return page
+}
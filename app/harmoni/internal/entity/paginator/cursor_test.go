@@ -0,0 +1,44 @@
+package paginator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCursorLimit(t *testing.T) {
+	tests := []struct {
+		limit int
+		want  int
+	}{
+		{0, defaultCursorLimit},
+		{-1, defaultCursorLimit},
+		{10, 10},
+		{maxCursorLimit + 1, maxCursorLimit},
+	}
+	for _, tt := range tests {
+		if got := CursorLimit(tt.limit); got != tt.want {
+			t.Errorf("CursorLimit(%d) = %d, want %d", tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestNewCursorPage(t *testing.T) {
+	id := func(v int64) int64 { return v }
+	tests := []struct {
+		name  string
+		data  []int64
+		limit int
+		want  CursorPage[int64]
+	}{
+		{"empty", []int64{}, 2, CursorPage[int64]{Data: []int64{}}},
+		{"last page", []int64{1, 2}, 2, CursorPage[int64]{Data: []int64{1, 2}, NextCursor: 2}},
+		{"has more", []int64{1, 2, 3}, 2, CursorPage[int64]{Data: []int64{1, 2}, NextCursor: 2, HasMore: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewCursorPage(tt.data, tt.limit, id); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewCursorPage() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,12 +3,13 @@ package post
 import (
 	"harmoni/app/harmoni/internal/entity"
 	"harmoni/app/harmoni/internal/entity/paginator"
+	"time"
 )
 
 type GetPostsRequest struct {
 	entity.PageCond
 	UserID int64  `json:"-"`
-	Order  string `query:"order" validate:"omitempty,oneof=like rtime ctime" label:"排序"`
+	Order  string `query:"order" validate:"omitempty,oneof=like rtime ctime hot" label:"排序"`
 	TagID  int64  `query:"tag_id"`
 }
 
@@ -41,3 +42,114 @@ type LikePostRequest struct {
 	UserID int64 `json:"-"`
 	Like   int8  `json:"like" validate:"required,oneof=1 2"`
 }
+
+type UpdatePostRequest struct {
+	PostID  int64             `params:"id" validate:"required" label:"帖子ID"`
+	TagIDs  entity.Int64Slice `json:"tag_ids" validate:"lte=4" label:"话题ID"`
+	UserID  int64             `json:"-"`
+	Title   string            `json:"title" validate:"required,gte=3,lte=128" label:"帖子标题"`
+	Content string            `json:"content" validate:"required,gte=6,lte=65535" label:"帖子内容"`
+	Summary string            `json:"summary" validate:"lte=256" label:"编辑说明"`
+}
+
+type UpdatePostReply struct {
+	PostInfo
+}
+
+type GetPostRevisionsRequest struct {
+	entity.PageCond
+	PostID int64 `params:"id" validate:"required" label:"帖子ID"`
+}
+
+type GetPostRevisionsReply struct {
+	*paginator.Page[PostRevisionInfo] `json:"revisions"`
+}
+
+type GetPostRevisionDiffRequest struct {
+	PostID int64 `params:"id" validate:"required" label:"帖子ID"`
+	From   int64 `query:"from" validate:"required" label:"起始版本"`
+	To     int64 `query:"to" validate:"required" label:"目标版本"`
+}
+
+type GetPostRevisionDiffReply struct {
+	PostRevisionDiff
+}
+
+type RollbackPostRequest struct {
+	PostID   int64 `params:"id" validate:"required" label:"帖子ID"`
+	Revision int64 `params:"revision" validate:"required" label:"版本"`
+	UserID   int64 `json:"-"`
+}
+
+type RollbackPostReply struct {
+	PostInfo
+}
+
+type DeletePostRequest struct {
+	PostID int64 `params:"id" validate:"required" label:"帖子ID"`
+	UserID int64 `json:"-"`
+}
+
+type DeletePostReply struct {
+}
+
+type RestorePostRequest struct {
+	PostID int64 `params:"id" validate:"required" label:"帖子ID"`
+	UserID int64 `json:"-"`
+}
+
+type RestorePostReply struct {
+	PostInfo
+}
+
+type GetTrashRequest struct {
+	entity.PageCond
+	UserID int64 `json:"-"`
+}
+
+type GetTrashReply struct {
+	*paginator.Page[TrashedPostInfo] `json:"posts"`
+}
+
+type CreateDraftRequest struct {
+	TagIDs  entity.Int64Slice `json:"tag_ids" validate:"lte=4" label:"话题ID"`
+	UserID  int64             `json:"-"`
+	Title   string            `json:"title" validate:"lte=128" label:"帖子标题"`
+	Content string            `json:"content" validate:"lte=65535" label:"帖子内容"`
+}
+
+type CreateDraftReply struct {
+	PostInfo
+}
+
+type SaveDraftRequest struct {
+	PostID  int64             `params:"id" validate:"required" label:"帖子ID"`
+	TagIDs  entity.Int64Slice `json:"tag_ids" validate:"lte=4" label:"话题ID"`
+	UserID  int64             `json:"-"`
+	Title   string            `json:"title" validate:"lte=128" label:"帖子标题"`
+	Content string            `json:"content" validate:"lte=65535" label:"帖子内容"`
+}
+
+type SaveDraftReply struct {
+	PostInfo
+}
+
+type PublishDraftRequest struct {
+	PostID int64 `params:"id" validate:"required" label:"帖子ID"`
+	UserID int64 `json:"-"`
+	// 为空时立即发布
+	PublishAt *time.Time `json:"publish_at" label:"发布时间"`
+}
+
+type PublishDraftReply struct {
+	PostInfo
+}
+
+type GetDraftsRequest struct {
+	entity.PageCond
+	UserID int64 `json:"-"`
+}
+
+type GetDraftsReply struct {
+	*paginator.Page[PostBasicInfo] `json:"posts"`
+}
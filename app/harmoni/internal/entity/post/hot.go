@@ -0,0 +1,23 @@
+package post
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// HotLikePoints 每个点赞贡献的热度分值
+	HotLikePoints = 1.0
+	// HotCommentPoints 每条评论贡献的热度分值，评论比点赞更能说明讨论的热烈程度
+	HotCommentPoints = 2.0
+)
+
+// hotEpoch 时间分的起点，避免分数过大损失精度
+var hotEpoch = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// HotScore 帖子的热度分，由互动分值的对数加上发布时间得到。
+// 晚发布 decay 的帖子只需要十分之一的互动分值就能排在前面，
+// 分数不随时间变化，只在产生新的互动时更新
+func HotScore(points float64, publishedAt time.Time, decay time.Duration) float64 {
+	return math.Log10(math.Max(points, 1)) + publishedAt.Sub(hotEpoch).Seconds()/decay.Seconds()
+}
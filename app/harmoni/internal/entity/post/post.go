@@ -2,6 +2,7 @@ package post
 
 import (
 	"context"
+	"harmoni/app/harmoni/internal/entity"
 	"harmoni/app/harmoni/internal/entity/paginator"
 	tagentity "harmoni/app/harmoni/internal/entity/tag"
 	"harmoni/app/harmoni/internal/entity/user"
@@ -10,15 +11,32 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	// PostStatusPublished 已发布，零值兼容状态字段启用前的数据
+	PostStatusPublished int32 = iota
+	PostStatusDraft
+	PostStatusScheduled
+)
+
 type Post struct {
 	gorm.Model
-	Status    int32   `gorm:"not null"`
-	PostID    int64   `gorm:"uniqueIndex"`
-	AuthorID  int64   `gorm:"index"`
-	Title     string  `gorm:"type:varchar(128)"`
-	Content   string  `gorm:"type:text"`
-	LikeCount int64   `gorm:"not null"`
-	TagIDs    []int64 `gorm:"-"`
+	Status       int32      `gorm:"not null;default:0;index"`
+	PostID       int64      `gorm:"uniqueIndex"`
+	AuthorID     int64      `gorm:"index"`
+	Title        string     `gorm:"type:varchar(128)"`
+	Content      string     `gorm:"type:text"`
+	LikeCount    int64      `gorm:"not null"`
+	DislikeCount int64      `gorm:"not null;default:0"`
+	CollectCount int64      `gorm:"not null;default:0"`
+	CommentCount int64      `gorm:"not null;default:0"`
+	Revision     int64      `gorm:"not null;default:0"`
+	DeletedBy    int64      `gorm:"not null;default:0"`
+	ScheduledAt  *time.Time `gorm:"index"`
+	// RepliedAt 最后一条评论的时间，没有评论时为发布时间
+	RepliedAt *time.Time `gorm:"index"`
+	// MentionIDs 内容中提及的用户
+	MentionIDs []int64 `gorm:"type:varchar(1024);serializer:json"`
+	TagIDs     []int64 `gorm:"-"`
 }
 
 func (Post) TableName() string {
@@ -27,22 +45,33 @@ func (Post) TableName() string {
 
 type PostInfo struct {
 	PostBasicInfo
-	CollectCount int64 `json:"collect_count"`
-	Collected    bool  `json:"collected"`
 }
 
 type PostBasicInfo struct {
-	Liked        bool                `json:"liked"`
-	Status       int32               `json:"status"`
-	LikeCount    int64               `json:"like_count"`
+	Liked        bool  `json:"liked"`
+	Collected    bool  `json:"collected"`
+	Status       int32 `json:"status"`
+	LikeCount    int64 `json:"like_count"`
+	DislikeCount int64 `json:"dislike_count"`
+	// Score 赞数减去踩数
+	Score int64 `json:"score"`
+	// Vote 当前用户的投票，1 为赞，-1 为踩，0 为未投票
+	Vote         int8                `json:"vote"`
+	CollectCount int64               `json:"collect_count"`
 	CommentCount int64               `json:"comment_count"`
 	PostID       int64               `json:"post_id,string"`
 	User         *user.UserBasicInfo `json:"user_info"`
 	Tags         []tagentity.TagInfo `json:"tags"`
 	Title        string              `json:"title"`
 	Content      string              `json:"content"`
-	CreatedAt    time.Time           `json:"created_at"`
-	UpdatedAt    time.Time           `json:"updated_at"`
+	// RenderedContent 提及的用户替换为用户主页链接后的内容
+	RenderedContent string                `json:"rendered_content,omitempty"`
+	Mentions        []*user.UserBasicInfo `json:"mentions"`
+	Revision        int64                 `json:"revision"`
+	ScheduledAt     *time.Time            `json:"scheduled_at,omitempty"`
+	RepliedAt       *time.Time            `json:"replied_at,omitempty"`
+	CreatedAt       time.Time             `json:"created_at"`
+	UpdatedAt       time.Time             `json:"updated_at"`
 }
 
 func (p *Post) ToBasic() PostBasicInfo {
@@ -50,13 +79,62 @@ func (p *Post) ToBasic() PostBasicInfo {
 		User: &user.UserBasicInfo{
 			UserID: p.AuthorID,
 		},
-		Status:    p.Status,
-		PostID:    p.PostID,
-		Title:     p.Title,
-		Content:   p.Content,
-		LikeCount: p.LikeCount,
-		CreatedAt: p.CreatedAt,
-		UpdatedAt: p.UpdatedAt,
+		Status:       p.Status,
+		PostID:       p.PostID,
+		Title:        p.Title,
+		Content:      p.Content,
+		LikeCount:    p.LikeCount,
+		DislikeCount: p.DislikeCount,
+		Score:        p.LikeCount - p.DislikeCount,
+		CollectCount: p.CollectCount,
+		CommentCount: p.CommentCount,
+		Mentions:     user.ToBasicInfos(p.MentionIDs),
+		Revision:     p.Revision,
+		ScheduledAt:  p.ScheduledAt,
+		RepliedAt:    p.RepliedAt,
+		CreatedAt:    p.CreatedAt,
+		UpdatedAt:    p.UpdatedAt,
+	}
+}
+
+func (p *Post) IsPublished() bool {
+	return p.Status == PostStatusPublished
+}
+
+// PostRef 首页时间线中的帖子，按发布时间和帖子ID倒序排列。
+// 草稿和定时帖子保留创建时的ID，发布时只更新 created_at，不能只按ID排序
+type PostRef struct {
+	PostID    int64
+	CreatedAt time.Time
+}
+
+// IsZero 零值的游标表示从最新的帖子开始
+func (r PostRef) IsZero() bool {
+	return r.PostID == 0
+}
+
+// After r 是否排在 other 前面
+func (r PostRef) After(other PostRef) bool {
+	if !r.CreatedAt.Equal(other.CreatedAt) {
+		return r.CreatedAt.After(other.CreatedAt)
+	}
+	return r.PostID > other.PostID
+}
+
+type TrashedPostInfo struct {
+	PostBasicInfo
+	// DeletedByModerator 帖子被版主而非作者删除，作者无法自行恢复
+	DeletedByModerator bool      `json:"deleted_by_moderator"`
+	DeletedAt          time.Time `json:"deleted_at"`
+	PurgeAt            time.Time `json:"purge_at"`
+}
+
+func (p *Post) ToTrashed(retention time.Duration) TrashedPostInfo {
+	return TrashedPostInfo{
+		PostBasicInfo:      p.ToBasic(),
+		DeletedByModerator: p.DeletedBy != p.AuthorID,
+		DeletedAt:          p.DeletedAt.Time,
+		PurgeAt:            p.DeletedAt.Time.Add(retention),
 	}
 }
 
@@ -70,14 +148,55 @@ func (p *Post) ToInfo() PostInfo {
 
 type PostRepository interface {
 	Create(ctx context.Context, post *Post) error
-	Delete(ctx context.Context, postID int64) error
+	// Update 修改帖子的标题、内容和话题，并写入一条新的编辑记录
+	Update(ctx context.Context, post *Post, revision *PostRevision) error
+	// SaveDraft 保存草稿的标题、内容和话题，不产生编辑记录
+	SaveDraft(ctx context.Context, post *Post) error
+	// Publish 发布草稿或定时帖子，帖子已发布时返回 false
+	Publish(ctx context.Context, postID int64, publishedAt time.Time) (bool, error)
+	Schedule(ctx context.Context, postID int64, scheduledAt time.Time) error
+	GetDraftPage(ctx context.Context, authorID int64, pageCond *entity.PageCond) (paginator.Page[Post], error)
+	// ListScheduledBefore 列出发布时间早于 before 的定时帖子
+	ListScheduledBefore(ctx context.Context, before time.Time, limit int) ([]Post, error)
+	// Delete 将帖子移入回收站
+	Delete(ctx context.Context, postID int64, operatorID int64) error
+	Restore(ctx context.Context, postID int64) error
+	GetTrashedByPostID(ctx context.Context, postID int64) (*Post, bool, error)
+	GetTrashPage(ctx context.Context, authorID int64, pageCond *entity.PageCond) (paginator.Page[Post], error)
+	// ListTrashedBefore 列出在 before 之前被删除的帖子
+	ListTrashedBefore(ctx context.Context, before time.Time, limit int) ([]Post, error)
+	// Purge 彻底删除回收站中的帖子
+	Purge(ctx context.Context, postIDs []int64) error
 	GetBasicInfoByPostID(ctx context.Context, postID int64) (*Post, bool, error)
 	GetByUserID(ctx context.Context, userID int64, queryCond *PostQuery) (paginator.Page[Post], error)
 	GetByUserIDs(ctx context.Context, userID []int64, queryCond *PostQuery) (paginator.Page[Post], error)
 	GetByPostID(ctx context.Context, postID int64) (*Post, bool, error)
 	GetPostsByTagID(ctx context.Context, tagID int64) ([]Post, error)
 	BatchByIDs(ctx context.Context, postIDs []int64) ([]Post, error)
+	// GetByPostIDs 按给定顺序返回已发布的帖子，不存在的帖子被忽略
+	GetByPostIDs(ctx context.Context, postIDs []int64) ([]Post, error)
 	GetLikeCount(ctx context.Context, postID int64) (int64, bool, error)
 	UpdateLikeCount(ctx context.Context, postID int64, count int64) error
+	UpdateDislikeCount(ctx context.Context, postID int64, count int64) error
+	UpdateCollectCount(ctx context.Context, postID int64, count int64) error
+	UpdateCommentCount(ctx context.Context, postID int64, count int64) error
 	GetPage(ctx context.Context, queryCond *PostQuery) (paginator.Page[Post], error)
+	// ListPublishedAfter 按帖子ID顺序遍历已发布的帖子
+	ListPublishedAfter(ctx context.Context, afterPostID int64, limit int) ([]Post, error)
+	// ListHiddenAuthors authorIDs 中 viewerID 看不到的私密账号
+	ListHiddenAuthors(ctx context.Context, viewerID int64, authorIDs []int64) ([]int64, error)
+	// ListRefsBefore 按发布时间和帖子ID倒序列出作者或标签下排在 cursor 之后的已发布帖子，cursor 为零值时从最新的开始
+	ListRefsBefore(ctx context.Context, queryCond *PostQuery, cursor PostRef, limit int) ([]PostRef, error)
+	// CountByAuthorsSince since 之后发过帖子的作者以及发布的帖子数
+	CountByAuthorsSince(ctx context.Context, since time.Time) (map[int64]int64, error)
+	// UpdateRepliedAt 更新帖子的最后回复时间，只会向后更新
+	UpdateRepliedAt(ctx context.Context, postID int64, repliedAt time.Time) error
+	// IncrHotPoints 增加帖子的互动分值，返回增加后的分值
+	IncrHotPoints(ctx context.Context, postID int64, delta float64) (float64, error)
+	SetHotPoints(ctx context.Context, postID int64, points float64) error
+	// UpdateHotRank 更新帖子在全站和所属话题热榜中的分数，每个热榜只保留前 size 名
+	UpdateHotRank(ctx context.Context, postID int64, score float64, size int) error
+	RemoveHotRank(ctx context.Context, postID int64) error
+	GetRevisionPage(ctx context.Context, postID int64, pageCond *entity.PageCond) (paginator.Page[PostRevision], error)
+	GetRevision(ctx context.Context, postID int64, revision int64) (*PostRevision, bool, error)
 }
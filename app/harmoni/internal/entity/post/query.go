@@ -6,6 +6,7 @@ const (
 	PostOrderByCreatedTime = "ctime"
 	PostOrderByReplyTime   = "rtime"
 	PostOrderByLike        = "like"
+	PostOrderByHot         = "hot"
 )
 
 type PostQuery struct {
@@ -16,6 +17,12 @@ type PostQuery struct {
 	TagID int64
 	// author condition
 	AuthorIDs []int64
+	// TagIDs 带有其中任一标签的帖子，和 AuthorIDs 同时存在时取并集
+	TagIDs []int64
+	// ExcludeAuthorIDs 排除这些作者的帖子，用于隐藏静音的用户
+	ExcludeAuthorIDs []int64
+	// HidePrivate 排除 UserID 看不到的私密账号的帖子
+	HidePrivate bool
 	// user id
 	UserID int64
 }
@@ -0,0 +1,83 @@
+package post
+
+import (
+	"harmoni/app/harmoni/internal/entity"
+	tagentity "harmoni/app/harmoni/internal/entity/tag"
+	"harmoni/app/harmoni/internal/entity/user"
+	"harmoni/app/harmoni/internal/pkg/diff"
+	"time"
+)
+
+// PostRevision 帖子的一次编辑记录，写入后不可修改
+type PostRevision struct {
+	entity.BaseModelWithNoSoftDelete
+	RevisionID   int64   `gorm:"uniqueIndex"`
+	PostID       int64   `gorm:"not null;uniqueIndex:idx_post_revision"`
+	Revision     int64   `gorm:"not null;uniqueIndex:idx_post_revision"`
+	EditorID     int64   `gorm:"not null"`
+	RollbackFrom int64   `gorm:"not null;default:0"`
+	Summary      string  `gorm:"type:varchar(256)"`
+	Title        string  `gorm:"type:varchar(128)"`
+	Content      string  `gorm:"type:text"`
+	TagIDs       []int64 `gorm:"type:varchar(256);serializer:json"`
+}
+
+func (PostRevision) TableName() string {
+	return "post_revision"
+}
+
+type PostRevisionInfo struct {
+	PostID       int64               `json:"post_id,string"`
+	Revision     int64               `json:"revision"`
+	Editor       *user.UserBasicInfo `json:"editor"`
+	RollbackFrom int64               `json:"rollback_from,omitempty"`
+	Summary      string              `json:"summary"`
+	Title        string              `json:"title"`
+	Content      string              `json:"content"`
+	TagIDs       entity.Int64Slice   `json:"tag_ids"`
+	CreatedAt    time.Time           `json:"created_at"`
+}
+
+func (r *PostRevision) ToInfo() PostRevisionInfo {
+	return PostRevisionInfo{
+		PostID:       r.PostID,
+		Revision:     r.Revision,
+		Editor:       &user.UserBasicInfo{UserID: r.EditorID},
+		RollbackFrom: r.RollbackFrom,
+		Summary:      r.Summary,
+		Title:        r.Title,
+		Content:      r.Content,
+		TagIDs:       r.TagIDs,
+		CreatedAt:    r.CreatedAt,
+	}
+}
+
+type PostRevisionDiff struct {
+	From        PostRevisionInfo    `json:"from"`
+	To          PostRevisionInfo    `json:"to"`
+	Title       []diff.Line         `json:"title"`
+	Content     []diff.Line         `json:"content"`
+	AddedTags   []tagentity.TagInfo `json:"added_tags"`
+	RemovedTags []tagentity.TagInfo `json:"removed_tags"`
+}
+
+// DiffTagIDs 返回 to 相对 from 新增和移除的话题
+func DiffTagIDs(from, to []int64) (added []int64, removed []int64) {
+	fromSet := make(map[int64]bool, len(from))
+	for _, id := range from {
+		fromSet[id] = true
+	}
+	toSet := make(map[int64]bool, len(to))
+	for _, id := range to {
+		toSet[id] = true
+		if !fromSet[id] {
+			added = append(added, id)
+		}
+	}
+	for _, id := range from {
+		if !toSet[id] {
+			removed = append(removed, id)
+		}
+	}
+	return added, removed
+}
@@ -0,0 +1,28 @@
+package search
+
+import (
+	"harmoni/app/harmoni/internal/entity"
+	"harmoni/app/harmoni/internal/entity/paginator"
+)
+
+type SearchRequest struct {
+	entity.PageCond
+	UserID int64  `json:"-"`
+	Query  string `query:"q" validate:"required,lte=64" label:"搜索内容"`
+	Type   string `query:"type" validate:"omitempty,oneof=post comment" label:"搜索类型"`
+}
+
+type SearchReply struct {
+	*paginator.Page[SearchResult] `json:"results"`
+}
+
+type ReindexRequest struct {
+	UserID int64 `json:"-"`
+}
+
+// ReindexReply 处理请求的实例重建后的文档数，其他实例收到通知后各自重建
+type ReindexReply struct {
+	Posts      int   `json:"posts"`
+	Comments   int   `json:"comments"`
+	InstanceID int64 `json:"instance_id,omitempty"`
+}
@@ -0,0 +1,34 @@
+package search
+
+import (
+	"harmoni/app/harmoni/internal/entity"
+	"harmoni/app/harmoni/internal/entity/user"
+	"time"
+)
+
+const (
+	SearchTypePost    = "post"
+	SearchTypeComment = "comment"
+)
+
+type SearchQuery struct {
+	entity.PageCond
+	// 查询文本
+	Query string
+	// 为空时同时搜索帖子和评论
+	Type string
+	// UserID 搜索的用户，私密账号的帖子和评论只对已关注的用户可见
+	UserID int64
+}
+
+// SearchResult 一条搜索结果，Title 和 Snippet 中命中的词用 <em> 标记
+type SearchResult struct {
+	Type      string              `json:"type"`
+	PostID    int64               `json:"post_id,string"`
+	CommentID int64               `json:"comment_id,omitempty,string"`
+	Title     string              `json:"title"`
+	Snippet   string              `json:"snippet"`
+	Score     float64             `json:"score"`
+	Author    *user.UserBasicInfo `json:"author"`
+	CreatedAt time.Time           `json:"created_at"`
+}
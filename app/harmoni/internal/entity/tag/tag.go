@@ -57,6 +57,8 @@ type TagRepository interface {
 	GetByTagID(ctx context.Context, tagID int64) (*Tag, bool, error)
 	GetByTagIDs(ctx context.Context, tagID []int64) ([]Tag, error)
 	GetTagsByPostID(ctx context.Context, postID int64) ([]Tag, error)
+	// GetTagsByPostIDs 按帖子ID分组的标签
+	GetTagsByPostIDs(ctx context.Context, postIDs []int64) (map[int64][]Tag, error)
 	GetByTagName(ctx context.Context, tagName string) (*Tag, bool, error)
 	GetPage(ctx context.Context, pageSize, pageNum int64) (paginator.Page[Tag], error)
 }
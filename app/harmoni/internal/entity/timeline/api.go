@@ -17,10 +17,24 @@ type GetUserTimeLineReply struct {
 }
 
 type GetHomeTimeLineRequest struct {
-	entity.PageCond
 	UserID int64 `json:"-"`
+	// MaxID 和 MaxTime 是上一页的 next_cursor 和 next_cursor_time，只返回排在其后的帖子
+	MaxID   int64 `query:"max_id"`
+	MaxTime int64 `query:"max_time" validate:"required_with=MaxID" label:"游标时间"`
+	Limit   int   `query:"limit" validate:"omitempty,lte=50" label:"每页数量"`
 }
 
 type GetHomeTimeLineReply struct {
+	paginator.CursorPage[postentity.PostBasicInfo]
+	// NextCursorTime 最后一条帖子的发布时间（毫秒），帖子按发布时间和ID倒序排列
+	NextCursorTime int64 `json:"next_cursor_time,string"`
+}
+
+type GetTagTimeLineRequest struct {
+	entity.PageCond
+	UserID int64 `json:"-"`
+}
+
+type GetTagTimeLineReply struct {
 	paginator.Page[postentity.PostBasicInfo]
 }
@@ -0,0 +1,17 @@
+package timeline
+
+import (
+	"context"
+	postentity "harmoni/app/harmoni/internal/entity/post"
+)
+
+// TimelineRepository 首页时间线的收件箱，按发布时间和帖子ID倒序保存最近的帖子
+type TimelineRepository interface {
+	// Push 把帖子推送到用户的收件箱，没有收件箱的用户跳过，下次读取时重建
+	Push(ctx context.Context, userIDs []int64, posts ...postentity.PostRef) error
+	Remove(ctx context.Context, userIDs []int64, postIDs ...int64) error
+	// Rebuild 用数据库中的帖子重建收件箱，没有帖子时也会建立空的收件箱
+	Rebuild(ctx context.Context, userID int64, posts []postentity.PostRef) error
+	// List 收件箱中排在 cursor 之后的帖子，cursor 为零值时从最新的开始，收件箱不存在时返回 false
+	List(ctx context.Context, userID int64, cursor postentity.PostRef, limit int) ([]postentity.PostRef, bool, error)
+}
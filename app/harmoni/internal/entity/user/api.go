@@ -21,6 +21,14 @@ type GetUsersReply struct {
 	paginator.Page[UserBasicInfo]
 }
 
+type SuggestUsersRequest struct {
+	Prefix string `query:"prefix" validate:"required,lte=20" label:"用户名前缀"`
+}
+
+type SuggestUsersReply struct {
+	Users []*UserBasicInfo `json:"users"`
+}
+
 type UserLoginRequset struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required"`
@@ -12,14 +12,25 @@ import (
 type User struct {
 	gorm.Model
 	UserID      int64  `json:"user_id,string" gorm:"not null;uniqueIndex"`
-	Name        string `json:"name" gorm:"not null;type:varchar(20)"`
+	Name        string `json:"name" gorm:"not null;type:varchar(20);index"`
 	Email       string `json:"email" gorm:"not null;uniqueIndex;type:varchar(100)"`
 	Password    string `json:"-" gorm:"not null;type:varchar(255)"`
-	FollowCount int64  `gorm:"not null;default:0"`
+	FollowCount int64  `gorm:"not null;default:0;index"`
 	LikeCount   int64  `gorm:"not null;default:0"`
 	Avatar      int64  `json:"avatar" gorm:"type:varchar(255)"`
+	Role        Role   `json:"role" gorm:"not null;default:0;type:TINYINT UNSIGNED"`
+	// Private 私密账号，关注需要批准，帖子只对关注者可见
+	Private bool `json:"private" gorm:"not null;default:0"`
 }
 
+type Role uint8
+
+const (
+	RoleUser Role = iota
+	// RoleModerator 版主，可以管理其他用户发布的内容
+	RoleModerator
+)
+
 type UserList []User
 
 func (l UserList) ToUserIDMap() map[int64]User {
@@ -45,16 +56,37 @@ type UserBasicInfo struct {
 	Avatar string `json:"avatar"`
 }
 
+// ToBasicInfos 只有用户ID的基本信息，其余字段稍后批量填充
+func ToBasicInfos(userIDs []int64) []*UserBasicInfo {
+	infos := make([]*UserBasicInfo, len(userIDs))
+	for i, userID := range userIDs {
+		infos[i] = &UserBasicInfo{UserID: userID}
+	}
+	return infos
+}
+
 type UserDetail struct {
 	UserBasicInfo
 	FollowCount int64 `json:"follow_count"`
 	LikeCount   int64 `json:"like_count"`
+	Private     bool  `json:"private"`
 }
 
 func (User) TableName() string {
 	return "user"
 }
 
+// VisibleTo 私密账号的帖子只对自己和已批准的关注者可见，只在需要时调用 following 查询 viewerID 是否已关注。
+// 未登录的用户 viewerID 为 0
+func (u *User) VisibleTo(viewerID int64, following func() (bool, error)) (bool, error) {
+	if !u.Private || u.UserID == viewerID {
+		return true, nil
+	} else if viewerID == 0 {
+		return false, nil
+	}
+	return following()
+}
+
 func (u *User) ToBasicInfo(avatarLink string) UserBasicInfo {
 	return UserBasicInfo{
 		UserID: u.UserID,
@@ -68,6 +100,7 @@ func ConvertUserToDetailDisplay(u *User, avatarLink string) UserDetail {
 		UserBasicInfo: u.ToBasicInfo(avatarLink),
 		FollowCount:   u.FollowCount,
 		LikeCount:     u.LikeCount,
+		Private:       u.Private,
 	}
 }
 
@@ -92,7 +125,17 @@ type UserRepository interface {
 	GetByEmail(ctx context.Context, email string) (*User, bool, error)
 	GetByUserID(ctx context.Context, userID int64) (*User, bool, error)
 	GetByUserIDs(ctx context.Context, userID []int64) ([]User, error)
+	// GetByNames 按用户名查找用户，用户名不唯一时可能返回多个用户
+	GetByNames(ctx context.Context, names []string) ([]User, error)
+	// ListByNamePrefix 用户名以 prefix 开头的用户，按用户名排序
+	ListByNamePrefix(ctx context.Context, prefix string, limit int) ([]User, error)
 	GetPage(ctx context.Context, pageSize, pageNum int64) (paginator.Page[User], error)
+	// ListAfter 按用户ID顺序遍历所有用户
+	ListAfter(ctx context.Context, afterUserID int64, limit int) ([]User, error)
+	// ListActiveIDsAfter 按用户ID顺序遍历 since 之后发过帖子、点过赞或关注过别人的用户
+	ListActiveIDsAfter(ctx context.Context, afterUserID int64, since time.Time, limit int) ([]int64, error)
+	// ListPopularIDs 粉丝最多的用户
+	ListPopularIDs(ctx context.Context, limit int) ([]int64, error)
 
 	// To ensure that the status corresponds to the correct security information
 	// the verification method (email or phone) needs to be specified.
@@ -107,5 +150,6 @@ type UserRepository interface {
 	UpdateLikeCount(ctx context.Context, userID int64, likeCount int64) error
 
 	SetAvatarID(ctx context.Context, userID int64, fileID int64) error
+	SetPrivate(ctx context.Context, userID int64, private bool) error
 	GetAvatarID(ctx context.Context, userID int64) (int64, error)
 }
@@ -0,0 +1,40 @@
+package user
+
+import "testing"
+
+func TestVisibleTo(t *testing.T) {
+	tests := []struct {
+		name      string
+		private   bool
+		viewerID  int64
+		following bool
+		want      bool
+		queried   bool
+	}{
+		{"public to anonymous", false, 0, false, true, false},
+		{"public to stranger", false, 2, false, true, false},
+		{"private to self", true, 1, false, true, false},
+		{"private to anonymous", true, 0, false, false, false},
+		{"private to follower", true, 2, true, true, true},
+		{"private to stranger", true, 2, false, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			author := &User{UserID: 1, Private: tt.private}
+			queried := false
+			got, err := author.VisibleTo(tt.viewerID, func() (bool, error) {
+				queried = true
+				return tt.following, nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("VisibleTo() = %v, want %v", got, tt.want)
+			}
+			if queried != tt.queried {
+				t.Errorf("VisibleTo() queried following = %v, want %v", queried, tt.queried)
+			}
+		})
+	}
+}
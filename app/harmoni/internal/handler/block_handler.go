@@ -0,0 +1,58 @@
+package handler
+
+import (
+	blockentity "harmoni/app/harmoni/internal/entity/block"
+	"harmoni/app/harmoni/internal/pkg/fiberx"
+	"harmoni/app/harmoni/internal/pkg/middleware"
+	"harmoni/app/harmoni/internal/pkg/reason"
+	"harmoni/app/harmoni/internal/service"
+	"harmoni/internal/pkg/errorx"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type BlockHandler struct {
+	bs *service.BlockService
+}
+
+func NewBlockHandler(bs *service.BlockService) *BlockHandler {
+	return &BlockHandler{
+		bs: bs,
+	}
+}
+
+func (h *BlockHandler) Block(c *fiber.Ctx) error {
+	req := blockentity.BlockRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	req.UserID = middleware.GetClaimsFromCtx(c.UserContext()).UserID
+	reply, err := h.bs.Block(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
+
+func (h *BlockHandler) Mute(c *fiber.Ctx) error {
+	req := blockentity.MuteRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	req.UserID = middleware.GetClaimsFromCtx(c.UserContext()).UserID
+	reply, err := h.bs.Mute(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
+
+func (h *BlockHandler) GetBlocks(c *fiber.Ctx) error {
+	req := blockentity.GetBlocksRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	req.UserID = middleware.GetClaimsFromCtx(c.UserContext()).UserID
+	reply, err := h.bs.GetBlocks(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	collectionentity "harmoni/app/harmoni/internal/entity/collection"
+	"harmoni/app/harmoni/internal/pkg/fiberx"
+	"harmoni/app/harmoni/internal/pkg/middleware"
+	"harmoni/app/harmoni/internal/pkg/reason"
+	"harmoni/app/harmoni/internal/service"
+	"harmoni/internal/pkg/errorx"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CollectionHandler struct {
+	cs *service.CollectionService
+}
+
+func NewCollectionHandler(cs *service.CollectionService) *CollectionHandler {
+	return &CollectionHandler{cs: cs}
+}
+
+func (h *CollectionHandler) CreateCollection(c *fiber.Ctx) error {
+	req := collectionentity.CreateCollectionRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	req.UserID = middleware.GetClaimsFromCtx(c.UserContext()).UserID
+	reply, err := h.cs.Create(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
+
+func (h *CollectionHandler) UpdateCollection(c *fiber.Ctx) error {
+	req := collectionentity.UpdateCollectionRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	req.UserID = middleware.GetClaimsFromCtx(c.UserContext()).UserID
+	reply, err := h.cs.Update(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
+
+func (h *CollectionHandler) DeleteCollection(c *fiber.Ctx) error {
+	req := collectionentity.DeleteCollectionRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	req.UserID = middleware.GetClaimsFromCtx(c.UserContext()).UserID
+	reply, err := h.cs.Delete(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
+
+func (h *CollectionHandler) GetCollection(c *fiber.Ctx) error {
+	req := collectionentity.GetCollectionRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	claim := middleware.GetClaimsFromCtx(c.UserContext())
+	if claim != nil {
+		req.UserID = claim.UserID
+	}
+	reply, err := h.cs.GetCollection(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
+
+func (h *CollectionHandler) GetCollections(c *fiber.Ctx) error {
+	req := collectionentity.GetCollectionsRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	claim := middleware.GetClaimsFromCtx(c.UserContext())
+	if claim != nil {
+		req.UserID = claim.UserID
+	}
+	reply, err := h.cs.GetCollections(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
+
+func (h *CollectionHandler) CollectPost(c *fiber.Ctx) error {
+	req := collectionentity.CollectPostRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	req.UserID = middleware.GetClaimsFromCtx(c.UserContext()).UserID
+	reply, err := h.cs.CollectPost(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
+
+func (h *CollectionHandler) UncollectPost(c *fiber.Ctx) error {
+	req := collectionentity.UncollectPostRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	req.UserID = middleware.GetClaimsFromCtx(c.UserContext()).UserID
+	reply, err := h.cs.UncollectPost(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
+
+func (h *CollectionHandler) GetCollectionPosts(c *fiber.Ctx) error {
+	req := collectionentity.GetCollectionPostsRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	claim := middleware.GetClaimsFromCtx(c.UserContext())
+	if claim != nil {
+		req.UserID = claim.UserID
+	}
+	reply, err := h.cs.GetCollectionPosts(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
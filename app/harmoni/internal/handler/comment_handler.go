@@ -27,11 +27,45 @@ func (h *CommentHandler) GetComments(c *fiber.Ctx) error {
 		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
 	}
 
+	claim := middleware.GetClaimsFromCtx(c.UserContext())
+	if claim != nil {
+		req.UserID = claim.UserID
+	}
 	reply, err := h.cs.GetComments(c.UserContext(), &req)
 
 	return fiberx.HandleResponse(c, err, reply)
 }
 
+func (h *CommentHandler) GetCommentReplies(c *fiber.Ctx) error {
+	req := commententity.GetCommentRepliesRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	claim := middleware.GetClaimsFromCtx(c.UserContext())
+	if claim != nil {
+		req.UserID = claim.UserID
+	}
+	reply, err := h.cs.GetReplies(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
+
+func (h *CommentHandler) GetCommentDialog(c *fiber.Ctx) error {
+	req := commententity.GetCommentDialogRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	claim := middleware.GetClaimsFromCtx(c.UserContext())
+	if claim != nil {
+		req.UserID = claim.UserID
+	}
+	reply, err := h.cs.GetDialog(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
+
 func (h *CommentHandler) CreateComment(c *fiber.Ctx) error {
 	req := commententity.CreateCommentRequest{}
 	if err := fiberx.ParseAndCheck(c, &req); err != nil {
@@ -42,3 +76,50 @@ func (h *CommentHandler) CreateComment(c *fiber.Ctx) error {
 
 	return fiberx.HandleResponse(c, err, reply)
 }
+
+func (h *CommentHandler) GetCommentArea(c *fiber.Ctx) error {
+	req := commententity.GetCommentAreaRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	reply, err := h.cs.GetArea(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
+
+func (h *CommentHandler) UpdateCommentArea(c *fiber.Ctx) error {
+	req := commententity.UpdateCommentAreaRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	req.UserID = middleware.GetClaimsFromCtx(c.UserContext()).UserID
+	reply, err := h.cs.UpdateArea(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
+
+func (h *CommentHandler) UpdateComment(c *fiber.Ctx) error {
+	req := commententity.UpdateCommentRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	req.UserID = middleware.GetClaimsFromCtx(c.UserContext()).UserID
+	reply, err := h.cs.Update(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
+
+func (h *CommentHandler) DeleteComment(c *fiber.Ctx) error {
+	req := commententity.DeleteCommentRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	req.UserID = middleware.GetClaimsFromCtx(c.UserContext()).UserID
+	reply, err := h.cs.Delete(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
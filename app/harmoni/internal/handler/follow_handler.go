@@ -86,3 +86,51 @@ func (h *FollowHandler) AreFollowEachOther(c *fiber.Ctx) error {
 
 	return fiberx.HandleResponse(c, err, reply)
 }
+
+func (h *FollowHandler) GetSuggestions(c *fiber.Ctx) error {
+	req := followentity.GetSuggestionsRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	req.UserID = middleware.GetClaimsFromCtx(c.UserContext()).UserID
+	reply, err := h.fs.GetSuggestions(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
+
+func (h *FollowHandler) GetFollowRequests(c *fiber.Ctx) error {
+	req := followentity.GetFollowRequestsRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	req.UserID = middleware.GetClaimsFromCtx(c.UserContext()).UserID
+	reply, err := h.fs.GetFollowRequests(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
+
+func (h *FollowHandler) HandleFollowRequest(c *fiber.Ctx) error {
+	req := followentity.HandleFollowRequestRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	req.UserID = middleware.GetClaimsFromCtx(c.UserContext()).UserID
+	reply, err := h.fs.HandleFollowRequest(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
+
+func (h *FollowHandler) SetPrivacy(c *fiber.Ctx) error {
+	req := followentity.SetPrivacyRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	req.UserID = middleware.GetClaimsFromCtx(c.UserContext()).UserID
+	reply, err := h.fs.SetPrivacy(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
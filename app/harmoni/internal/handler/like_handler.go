@@ -40,6 +40,69 @@ func (h *LikeHandler) Like(c *fiber.Ctx) error {
 	return fiberx.HandleResponse(c, err, reply)
 }
 
+func (h *LikeHandler) React(c *fiber.Ctx) error {
+	req := likeentity.ReactRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		h.logger.Warn(err)
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	req.UserID = middleware.GetClaimsFromCtx(c.UserContext()).UserID
+	reply, err := h.ls.React(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
+
+func (h *LikeHandler) GetReactions(c *fiber.Ctx) error {
+	req := likeentity.GetReactionsRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		h.logger.Warn(err)
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	claim := middleware.GetClaimsFromCtx(c.UserContext())
+	if claim != nil {
+		req.UserID = claim.UserID
+	}
+	reply, err := h.ls.GetReactions(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
+
+func (h *LikeHandler) GetReceived(c *fiber.Ctx) error {
+	req := likeentity.GetReceivedLikesRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		h.logger.Warn(err)
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	req.UserID = middleware.GetClaimsFromCtx(c.UserContext()).UserID
+	reply, err := h.ls.GetReceived(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
+
+func (h *LikeHandler) GetReceivedStats(c *fiber.Ctx) error {
+	req := likeentity.GetReceivedLikeStatsRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		h.logger.Warn(err)
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	req.UserID = middleware.GetClaimsFromCtx(c.UserContext()).UserID
+	reply, err := h.ls.GetReceivedStats(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
+
+func (h *LikeHandler) Reconcile(c *fiber.Ctx) error {
+	req := likeentity.ReconcileLikesRequest{}
+	req.UserID = middleware.GetClaimsFromCtx(c.UserContext()).UserID
+	reply, err := h.ls.Reconcile(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
+
 func (h *LikeHandler) IsLiking(c *fiber.Ctx) error {
 	req := likeentity.IsLikingRequest{}
 	if err := fiberx.ParseAndCheck(c, &req); err != nil {
@@ -13,4 +13,7 @@ var ProviderSetHandler = wire.NewSet(
 	NewLikeHandler,
 	NewTimeLineHandler,
 	NewFileHandler,
+	NewSearchHandler,
+	NewCollectionHandler,
+	NewBlockHandler,
 )
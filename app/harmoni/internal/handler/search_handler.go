@@ -0,0 +1,43 @@
+package handler
+
+import (
+	searchentity "harmoni/app/harmoni/internal/entity/search"
+	"harmoni/app/harmoni/internal/pkg/fiberx"
+	"harmoni/app/harmoni/internal/pkg/middleware"
+	"harmoni/app/harmoni/internal/pkg/reason"
+	"harmoni/app/harmoni/internal/service"
+	"harmoni/internal/pkg/errorx"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SearchHandler struct {
+	ss *service.SearchService
+}
+
+func NewSearchHandler(ss *service.SearchService) *SearchHandler {
+	return &SearchHandler{ss: ss}
+}
+
+func (h *SearchHandler) Search(c *fiber.Ctx) error {
+	req := searchentity.SearchRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	claim := middleware.GetClaimsFromCtx(c.UserContext())
+	if claim != nil {
+		req.UserID = claim.UserID
+	}
+	reply, err := h.ss.Search(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
+
+func (h *SearchHandler) Reindex(c *fiber.Ctx) error {
+	req := searchentity.ReindexRequest{}
+	req.UserID = middleware.GetClaimsFromCtx(c.UserContext()).UserID
+	reply, err := h.ss.Reindex(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
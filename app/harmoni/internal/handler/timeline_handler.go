@@ -42,3 +42,15 @@ func (h *TimeLineHandler) GetHomeTimeLine(c *fiber.Ctx) error {
 
 	return fiberx.HandleResponse(c, err, reply)
 }
+
+func (h *TimeLineHandler) GetTagTimeLine(c *fiber.Ctx) error {
+	req := timelineentity.GetTagTimeLineRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	req.UserID = middleware.GetClaimsFromCtx(c.UserContext()).UserID
+	reply, err := h.ts.GetTagTimeLine(c.UserContext(), &req)
+
+	return fiberx.HandleResponse(c, err, reply)
+}
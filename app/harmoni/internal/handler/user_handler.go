@@ -32,6 +32,16 @@ func (h *UserHandler) GetUsers(c *fiber.Ctx) error {
 	return fiberx.HandleResponse(c, err, reply)
 }
 
+func (h *UserHandler) SuggestUsers(c *fiber.Ctx) error {
+	req := userentity.SuggestUsersRequest{}
+	if err := fiberx.ParseAndCheck(c, &req); err != nil {
+		return fiberx.HandleResponse(c, errorx.BadRequest(reason.RequestFormatError).WithMsg(err.Error()), nil)
+	}
+
+	reply, err := h.us.SuggestUsers(c.UserContext(), &req)
+	return fiberx.HandleResponse(c, err, reply)
+}
+
 func (h *UserHandler) GetUser(c *fiber.Ctx) error {
 	req := userentity.GetUserDetailRequest{}
 	if err := fiberx.ParseAndCheck(c, &req); err != nil {
@@ -24,6 +24,12 @@ type Config struct {
 	MessageQueue *MessageQueue      `mapstructure:"messageQueue"`
 	FileStorage  *FileStorage       `mapstructure:"fileStorage"`
 	Like         *Like              `mapstructure:"like"`
+	Post         *Post              `mapstructure:"post"`
+	Search       *Search            `mapstructure:"search"`
+	Collection   *Collection        `mapstructure:"collection"`
+	Comment      *Comment           `mapstructure:"comment"`
+	Timeline     *Timeline          `mapstructure:"timeline"`
+	Follow       *Follow            `mapstructure:"follow"`
 }
 
 type App struct {
@@ -265,12 +271,149 @@ func SetFileStorageDefault(v *viper.Viper) {
 type Like struct {
 	CacheDuration        time.Duration `mapstructure:"cacheDuration"`
 	DatabaseSyncInterval time.Duration `mapstructure:"databaseSyncInterval"`
+	// 定期从点赞表重新统计点赞数，修复缓存和数据库的偏差
+	ReconcileInterval time.Duration `mapstructure:"reconcileInterval"`
+	// ReconcileSettle 点赞记录异步写入点赞表，发现的偏差等待这段时间后重新统计确认
+	ReconcileSettle time.Duration `mapstructure:"reconcileSettle"`
+	// Reactions 可用的表情回应
+	Reactions []string `mapstructure:"reactions"`
+	// MultipleReactions 允许用户对同一对象同时保留多个表情回应，否则新的回应替换旧的
+	MultipleReactions bool `mapstructure:"multipleReactions"`
+	// NegativeCacheDuration 不存在的帖子、评论和用户的缓存时间
+	NegativeCacheDuration time.Duration `mapstructure:"negativeCacheDuration"`
+	// ObjectFilterCapacity 和 ObjectFilterFalsePositive 决定每种对象的布隆过滤器大小
+	ObjectFilterCapacity      uint64  `mapstructure:"objectFilterCapacity"`
+	ObjectFilterFalsePositive float64 `mapstructure:"objectFilterFalsePositive"`
+	// 定期从数据库重建布隆过滤器，清除已删除的对象
+	ObjectFilterRebuildInterval time.Duration `mapstructure:"objectFilterRebuildInterval"`
 }
 
 func SetLikeDefault(v *viper.Viper) {
 	v.SetDefault("like", map[string]interface{}{
+		"cacheDuration":         "24h",
+		"databaseSyncInterval":  "4h",
+		"reconcileInterval":     "24h",
+		"reconcileSettle":       "1m",
+		"reactions":             []string{"👍", "❤️", "😂", "🎉", "😮", "😢"},
+		"multipleReactions":     true,
+		"negativeCacheDuration": "1m",
+		// a filter of 2M objects takes about 2.3MB
+		"objectFilterCapacity":        2000000,
+		"objectFilterFalsePositive":   0.01,
+		"objectFilterRebuildInterval": "24h",
+	})
+}
+
+// Collection 帖子收藏数先写入缓存，定期同步到数据库
+type Collection struct {
+	CacheDuration        time.Duration `mapstructure:"cacheDuration"`
+	DatabaseSyncInterval time.Duration `mapstructure:"databaseSyncInterval"`
+}
+
+func SetCollectionDefault(v *viper.Viper) {
+	v.SetDefault("collection", map[string]interface{}{
+		"cacheDuration":        "24h",
+		"databaseSyncInterval": "4h",
+	})
+}
+
+// Comment 帖子评论数先写入缓存，定期同步到数据库
+type Comment struct {
+	CacheDuration        time.Duration `mapstructure:"cacheDuration"`
+	DatabaseSyncInterval time.Duration `mapstructure:"databaseSyncInterval"`
+	// 定期从评论表重新统计评论数，修复缓存和数据库的偏差
+	ReconcileInterval time.Duration `mapstructure:"reconcileInterval"`
+	// 评论发布后允许编辑的时间
+	EditWindow time.Duration `mapstructure:"editWindow"`
+	// 评论热度的时间衰减，评论的讨论周期比帖子短
+	HotDecay time.Duration `mapstructure:"hotDecay"`
+}
+
+func SetCommentDefault(v *viper.Viper) {
+	v.SetDefault("comment", map[string]interface{}{
 		"cacheDuration":        "24h",
 		"databaseSyncInterval": "4h",
+		"reconcileInterval":    "24h",
+		"editWindow":           "15m",
+		"hotDecay":             "6h",
+	})
+}
+
+// Timeline 首页时间线，普通作者的帖子发布时推送到粉丝的收件箱，粉丝多的作者在读取时拉取
+type Timeline struct {
+	// 每个收件箱保留的帖子数
+	InboxSize int `mapstructure:"inboxSize"`
+	// 收件箱多久没有读取后过期，过期的收件箱不再推送，下次读取时从数据库重建
+	InboxExpire time.Duration `mapstructure:"inboxExpire"`
+	// 粉丝数达到 PullThreshold 的作者不推送
+	PullThreshold int64 `mapstructure:"pullThreshold"`
+}
+
+func SetTimelineDefault(v *viper.Viper) {
+	v.SetDefault("timeline", map[string]interface{}{
+		"inboxSize":     800,
+		"inboxExpire":   "168h",
+		"pullThreshold": 5000,
+	})
+}
+
+// Follow 关注推荐由定时任务预先计算，新用户没有推荐时使用粉丝最多的用户
+type Follow struct {
+	// 每个用户保留的推荐数
+	SuggestionSize int `mapstructure:"suggestionSize"`
+	// 重新计算推荐的间隔，推荐在两个间隔后过期
+	SuggestionInterval time.Duration `mapstructure:"suggestionInterval"`
+	// 在这段时间内发过帖子的用户排名靠前，只为这段时间内活跃的用户重新计算推荐
+	ActiveWindow time.Duration `mapstructure:"activeWindow"`
+	// 静音变化发送给通知服务的间隔
+	MuteRelayInterval time.Duration `mapstructure:"muteRelayInterval"`
+}
+
+func SetFollowDefault(v *viper.Viper) {
+	v.SetDefault("follow", map[string]interface{}{
+		"suggestionSize":     100,
+		"suggestionInterval": "6h",
+		"activeWindow":       "168h",
+		"muteRelayInterval":  "10s",
+	})
+}
+
+type Post struct {
+	// 帖子删除后在回收站中保留的时间，超时后彻底清除
+	TrashRetention     time.Duration `mapstructure:"trashRetention"`
+	TrashPurgeInterval time.Duration `mapstructure:"trashPurgeInterval"`
+	// 检查定时发布帖子的间隔
+	SchedulePublishInterval time.Duration `mapstructure:"schedulePublishInterval"`
+	// 热度的时间衰减，晚发布 HotDecay 的帖子只需十分之一的互动就能排在前面
+	HotDecay time.Duration `mapstructure:"hotDecay"`
+	// 全站和每个话题热榜保留的帖子数
+	HotRankSize int `mapstructure:"hotRankSize"`
+	// 定期从数据库重建热榜，修复丢失事件造成的偏差
+	HotRebuildInterval time.Duration `mapstructure:"hotRebuildInterval"`
+}
+
+func SetPostDefault(v *viper.Viper) {
+	v.SetDefault("post", map[string]interface{}{
+		"trashRetention":          "720h",
+		"trashPurgeInterval":      "1h",
+		"schedulePublishInterval": "1m",
+		"hotDecay":                "12h30m",
+		"hotRankSize":             1000,
+		"hotRebuildInterval":      "24h",
+	})
+}
+
+type Search struct {
+	// 定期从数据库重建索引，修复丢失事件造成的偏差
+	ReindexInterval time.Duration `mapstructure:"reindexInterval"`
+	// 搜索结果中内容片段的最大字数
+	SnippetLength int `mapstructure:"snippetLength"`
+}
+
+func SetSearchDefault(v *viper.Viper) {
+	v.SetDefault("search", map[string]interface{}{
+		"reindexInterval": "24h",
+		"snippetLength":   120,
 	})
 }
 
@@ -285,6 +428,12 @@ func ReadConfig(filePath string) (*Config, error) {
 	SetEmailDefault(v)
 	SetFileStorageDefault(v)
 	SetLikeDefault(v)
+	SetPostDefault(v)
+	SetSearchDefault(v)
+	SetCollectionDefault(v)
+	SetCommentDefault(v)
+	SetTimelineDefault(v)
+	SetFollowDefault(v)
 
 	filename := path.Base(filePath)
 	fileext := path.Ext(filePath)
@@ -1,6 +1,8 @@
 package data
 
 import (
+	blockentity "harmoni/app/harmoni/internal/entity/block"
+	collectionentity "harmoni/app/harmoni/internal/entity/collection"
 	fileentity "harmoni/app/harmoni/internal/entity/file"
 	followentity "harmoni/app/harmoni/internal/entity/follow"
 	likeentity "harmoni/app/harmoni/internal/entity/like"
@@ -10,6 +12,7 @@ import (
 	userentity "harmoni/app/harmoni/internal/entity/user"
 	"harmoni/app/harmoni/internal/infrastructure/po/comment"
 	"harmoni/internal/conf"
+	"harmoni/internal/types/object"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -56,9 +59,26 @@ func NewDB(conf *conf.Database, logger *zap.Logger) (*gorm.DB, func(), error) {
 	// SetConnMaxLifetime 设置了连接可复用的最大时间。
 	sqlDB.SetConnMaxLifetime(conf.ConnMaxLifeTime)
 
-	err = db.AutoMigrate(&userentity.User{}, &comment.Comment{}, &postentity.Post{},
-		&tagentity.Tag{}, &followentity.Follow{}, &likeentity.Like{},
-		&postreltag.PostTag{}, &fileentity.File{})
+	// 点赞记录早期按 liking_id 唯一，每个对象只能保存一条记录
+	if db.Migrator().HasIndex(&likeentity.Like{}, "idx_like_liking_id") {
+		err = db.Migrator().DropIndex(&likeentity.Like{}, "idx_like_liking_id")
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	err = db.AutoMigrate(&userentity.User{}, &comment.Comment{}, &comment.CommentArea{}, &postentity.Post{},
+		&postentity.PostRevision{}, &tagentity.Tag{}, &followentity.Follow{}, &followentity.PendingFollow{}, &likeentity.Like{},
+		&postreltag.PostTag{}, &fileentity.File{}, &collectionentity.Collection{}, &collectionentity.CollectionItem{},
+		&blockentity.Block{}, &blockentity.MuteEvent{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// replied_at 新增前发布的帖子按最后一条评论的时间回填，没有评论时取发布时间，草稿仍为空
+	err = db.Exec("UPDATE post SET replied_at = COALESCE((SELECT MAX(c.created_at) FROM comment c "+
+		"WHERE c.object_id = post.post_id AND c.object_type = ? AND c.deleted_at IS NULL), post.created_at) "+
+		"WHERE replied_at IS NULL AND status = ?", object.ObjectTypePost, postentity.PostStatusPublished).Error
 	if err != nil {
 		return nil, nil, err
 	}
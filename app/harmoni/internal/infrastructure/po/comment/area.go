@@ -0,0 +1,35 @@
+package comment
+
+import (
+	"harmoni/app/harmoni/internal/entity/comment"
+	"harmoni/internal/types/object"
+
+	"gorm.io/gorm"
+)
+
+// CommentArea 评论区的设置，没有设置的评论区对所有人开放
+type CommentArea struct {
+	gorm.Model
+	ObjectID   int64              `gorm:"not null;uniqueIndex:idx_comment_area"`
+	ObjectType object.ObjectType  `gorm:"not null;uniqueIndex:idx_comment_area"`
+	Status     comment.AreaStatus `gorm:"not null"`
+}
+
+func (CommentArea) TableName() string {
+	return "comment_area"
+}
+
+func (a *CommentArea) ToDomain() *comment.Area {
+	return &comment.Area{
+		ObjectID:   a.ObjectID,
+		ObjectType: a.ObjectType,
+		Status:     a.Status,
+	}
+}
+
+func (a *CommentArea) FromDomain(area *comment.Area) *CommentArea {
+	a.ObjectID = area.ObjectID
+	a.ObjectType = area.ObjectType
+	a.Status = area.Status
+	return a
+}
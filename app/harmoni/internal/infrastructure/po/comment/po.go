@@ -5,21 +5,30 @@ import (
 	"harmoni/app/harmoni/internal/entity/comment"
 	"harmoni/app/harmoni/internal/entity/user"
 	"harmoni/app/harmoni/internal/pkg/common"
+	"harmoni/internal/types/object"
 
 	"gorm.io/gorm"
 )
 
 type Comment struct {
 	gorm.Model
-	CommentID int64 `gorm:"uniqueIndex"`
-	ObjectID  int64
-	AuthorID  int64
-	ParentID  int64
-	RootID    int64
-	DialogID  int64
+	CommentID int64 `gorm:"uniqueIndex;index:idx_comment_root,priority:2;index:idx_comment_dialog,priority:2"`
+	ObjectID  int64 `gorm:"index"`
+	// ObjectType 评论区所属对象的类型，早期的评论都在帖子下
+	ObjectType object.ObjectType `gorm:"not null;default:1"`
+	AuthorID   int64
+	ParentID   int64
+	RootID     int64 `gorm:"index:idx_comment_root,priority:1"`
+	// DialogID 两个用户之间连续互相回复的对话，值为对话中第一条回复的ID
+	DialogID  int64  `gorm:"index:idx_comment_dialog,priority:1"`
 	Content   string `gorm:"type:varchar(2048)"`
 	ToUserIDs string `gorm:"type:varchar(2048)"`
-	LikeCount int64  `gorm:"not null"`
+	// MentionUserIDs 内容中提及的用户
+	MentionUserIDs string `gorm:"type:varchar(1024)"`
+	LikeCount      int64  `gorm:"not null"`
+	DislikeCount   int64  `gorm:"not null;default:0"`
+	// Deleted 评论被删除后保留为占位，回复关系不受影响
+	Deleted bool `gorm:"not null;default:false"`
 }
 
 func (Comment) TableName() string {
@@ -28,17 +37,21 @@ func (Comment) TableName() string {
 
 func (c *Comment) ToDomain() *comment.Comment {
 	comm := &comment.Comment{
-		CommentID: c.CommentID,
-		ObjectID:  c.ObjectID,
+		CommentID:  c.CommentID,
+		ObjectID:   c.ObjectID,
+		ObjectType: c.ObjectType,
 		Author: &user.UserBasicInfo{
 			UserID: c.AuthorID,
 		},
-		ParentID:  c.ParentID,
-		RootID:    c.RootID,
-		Content:   c.Content,
-		CreatedAt: c.CreatedAt,
-		UpdatedAt: c.UpdatedAt,
-		LikeCount: c.LikeCount,
+		ParentID:     c.ParentID,
+		RootID:       c.RootID,
+		DialogID:     c.DialogID,
+		Content:      c.Content,
+		CreatedAt:    c.CreatedAt,
+		UpdatedAt:    c.UpdatedAt,
+		LikeCount:    c.LikeCount,
+		DislikeCount: c.DislikeCount,
+		Deleted:      c.Deleted,
 	}
 	if len(c.ToUserIDs) != 0 {
 		comm.ToMembers = make([]*user.UserBasicInfo, 0, len(c.ToUserIDs))
@@ -48,24 +61,39 @@ func (c *Comment) ToDomain() *comment.Comment {
 			comm.ToMembers = append(comm.ToMembers, &user.UserBasicInfo{UserID: toUserID})
 		}
 	}
+	if len(c.MentionUserIDs) != 0 {
+		data := []int64{}
+		json.Unmarshal(common.StringToBytes(c.MentionUserIDs), &data)
+		comm.Mentions = user.ToBasicInfos(data)
+	}
 	return comm
 }
 
 func (c *Comment) FromDomain(ce *comment.Comment) *Comment {
 	c.CommentID = ce.CommentID
 	c.ObjectID = ce.ObjectID
+	c.ObjectType = ce.ObjectType
 	c.AuthorID = ce.Author.UserID
 	c.ParentID = ce.ParentID
 	c.RootID = ce.RootID
+	c.DialogID = ce.DialogID
 	c.Content = ce.Content
 	c.CreatedAt = ce.CreatedAt
 	c.UpdatedAt = ce.UpdatedAt
 	c.LikeCount = ce.LikeCount
+	c.DislikeCount = ce.DislikeCount
+	c.Deleted = ce.Deleted
 	toUserIDs := make([]int64, 0, len(ce.ToMembers))
 	for _, toUser := range ce.ToMembers {
 		toUserIDs = append(toUserIDs, toUser.UserID)
 	}
 	data, _ := json.Marshal(&toUserIDs)
 	c.ToUserIDs = common.BytesToString(data)
+	mentionUserIDs := make([]int64, 0, len(ce.Mentions))
+	for _, mentioned := range ce.Mentions {
+		mentionUserIDs = append(mentionUserIDs, mentioned.UserID)
+	}
+	data, _ = json.Marshal(&mentionUserIDs)
+	c.MentionUserIDs = common.BytesToString(data)
 	return c
 }
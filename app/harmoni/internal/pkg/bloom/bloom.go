@@ -0,0 +1,46 @@
+// Package bloom 计算布隆过滤器中元素对应的位，位图本身由调用方保存
+package bloom
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+)
+
+// Filter 布隆过滤器的位数和哈希函数个数
+type Filter struct {
+	Bits   uint64
+	Hashes int
+}
+
+// New 按预计的元素个数和误判率计算位数和哈希函数个数
+func New(capacity uint64, falsePositive float64) Filter {
+	if capacity == 0 {
+		capacity = 1
+	}
+	if falsePositive <= 0 || falsePositive >= 1 {
+		falsePositive = 0.01
+	}
+	bits := math.Ceil(-float64(capacity) * math.Log(falsePositive) / (math.Ln2 * math.Ln2))
+	hashes := int(math.Round(bits / float64(capacity) * math.Ln2))
+	if hashes < 1 {
+		hashes = 1
+	}
+	return Filter{Bits: uint64(bits), Hashes: hashes}
+}
+
+// Offsets 元素对应的位，用两个哈希值组合出所有哈希函数
+func (f Filter) Offsets(id int64) []uint64 {
+	bs := make([]byte, 8)
+	binary.LittleEndian.PutUint64(bs, uint64(id))
+	h := fnv.New64a()
+	h.Write(bs)
+	sum := h.Sum64()
+	h1, h2 := sum&math.MaxUint32, sum>>32|1
+
+	offsets := make([]uint64, f.Hashes)
+	for i := range offsets {
+		offsets[i] = (h1 + uint64(i)*h2) % f.Bits
+	}
+	return offsets
+}
@@ -0,0 +1,49 @@
+package bloom_test
+
+import (
+	"harmoni/app/harmoni/internal/pkg/bloom"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	f := bloom.New(1000000, 0.01)
+	if f.Bits < 9000000 || f.Bits > 10000000 {
+		t.Errorf("Bits = %d, want about 9.6M", f.Bits)
+	}
+	if f.Hashes != 7 {
+		t.Errorf("Hashes = %d, want 7", f.Hashes)
+	}
+}
+
+func TestFalsePositive(t *testing.T) {
+	f := bloom.New(10000, 0.01)
+	bits := make([]bool, f.Bits)
+	for id := int64(1); id <= 10000; id++ {
+		for _, offset := range f.Offsets(id) {
+			bits[offset] = true
+		}
+	}
+
+	contains := func(id int64) bool {
+		for _, offset := range f.Offsets(id) {
+			if !bits[offset] {
+				return false
+			}
+		}
+		return true
+	}
+	for id := int64(1); id <= 10000; id++ {
+		if !contains(id) {
+			t.Fatalf("added id %d is not contained", id)
+		}
+	}
+	positives := 0
+	for id := int64(1000000); id < 1100000; id++ {
+		if contains(id) {
+			positives++
+		}
+	}
+	if rate := float64(positives) / 100000; rate > 0.02 {
+		t.Errorf("false positive rate = %f, want about 0.01", rate)
+	}
+}
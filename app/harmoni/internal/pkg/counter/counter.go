@@ -0,0 +1,167 @@
+// Package counter 对象计数按ID分散保存在多个 Redis hash 中，hash 的 key 为前缀加分片号
+package counter
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Shards 每个前缀下的分片数
+const Shards = 5
+
+// Shard 对象计数所在的分片
+func Shard(id int64) uint32 {
+	bs := make([]byte, 8)
+	binary.LittleEndian.PutUint64(bs, uint64(id))
+	return crc32.ChecksumIEEE(bs) % Shards
+}
+
+// Key 对象计数所在的 hash
+func Key(prefix string, id int64) string {
+	return fmt.Sprintf("%s%d", prefix, Shard(id))
+}
+
+// Fields 按所在的 hash 分组对象ID
+func Fields(prefix string, ids []int64) map[string][]string {
+	fields := make(map[string][]string)
+	for _, id := range ids {
+		key := Key(prefix, id)
+		fields[key] = append(fields[key], strconv.FormatInt(id, 10))
+	}
+	return fields
+}
+
+// incrScript 只在计数已缓存时累加，未缓存的计数下次读取时从数据库加载
+var incrScript = redis.NewScript(`
+if redis.call('HEXISTS', KEYS[1], ARGV[1]) == 1 then
+	redis.call('HINCRBY', KEYS[1], ARGV[1], ARGV[2])
+end
+return 1
+`)
+
+// Incr 累加已缓存的计数
+func Incr(ctx context.Context, rdb redis.UniversalClient, prefix string, id, delta int64) error {
+	return incrScript.Run(ctx, rdb, []string{Key(prefix, id)}, id, delta).Err()
+}
+
+// casScript 计数仍为预期值时才覆盖，ARGV 依次为对象ID、预期值和新值
+var casScript = redis.NewScript(`
+local n = 0
+for i = 1, #ARGV, 3 do
+	if redis.call('HGET', KEYS[1], ARGV[i]) == ARGV[i + 1] then
+		redis.call('HSET', KEYS[1], ARGV[i], ARGV[i + 2])
+		n = n + 1
+	end
+end
+return n
+`)
+
+// Swap 缓存中的计数仍为 Old 时覆盖为 New
+type Swap struct {
+	ID  int64
+	Old int64
+	New int64
+}
+
+// CompareAndSet 覆盖期间没有变化的计数，返回覆盖的个数
+func CompareAndSet(ctx context.Context, rdb redis.UniversalClient, prefix string, swaps []Swap) (int64, error) {
+	args := make(map[string][]interface{})
+	for _, swap := range swaps {
+		key := Key(prefix, swap.ID)
+		args[key] = append(args[key], swap.ID, swap.Old, swap.New)
+	}
+
+	var swapped int64
+	for key, argv := range args {
+		n, err := casScript.Run(ctx, rdb, []string{key}, argv...).Int64()
+		if err != nil {
+			return swapped, err
+		}
+		swapped += n
+	}
+	return swapped, nil
+}
+
+// Set 缓存计数，hash 没有过期时间时设置为 expiration
+func Set(ctx context.Context, rdb redis.UniversalClient, prefix string, counts map[int64]int64, expiration time.Duration) error {
+	keyvals := make(map[string][]interface{})
+	for id, count := range counts {
+		key := Key(prefix, id)
+		keyvals[key] = append(keyvals[key], id, count)
+	}
+
+	for key, kvs := range keyvals {
+		err := rdb.HSet(ctx, key, kvs...).Err()
+		if err != nil {
+			return err
+		}
+		err = rdb.ExpireNX(ctx, key, expiration).Err()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete 删除对象的计数
+func Delete(ctx context.Context, rdb redis.UniversalClient, prefix string, ids []int64) error {
+	for key, fields := range Fields(prefix, ids) {
+		err := rdb.HDel(ctx, key, fields...).Err()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get 读取已缓存的计数，未缓存的对象ID单独返回。
+// 缓存为占位值的对象视为已缓存，不出现在计数中
+func Get(ctx context.Context, rdb redis.UniversalClient, prefix string, ids []int64) (map[int64]int64, []int64, error) {
+	counts := make(map[int64]int64, len(ids))
+	notCached := []int64{}
+	for key, fields := range Fields(prefix, ids) {
+		values, err := rdb.HMGet(ctx, key, fields...).Result()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for i, field := range fields {
+			id, _ := strconv.ParseInt(field, 10, 64)
+			value, ok := values[i].(string)
+			if !ok {
+				notCached = append(notCached, id)
+				continue
+			}
+			if count, err := strconv.ParseInt(value, 10, 64); err == nil {
+				counts[id] = count
+			}
+		}
+	}
+	return counts, notCached, nil
+}
+
+// All 读取前缀下所有已缓存的计数
+func All(ctx context.Context, rdb redis.UniversalClient, prefix string) (map[int64]int64, error) {
+	counts := make(map[int64]int64)
+	for i := 0; i < Shards; i++ {
+		kvs, err := rdb.HGetAll(ctx, fmt.Sprintf("%s%d", prefix, i)).Result()
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range kvs {
+			count, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				continue
+			}
+			id, _ := strconv.ParseInt(k, 10, 64)
+			counts[id] = count
+		}
+	}
+	return counts, nil
+}
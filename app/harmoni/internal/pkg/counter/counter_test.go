@@ -0,0 +1,38 @@
+package counter_test
+
+import (
+	"harmoni/app/harmoni/internal/pkg/counter"
+	"strconv"
+	"testing"
+)
+
+func TestKey(t *testing.T) {
+	for _, id := range []int64{0, 1, 42, 1 << 40} {
+		shard := counter.Shard(id)
+		if shard >= counter.Shards {
+			t.Errorf("Shard(%d) = %d, want < %d", id, shard, counter.Shards)
+		}
+		if got, want := counter.Key("post:like.count:", id), "post:like.count:"+strconv.Itoa(int(shard)); got != want {
+			t.Errorf("Key(%d) = %q, want %q", id, got, want)
+		}
+	}
+}
+
+func TestFields(t *testing.T) {
+	ids := []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	fields := counter.Fields("p:", ids)
+
+	total := 0
+	for key, ids := range fields {
+		for _, field := range ids {
+			id, _ := strconv.ParseInt(field, 10, 64)
+			if got := counter.Key("p:", id); got != key {
+				t.Errorf("id %d grouped under %q, want %q", id, key, got)
+			}
+		}
+		total += len(ids)
+	}
+	if total != len(ids) {
+		t.Errorf("grouped %d ids, want %d", total, len(ids))
+	}
+}
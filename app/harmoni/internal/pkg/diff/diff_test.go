@@ -0,0 +1,53 @@
+package diff_test
+
+import (
+	"harmoni/app/harmoni/internal/pkg/diff"
+	"strings"
+	"testing"
+)
+
+func apply(lines []diff.Line) (string, string) {
+	var a, b []string
+	for _, line := range lines {
+		switch line.Op {
+		case diff.OpEqual:
+			a = append(a, line.Text)
+			b = append(b, line.Text)
+		case diff.OpDelete:
+			a = append(a, line.Text)
+		case diff.OpInsert:
+			b = append(b, line.Text)
+		}
+	}
+	return strings.Join(a, "\n"), strings.Join(b, "\n")
+}
+
+func TestLines(t *testing.T) {
+	cases := []struct {
+		a, b  string
+		edits int
+	}{
+		{"", "", 0},
+		{"a", "", 1},
+		{"", "a\nb", 2},
+		{"a\nb\nc", "a\nb\nc", 0},
+		{"a\nb\nc\na\nb\nb\na", "c\nb\na\nb\na\nc", 5},
+		{"标题\n正文第一行\n正文第二行", "标题\n正文第一行（已修改）\n正文第二行", 2},
+	}
+	for _, c := range cases {
+		lines := diff.Lines(c.a, c.b)
+		a, b := apply(lines)
+		if a != c.a || b != c.b {
+			t.Fatalf("diff of %q -> %q does not reproduce inputs: got %q -> %q", c.a, c.b, a, b)
+		}
+		edits := 0
+		for _, line := range lines {
+			if line.Op != diff.OpEqual {
+				edits++
+			}
+		}
+		if edits != c.edits {
+			t.Fatalf("diff of %q -> %q: want %d edits, got %d", c.a, c.b, c.edits, edits)
+		}
+	}
+}
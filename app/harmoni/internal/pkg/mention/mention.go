@@ -0,0 +1,71 @@
+package mention
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MaxMentions 一段内容中最多解析的提及数，超出的部分按普通文本处理
+const MaxMentions = 20
+
+// 用户名允许的字符中去掉了 "!"，避免 "@name!" 被解析成另一个用户名
+var mentionReg = regexp.MustCompile(`(^|[^-_a-zA-Z0-9\x{4e00}-\x{9fa5}])@([-_a-zA-Z0-9\x{4e00}-\x{9fa5}]{1,32})`)
+
+type match struct {
+	start, end int
+	name       string
+}
+
+func find(content string) []match {
+	locs := mentionReg.FindAllStringSubmatchIndex(content, -1)
+	matches := make([]match, len(locs))
+	for i, loc := range locs {
+		// loc[3] is the position of the "@" sign, the name follows it
+		matches[i] = match{
+			start: loc[3],
+			end:   loc[5],
+			name:  content[loc[4]:loc[5]],
+		}
+	}
+	return matches
+}
+
+// Parse 按出现顺序返回内容中提及的用户名，已去重
+func Parse(content string) []string {
+	names := []string{}
+	visited := map[string]bool{}
+	for _, m := range find(content) {
+		if visited[m.name] {
+			continue
+		}
+		visited[m.name] = true
+		names = append(names, m.name)
+		if len(names) == MaxMentions {
+			break
+		}
+	}
+	return names
+}
+
+// Render 把内容中能解析到用户的提及替换为用户主页的链接，内容应当已经转义过
+func Render(content string, users map[string]int64) string {
+	matches := find(content)
+	if len(matches) == 0 || len(users) == 0 {
+		return content
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		userID, ok := users[m.name]
+		if !ok {
+			continue
+		}
+		b.WriteString(content[last:m.start])
+		fmt.Fprintf(&b, `<a href="/user/%d" class="mention">@%s</a>`, userID, m.name)
+		last = m.end
+	}
+	b.WriteString(content[last:])
+	return b.String()
+}
@@ -0,0 +1,37 @@
+package mention_test
+
+import (
+	"harmoni/app/harmoni/internal/pkg/mention"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		content string
+		names   []string
+	}{
+		{"", []string{}},
+		{"no mentions", []string{}},
+		{"@alice hello", []string{"alice"}},
+		{"hi @alice and @bob, @alice again", []string{"alice", "bob"}},
+		{"mail me at bob@example.com", []string{}},
+		{"thanks @bob!", []string{"bob"}},
+		{"&#34;@张三&#34;", []string{"张三"}},
+	}
+	for _, c := range cases {
+		names := mention.Parse(c.content)
+		if !reflect.DeepEqual(names, c.names) {
+			t.Errorf("Parse(%q) = %v, want %v", c.content, names, c.names)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	users := map[string]int64{"alice": 1}
+	got := mention.Render("hi @alice and @bob", users)
+	want := `hi <a href="/user/1" class="mention">@alice</a> and @bob`
+	if got != want {
+		t.Errorf("Render = %q, want %q", got, want)
+	}
+}
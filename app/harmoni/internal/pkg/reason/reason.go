@@ -23,6 +23,16 @@ const (
 	FollowAlreadyExist = "error.follow.already_exist"
 	FollowNotFound     = "error.follow.not_found"
 
+	FollowRequestAlreadyExist = "error.follow.request_already_exist"
+	FollowRequestNotFound     = "error.follow.request_not_found"
+	// PrivateAccount 私密账号的帖子只对已关注的用户可见
+	PrivateAccount = "error.follow.private_account"
+
+	BlockAlreadyExist     = "error.block.already_exist"
+	BlockNotFound         = "error.block.not_found"
+	BlockedByUser         = "error.block.blocked_by_user"
+	DisallowBlockYourSelf = "error.block.disallow_block_your_self"
+
 	TypeNotSupport = "error.type_not_support"
 
 	AuthHeaderInvalid                = "error.auth.header_invalid"
@@ -67,10 +77,30 @@ const (
 	TagAlreadyExist                  = "error.tag.already_exist"
 	TagDuplicateInObeject            = "error.tag.duplicate_in_object"
 	PostNotFound                     = "error.post.not_found"
+	PostEditWithoutPermission        = "error.post.edit_without_permission"
+	PostRevisionNotFound             = "error.post.revision_not_found"
+	PostDeleteWithoutPermission      = "error.post.delete_without_permission"
+	PostRestoreWithoutPermission     = "error.post.restore_without_permission"
+	PostAlreadyPublished             = "error.post.already_published"
+	PostNotPublished                 = "error.post.not_published"
+	PostDraftIncomplete              = "error.post.draft_incomplete"
+	SearchReindexInProgress          = "error.search.reindex_in_progress"
+	CollectionNotFound               = "error.collection.not_found"
+	CollectionEditWithoutPermission  = "error.collection.edit_without_permission"
+	CollectionPostAlreadyExist       = "error.collection.post_already_exist"
+	CollectionPostNotFound           = "error.collection.post_not_found"
+	CommentDeleteWithoutPermission   = "error.comment.delete_without_permission"
+	ModeratorRequired                = "error.user.moderator_required"
+	CommentAreaClosed                = "error.comment.area_closed"
+	CommentAreaFollowersOnly         = "error.comment.area_followers_only"
+	CommentAreaWithoutPermission     = "error.comment.area_without_permission"
+	CommentAreaNotSupported          = "error.comment.area_not_supported"
 	RankFailToMeetTheCondition       = "error.rank.fail_to_meet_the_condition"
 	LikeAlreadyExist                 = "error.like.already_exist"
 	LikeCancelFailToNotLiked         = "error.like.cancel_fail_to_not_liked"
 	LikeUnknownType                  = "error.like.unknown_type"
+	LikeReactionNotSupported         = "error.like.reaction_not_supported"
+	LikeReconcileInProgress          = "error.like.reconcile_in_progress"
 	DisallowLikeYourSelf             = "error.object.disallow_like_your_self"
 	VoteRankFailToMeetTheCondition   = "error.rank.vote_fail_to_meet_the_condition"
 	ThemeNotFound                    = "error.theme.not_found"
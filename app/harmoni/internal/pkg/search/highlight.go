@@ -0,0 +1,97 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	HighlightPre  = "<em>"
+	HighlightPost = "</em>"
+	ellipsis      = "..."
+)
+
+type span struct {
+	start, end int
+}
+
+func matchedSpans(text string, terms []string) []span {
+	termSet := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		termSet[term] = true
+	}
+
+	spans := []span{}
+	for _, token := range Tokenize(text) {
+		if !termSet[token.Term] {
+			continue
+		}
+		// tokens are ordered by start, merge overlapping bigrams
+		if n := len(spans); n > 0 && token.Start <= spans[n-1].end {
+			if token.End > spans[n-1].end {
+				spans[n-1].end = token.End
+			}
+			continue
+		}
+		spans = append(spans, span{start: token.Start, end: token.End})
+	}
+	return spans
+}
+
+// Highlight 截取包含第一个命中词的片段，命中的词用 <em> 标记，其余部分做 HTML 转义。
+// maxRunes 小于等于 0 时返回全文
+func Highlight(text string, terms []string, maxRunes int) string {
+	spans := matchedSpans(text, terms)
+
+	from, to := 0, len(text)
+	if maxRunes > 0 && utf8.RuneCountInString(text) > maxRunes {
+		if len(spans) != 0 {
+			// keep some context before the first match
+			from = moveBack(text, spans[0].start, maxRunes/4)
+		}
+		to = moveForward(text, from, maxRunes)
+	}
+
+	var sb strings.Builder
+	if from > 0 {
+		sb.WriteString(ellipsis)
+	}
+	pos := from
+	for _, s := range spans {
+		if s.end <= from {
+			continue
+		}
+		if s.start >= to {
+			break
+		}
+		start, end := max(s.start, from), min(s.end, to)
+		sb.WriteString(html.EscapeString(text[pos:start]))
+		sb.WriteString(HighlightPre)
+		sb.WriteString(html.EscapeString(text[start:end]))
+		sb.WriteString(HighlightPost)
+		pos = end
+	}
+	sb.WriteString(html.EscapeString(text[pos:to]))
+	if to < len(text) {
+		sb.WriteString(ellipsis)
+	}
+
+	return sb.String()
+}
+
+func moveBack(text string, i int, runes int) int {
+	for ; runes > 0 && i > 0; runes-- {
+		_, size := utf8.DecodeLastRuneInString(text[:i])
+		i -= size
+	}
+	return i
+}
+
+func moveForward(text string, i int, runes int) int {
+	for ; runes > 0 && i < len(text); runes-- {
+		_, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+	}
+	return i
+}
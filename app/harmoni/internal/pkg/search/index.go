@@ -0,0 +1,216 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+type DocType uint8
+
+const (
+	DocPost DocType = iota + 1
+	DocComment
+)
+
+const (
+	// titleBoost 标题中的词权重更高
+	titleBoost = 2.0
+	bm25K1     = 1.2
+	bm25B      = 0.75
+)
+
+type Document struct {
+	Type DocType
+	ID   int64
+	// 评论所属的帖子，帖子的 PostID 等于 ID
+	PostID   int64
+	AuthorID int64
+	// PostAuthorID 所属帖子的作者，用于隐藏私密账号的帖子和帖子下的评论
+	PostAuthorID int64
+	Title        string
+	Content      string
+	CreatedAt    time.Time
+}
+
+type Hit struct {
+	Document
+	Score float64
+}
+
+type Query struct {
+	Text   string
+	Type   DocType // 0 表示不限类型
+	Offset int
+	Limit  int
+}
+
+type docKey struct {
+	typ DocType
+	id  int64
+}
+
+type docEntry struct {
+	doc    Document
+	terms  map[string]float64
+	length float64
+}
+
+// Index 内存中的倒排索引，使用 BM25 排序，并发安全
+type Index struct {
+	mu       sync.RWMutex
+	docs     map[docKey]*docEntry
+	postings map[string]map[docKey]float64
+	byPost   map[int64]map[docKey]struct{}
+	totalLen float64
+}
+
+func NewIndex() *Index {
+	return &Index{
+		docs:     map[docKey]*docEntry{},
+		postings: map[string]map[docKey]float64{},
+		byPost:   map[int64]map[docKey]struct{}{},
+	}
+}
+
+func (idx *Index) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.docs)
+}
+
+// Add 添加或替换文档
+func (idx *Index) Add(doc Document) {
+	entry := &docEntry{doc: doc, terms: map[string]float64{}}
+	for _, token := range Tokenize(doc.Title) {
+		entry.terms[token.Term] += titleBoost
+		entry.length += titleBoost
+	}
+	for _, token := range Tokenize(doc.Content) {
+		entry.terms[token.Term]++
+		entry.length++
+	}
+
+	key := docKey{typ: doc.Type, id: doc.ID}
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(key)
+	idx.docs[key] = entry
+	idx.totalLen += entry.length
+	for term, tf := range entry.terms {
+		if idx.postings[term] == nil {
+			idx.postings[term] = map[docKey]float64{}
+		}
+		idx.postings[term][key] = tf
+	}
+	if idx.byPost[doc.PostID] == nil {
+		idx.byPost[doc.PostID] = map[docKey]struct{}{}
+	}
+	idx.byPost[doc.PostID][key] = struct{}{}
+}
+
+func (idx *Index) Remove(typ DocType, id int64) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.remove(docKey{typ: typ, id: id})
+}
+
+// RemovePost 删除帖子以及帖子下的所有评论
+func (idx *Index) RemovePost(postID int64) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for key := range idx.byPost[postID] {
+		idx.remove(key)
+	}
+}
+
+func (idx *Index) remove(key docKey) {
+	entry, ok := idx.docs[key]
+	if !ok {
+		return
+	}
+
+	for term := range entry.terms {
+		delete(idx.postings[term], key)
+		if len(idx.postings[term]) == 0 {
+			delete(idx.postings, term)
+		}
+	}
+	delete(idx.byPost[entry.doc.PostID], key)
+	if len(idx.byPost[entry.doc.PostID]) == 0 {
+		delete(idx.byPost, entry.doc.PostID)
+	}
+	idx.totalLen -= entry.length
+	delete(idx.docs, key)
+}
+
+// Search 返回包含全部查询词的文档，按相关度从高到低排序，同分时新的在前
+func (idx *Index) Search(q Query) ([]Hit, int) {
+	terms := QueryTerms(q.Text)
+	if len(terms) == 0 {
+		return []Hit{}, 0
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	// start from the rarest term to keep the candidate set small
+	postings := make([]map[docKey]float64, len(terms))
+	for i, term := range terms {
+		postings[i] = idx.postings[term]
+		if len(postings[i]) == 0 {
+			return []Hit{}, 0
+		}
+	}
+	sort.Slice(postings, func(i, j int) bool {
+		return len(postings[i]) < len(postings[j])
+	})
+
+	n := float64(len(idx.docs))
+	avgLen := idx.totalLen / n
+	hits := []Hit{}
+	for key := range postings[0] {
+		if q.Type != 0 && key.typ != q.Type {
+			continue
+		}
+
+		entry := idx.docs[key]
+		score := 0.0
+		matched := true
+		for _, posting := range postings {
+			tf, ok := posting[key]
+			if !ok {
+				matched = false
+				break
+			}
+			df := float64(len(posting))
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			score += idf * tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*entry.length/avgLen))
+		}
+		if matched {
+			hits = append(hits, Hit{Document: entry.doc, Score: score})
+		}
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if !hits[i].CreatedAt.Equal(hits[j].CreatedAt) {
+			return hits[i].CreatedAt.After(hits[j].CreatedAt)
+		}
+		return hits[i].ID > hits[j].ID
+	})
+
+	total := len(hits)
+	if q.Offset >= total {
+		return []Hit{}, total
+	}
+	end := total
+	if q.Limit > 0 && q.Offset+q.Limit < total {
+		end = q.Offset + q.Limit
+	}
+	return hits[q.Offset:end], total
+}
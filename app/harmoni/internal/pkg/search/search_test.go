@@ -0,0 +1,72 @@
+package search_test
+
+import (
+	"harmoni/app/harmoni/internal/pkg/search"
+	"reflect"
+	"testing"
+)
+
+func TestQueryTerms(t *testing.T) {
+	cases := []struct {
+		text  string
+		terms []string
+	}{
+		{"", []string{}},
+		{"Hello, World hello", []string{"hello", "world"}},
+		{"猫", []string{"猫"}},
+		{"数据库", []string{"数据", "据库"}},
+		{"Go语言2023", []string{"go", "语言", "2023"}},
+	}
+	for _, c := range cases {
+		terms := search.QueryTerms(c.text)
+		if !reflect.DeepEqual(terms, c.terms) {
+			t.Fatalf("QueryTerms(%q) = %q, want %q", c.text, terms, c.terms)
+		}
+	}
+}
+
+func TestIndexSearch(t *testing.T) {
+	idx := search.NewIndex()
+	idx.Add(search.Document{Type: search.DocPost, ID: 1, PostID: 1, Title: "数据库索引", Content: "聊聊 MySQL 的索引"})
+	idx.Add(search.Document{Type: search.DocPost, ID: 2, PostID: 2, Title: "周末去哪儿", Content: "想找个地方看看数据库相关的书"})
+	idx.Add(search.Document{Type: search.DocComment, ID: 3, PostID: 1, Content: "数据库索引要看执行计划"})
+
+	hits, total := idx.Search(search.Query{Text: "数据库"})
+	if total != 3 || hits[0].ID != 1 {
+		t.Fatalf("want post 1 ranked first among 3 hits, got %d hits: %+v", total, hits)
+	}
+
+	hits, total = idx.Search(search.Query{Text: "数据库", Type: search.DocComment})
+	if total != 1 || hits[0].ID != 3 {
+		t.Fatalf("want only comment 3, got %+v", hits)
+	}
+
+	if _, total = idx.Search(search.Query{Text: "数据库 redis"}); total != 0 {
+		t.Fatalf("all query terms should be required, got %d hits", total)
+	}
+
+	idx.RemovePost(1)
+	if _, total = idx.Search(search.Query{Text: "索引"}); total != 0 || idx.Len() != 1 {
+		t.Fatalf("post and its comments should be removed, got %d hits, %d docs", total, idx.Len())
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	cases := []struct {
+		text     string
+		query    string
+		maxRunes int
+		want     string
+	}{
+		{"学习数据库原理", "数据库", 0, "学习<em>数据库</em>原理"},
+		{"a <b> go c", "go", 0, "a &lt;b&gt; <em>go</em> c"},
+		{"一二三四五六七八九十数据库", "数据库", 8, "...九十<em>数据库</em>"},
+		{"no match here", "数据库", 5, "no ma..."},
+	}
+	for _, c := range cases {
+		got := search.Highlight(c.text, search.QueryTerms(c.query), c.maxRunes)
+		if got != c.want {
+			t.Fatalf("Highlight(%q, %q) = %q, want %q", c.text, c.query, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const maxWordLength = 64
+
+type Token struct {
+	Term string
+	// 词在原文中的字节偏移
+	Start int
+	End   int
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+func isWord(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Tokenize 切分待索引的文本，英文和数字按词切分，中日韩文字同时输出单字和二元组，
+// 这样单字查询和多字查询都能命中
+func Tokenize(text string) []Token {
+	tokens := make([]Token, 0, len(text)/2)
+	scan(text, func(t Token) {
+		tokens = append(tokens, t)
+	}, true)
+	return tokens
+}
+
+// QueryTerms 切分查询文本并去重，中日韩文字只使用二元组，单独一个字时使用单字
+func QueryTerms(text string) []string {
+	visited := map[string]bool{}
+	terms := []string{}
+	scan(text, func(t Token) {
+		if !visited[t.Term] {
+			visited[t.Term] = true
+			terms = append(terms, t.Term)
+		}
+	}, false)
+	return terms
+}
+
+func scan(text string, emit func(Token), withUnigram bool) {
+	i := 0
+	for i < len(text) {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case isCJK(r):
+			i = scanCJK(text, i, emit, withUnigram)
+		case isWord(r):
+			start := i
+			for i < len(text) {
+				r, size = utf8.DecodeRuneInString(text[i:])
+				if !isWord(r) || isCJK(r) {
+					break
+				}
+				i += size
+			}
+			if i-start <= maxWordLength {
+				emit(Token{Term: strings.ToLower(text[start:i]), Start: start, End: i})
+			}
+		default:
+			i += size
+		}
+	}
+}
+
+func scanCJK(text string, i int, emit func(Token), withUnigram bool) int {
+	// offsets of each rune in the run, plus the end of the run
+	offsets := []int{}
+	for i < len(text) {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if !isCJK(r) {
+			break
+		}
+		offsets = append(offsets, i)
+		i += size
+	}
+	offsets = append(offsets, i)
+
+	n := len(offsets) - 1
+	if n == 1 {
+		emit(Token{Term: text[offsets[0]:offsets[1]], Start: offsets[0], End: offsets[1]})
+		return i
+	}
+	for j := 0; j < n; j++ {
+		if withUnigram {
+			emit(Token{Term: text[offsets[j]:offsets[j+1]], Start: offsets[j], End: offsets[j+1]})
+		}
+		if j+1 < n {
+			emit(Token{Term: text[offsets[j]:offsets[j+2]], Start: offsets[j], End: offsets[j+2]})
+		}
+	}
+	return i
+}
@@ -0,0 +1,205 @@
+package block
+
+import (
+	"context"
+	blockentity "harmoni/app/harmoni/internal/entity/block"
+	"harmoni/app/harmoni/internal/entity/paginator"
+	"harmoni/app/harmoni/internal/pkg/reason"
+	"harmoni/internal/pkg/errorx"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var _ blockentity.BlockRepository = (*BlockRepo)(nil)
+
+type BlockRepo struct {
+	db     *gorm.DB
+	logger *zap.SugaredLogger
+}
+
+func NewBlockRepo(db *gorm.DB, logger *zap.SugaredLogger) *BlockRepo {
+	return &BlockRepo{
+		db:     db,
+		logger: logger.With("module", "repository/block"),
+	}
+}
+
+func (r *BlockRepo) Create(ctx context.Context, block *blockentity.Block) error {
+	exist, err := r.Exists(ctx, block.UserID, block.TargetID, block.BlockType)
+	if err != nil {
+		return err
+	} else if exist {
+		return errorx.BadRequest(reason.BlockAlreadyExist)
+	}
+
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(block).Error
+		if err != nil {
+			return err
+		}
+		return createMuteEvent(tx, block, false)
+	})
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func (r *BlockRepo) Delete(ctx context.Context, block *blockentity.Block) error {
+	var deleted int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("user_id = ? AND target_id = ? AND block_type = ?", block.UserID, block.TargetID, block.BlockType).
+			Delete(&blockentity.Block{})
+		if result.Error != nil {
+			return result.Error
+		}
+		deleted = result.RowsAffected
+		if deleted == 0 {
+			return nil
+		}
+		return createMuteEvent(tx, block, true)
+	})
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	} else if deleted == 0 {
+		return errorx.NotFound(reason.BlockNotFound)
+	}
+	return nil
+}
+
+// createMuteEvent 静音变化写入发件箱，拉黑不需要通知
+func createMuteEvent(tx *gorm.DB, block *blockentity.Block, isCancel bool) error {
+	if block.BlockType != blockentity.MuteUser {
+		return nil
+	}
+	return tx.Create(&blockentity.MuteEvent{
+		UserID:   block.UserID,
+		TargetID: block.TargetID,
+		IsCancel: isCancel,
+	}).Error
+}
+
+func (r *BlockRepo) ListMuteEvents(ctx context.Context, limit int) ([]*blockentity.MuteEvent, error) {
+	events := []*blockentity.MuteEvent{}
+	err := r.db.WithContext(ctx).Order("id").Limit(limit).Find(&events).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return events, nil
+}
+
+func (r *BlockRepo) DeleteMuteEvents(ctx context.Context, ids []uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	err := r.db.WithContext(ctx).Delete(&blockentity.MuteEvent{}, ids).Error
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func (r *BlockRepo) Exists(ctx context.Context, userID, targetID int64, blockType blockentity.BlockType) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&blockentity.Block{}).
+		Where("user_id = ? AND target_id = ? AND block_type = ?", userID, targetID, blockType).
+		Count(&count).Error
+	if err != nil {
+		return false, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return count > 0, nil
+}
+
+func (r *BlockRepo) ListBlockers(ctx context.Context, userIDs []int64, targetID int64, blockType blockentity.BlockType) ([]int64, error) {
+	blockers := []int64{}
+	if len(userIDs) == 0 {
+		return blockers, nil
+	}
+
+	err := r.db.WithContext(ctx).Model(&blockentity.Block{}).
+		Where("target_id = ? AND block_type = ? AND user_id IN ?", targetID, blockType, userIDs).
+		Pluck("user_id", &blockers).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return blockers, nil
+}
+
+func (r *BlockRepo) ListTargetIDs(ctx context.Context, userID int64, blockType blockentity.BlockType) ([]int64, error) {
+	targetIDs := []int64{}
+	err := r.db.WithContext(ctx).Model(&blockentity.Block{}).
+		Where("user_id = ? AND block_type = ?", userID, blockType).
+		Pluck("target_id", &targetIDs).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return targetIDs, nil
+}
+
+func (r *BlockRepo) BatchListTargetIDs(ctx context.Context, userIDs []int64, blockType blockentity.BlockType) (map[int64][]int64, error) {
+	targetIDs := make(map[int64][]int64, len(userIDs))
+	if len(userIDs) == 0 {
+		return targetIDs, nil
+	}
+
+	var blocks []blockentity.Block
+	err := r.db.WithContext(ctx).
+		Select("user_id", "target_id").
+		Where("user_id IN ? AND block_type = ?", userIDs, blockType).
+		Find(&blocks).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	for _, b := range blocks {
+		targetIDs[b.UserID] = append(targetIDs[b.UserID], b.TargetID)
+	}
+	return targetIDs, nil
+}
+
+func (r *BlockRepo) BatchListBlockersOf(ctx context.Context, targetIDs []int64, blockType blockentity.BlockType) (map[int64][]int64, error) {
+	blockers := make(map[int64][]int64, len(targetIDs))
+	if len(targetIDs) == 0 {
+		return blockers, nil
+	}
+
+	var blocks []blockentity.Block
+	err := r.db.WithContext(ctx).
+		Select("user_id", "target_id").
+		Where("target_id IN ? AND block_type = ?", targetIDs, blockType).
+		Find(&blocks).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	for _, b := range blocks {
+		blockers[b.TargetID] = append(blockers[b.TargetID], b.UserID)
+	}
+	return blockers, nil
+}
+
+func (r *BlockRepo) GetPage(ctx context.Context, query *blockentity.BlockQuery) (paginator.Page[int64], error) {
+	idPage := paginator.Page[int64]{
+		CurrentPage: query.Page,
+		PageSize:    query.PageSize,
+		Data:        make([]int64, 0, 8),
+	}
+	err := r.db.WithContext(ctx).Model(&blockentity.Block{}).
+		Where("user_id = ? AND block_type = ?", query.UserID, query.BlockType).
+		Count(&idPage.Total).Error
+	if err != nil {
+		return paginator.Page[int64]{}, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	err = r.db.WithContext(ctx).Model(&blockentity.Block{}).
+		Select("target_id").
+		Where("user_id = ? AND block_type = ?", query.UserID, query.BlockType).
+		Order("id DESC").
+		Scopes(paginator.Paginate(&idPage)).
+		Find(&idPage.Data).Error
+	if err != nil {
+		return paginator.Page[int64]{}, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return idPage, nil
+}
@@ -0,0 +1,307 @@
+package collection
+
+import (
+	"context"
+	"harmoni/app/harmoni/internal/entity"
+	collectionentity "harmoni/app/harmoni/internal/entity/collection"
+	"harmoni/app/harmoni/internal/entity/paginator"
+	"harmoni/app/harmoni/internal/entity/unique"
+	"harmoni/app/harmoni/internal/infrastructure/config"
+	"harmoni/app/harmoni/internal/pkg/reason"
+	"harmoni/internal/pkg/errorx"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var _ collectionentity.CollectionRepository = (*CollectionRepo)(nil)
+
+type CollectionRepo struct {
+	conf         *config.Collection
+	db           *gorm.DB
+	rdb          *redis.Client
+	uniqueIDRepo unique.UniqueIDRepo
+	logger       *zap.SugaredLogger
+}
+
+func NewCollectionRepo(
+	conf *config.Collection,
+	db *gorm.DB,
+	rdb *redis.Client,
+	uniqueIDRepo unique.UniqueIDRepo,
+	logger *zap.SugaredLogger,
+) *CollectionRepo {
+	return &CollectionRepo{
+		conf:         conf,
+		db:           db,
+		rdb:          rdb,
+		uniqueIDRepo: uniqueIDRepo,
+		logger:       logger.With("module", "repository/collection"),
+	}
+}
+
+func (r *CollectionRepo) Create(ctx context.Context, collection *collectionentity.Collection) (err error) {
+	collection.CollectionID, err = r.uniqueIDRepo.GenUniqueID(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.WithContext(ctx).Create(collection).Error
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return nil
+}
+
+func (r *CollectionRepo) Update(ctx context.Context, collection *collectionentity.Collection) error {
+	err := r.db.WithContext(ctx).
+		Model(&collectionentity.Collection{}).
+		Where("collection_id = ?", collection.CollectionID).
+		Updates(map[string]interface{}{
+			"name":        collection.Name,
+			"description": collection.Description,
+			"public":      collection.Public,
+		}).Error
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return nil
+}
+
+func (r *CollectionRepo) Delete(ctx context.Context, collection *collectionentity.Collection) error {
+	var postIDs []int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&collectionentity.CollectionItem{}).
+			Where("collection_id = ?", collection.CollectionID).
+			Pluck("post_id", &postIDs).Error
+		if err != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+
+		err = tx.Where("collection_id = ?", collection.CollectionID).Delete(&collectionentity.CollectionItem{}).Error
+		if err != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+
+		err = tx.Where("collection_id = ?", collection.CollectionID).Delete(&collectionentity.Collection{}).Error
+		if err != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.uncollectIfNoneLeft(ctx, collection.UserID, postIDs)
+}
+
+func (r *CollectionRepo) GetByCollectionID(ctx context.Context, collectionID int64) (*collectionentity.Collection, bool, error) {
+	collection := &collectionentity.Collection{}
+	err := r.db.WithContext(ctx).Where("collection_id = ?", collectionID).First(collection).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, false, nil
+		}
+		return nil, false, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return collection, true, nil
+}
+
+func (r *CollectionRepo) GetPageByUserID(ctx context.Context, userID int64, publicOnly bool, pageCond *entity.PageCond) (paginator.Page[collectionentity.Collection], error) {
+	collectionPage := paginator.Page[collectionentity.Collection]{CurrentPage: pageCond.Page, PageSize: pageCond.PageSize}
+	db := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if publicOnly {
+		db = db.Where("public = ?", true)
+	}
+	db = db.Order("updated_at DESC")
+	err := collectionPage.SelectPages(db)
+	if err != nil {
+		return paginator.Page[collectionentity.Collection]{}, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return collectionPage, nil
+}
+
+func (r *CollectionRepo) AddPost(ctx context.Context, item *collectionentity.CollectionItem) error {
+	collected := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Model(&collectionentity.CollectionItem{}).
+			Where("post_id = ? AND user_id = ?", item.PostID, item.UserID).
+			Count(&count).Error
+		if err != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		collected = count > 0
+
+		err = tx.Model(&collectionentity.CollectionItem{}).
+			Where("collection_id = ? AND post_id = ?", item.CollectionID, item.PostID).
+			Count(&count).Error
+		if err != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		} else if count > 0 {
+			return errorx.BadRequest(reason.CollectionPostAlreadyExist)
+		}
+
+		err = tx.Create(item).Error
+		if err != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+
+		return r.updatePostCount(tx, item.CollectionID, 1)
+	})
+	if err != nil {
+		return err
+	}
+
+	// the count is the number of users, collecting into another collection does not change it
+	if collected {
+		return nil
+	}
+	return r.incrCollectCount(ctx, item.PostID, 1)
+}
+
+func (r *CollectionRepo) RemovePost(ctx context.Context, item *collectionentity.CollectionItem) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("collection_id = ? AND post_id = ?", item.CollectionID, item.PostID).
+			Delete(&collectionentity.CollectionItem{})
+		if result.Error != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(result.Error).WithStack()
+		} else if result.RowsAffected == 0 {
+			return errorx.NotFound(reason.CollectionPostNotFound)
+		}
+
+		return r.updatePostCount(tx, item.CollectionID, -1)
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.uncollectIfNoneLeft(ctx, item.UserID, []int64{item.PostID})
+}
+
+func (r *CollectionRepo) updatePostCount(tx *gorm.DB, collectionID int64, delta int64) error {
+	err := tx.Model(&collectionentity.Collection{}).
+		Where("collection_id = ?", collectionID).
+		Updates(map[string]interface{}{
+			"post_count": gorm.Expr("post_count + ?", delta),
+		}).Error
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return nil
+}
+
+// uncollectIfNoneLeft 用户的其他收藏夹中也没有这些帖子时减少帖子的收藏数
+func (r *CollectionRepo) uncollectIfNoneLeft(ctx context.Context, userID int64, postIDs []int64) error {
+	if len(postIDs) == 0 {
+		return nil
+	}
+
+	collected, err := r.BatchIsCollected(ctx, userID, postIDs)
+	if err != nil {
+		return err
+	}
+	for _, postID := range postIDs {
+		if collected[postID] {
+			continue
+		}
+		err = r.incrCollectCount(ctx, postID, -1)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *CollectionRepo) GetPostIDPage(ctx context.Context, collectionID int64, pageCond *entity.PageCond) (paginator.Page[int64], error) {
+	itemPage := paginator.Page[collectionentity.CollectionItem]{CurrentPage: pageCond.Page, PageSize: pageCond.PageSize}
+	db := r.db.WithContext(ctx).
+		Where("collection_id = ?", collectionID).
+		Order("created_at DESC")
+	err := itemPage.SelectPages(db)
+	if err != nil {
+		return paginator.Page[int64]{}, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	postIDs := make([]int64, len(itemPage.Data))
+	for i, item := range itemPage.Data {
+		postIDs[i] = item.PostID
+	}
+	return paginator.Page[int64]{
+		CurrentPage: itemPage.CurrentPage,
+		PageSize:    itemPage.PageSize,
+		Total:       itemPage.Total,
+		Pages:       itemPage.Pages,
+		Data:        postIDs,
+	}, nil
+}
+
+func (r *CollectionRepo) BatchIsCollected(ctx context.Context, userID int64, postIDs []int64) (map[int64]bool, error) {
+	collected := make(map[int64]bool, len(postIDs))
+	if len(postIDs) == 0 {
+		return collected, nil
+	}
+
+	var collectedIDs []int64
+	err := r.db.WithContext(ctx).
+		Model(&collectionentity.CollectionItem{}).
+		Distinct("post_id").
+		Where("user_id = ? AND post_id IN ?", userID, postIDs).
+		Pluck("post_id", &collectedIDs).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	for _, postID := range collectedIDs {
+		collected[postID] = true
+	}
+	return collected, nil
+}
+
+func (r *CollectionRepo) DeleteByPostIDs(ctx context.Context, postIDs []int64) error {
+	if len(postIDs) == 0 {
+		return nil
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		counts := []struct {
+			CollectionID int64
+			Count        int64
+		}{}
+		err := tx.Model(&collectionentity.CollectionItem{}).
+			Select("collection_id, COUNT(*) AS count").
+			Where("post_id IN ?", postIDs).
+			Group("collection_id").
+			Scan(&counts).Error
+		if err != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+
+		err = tx.Where("post_id IN ?", postIDs).Delete(&collectionentity.CollectionItem{}).Error
+		if err != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+
+		for _, count := range counts {
+			err = r.updatePostCount(tx, count.CollectionID, -count.Count)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.deleteCollectCounts(ctx, postIDs)
+}
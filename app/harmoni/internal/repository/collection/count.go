@@ -0,0 +1,76 @@
+package collection
+
+import (
+	"context"
+	collectionentity "harmoni/app/harmoni/internal/entity/collection"
+	"harmoni/app/harmoni/internal/pkg/counter"
+	"harmoni/app/harmoni/internal/pkg/reason"
+	"harmoni/internal/pkg/errorx"
+)
+
+const postCollectCountPrefix = "post:collect.count:"
+
+func (r *CollectionRepo) incrCollectCount(ctx context.Context, postID int64, delta int64) error {
+	err := counter.Incr(ctx, r.rdb, postCollectCountPrefix, postID, delta)
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return nil
+}
+
+func (r *CollectionRepo) deleteCollectCounts(ctx context.Context, postIDs []int64) error {
+	err := counter.Delete(ctx, r.rdb, postCollectCountPrefix, postIDs)
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return nil
+}
+
+func (r *CollectionRepo) BatchCollectCountByIDs(ctx context.Context, postIDs []int64) (map[int64]int64, error) {
+	countMap, notCached, err := counter.Get(ctx, r.rdb, postCollectCountPrefix, postIDs)
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if len(notCached) == 0 {
+		return countMap, nil
+	}
+
+	collectCounts := []struct {
+		PostID int64
+		Count  int64
+	}{}
+	err = r.db.WithContext(ctx).
+		Model(&collectionentity.CollectionItem{}).
+		Select("post_id, COUNT(DISTINCT user_id) AS count").
+		Where("post_id IN ?", notCached).
+		Group("post_id").
+		Scan(&collectCounts).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	for _, count := range collectCounts {
+		countMap[count.PostID] = count.Count
+	}
+
+	counts := make(map[int64]int64, len(notCached))
+	for _, postID := range notCached {
+		counts[postID] = countMap[postID]
+	}
+	err = counter.Set(ctx, r.rdb, postCollectCountPrefix, counts, r.conf.CacheDuration)
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return countMap, nil
+}
+
+func (r *CollectionRepo) BatchCollectCount(ctx context.Context) (map[int64]int64, error) {
+	m, err := counter.All(ctx, r.rdb, postCollectCountPrefix)
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return m, nil
+}
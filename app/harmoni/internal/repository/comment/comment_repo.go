@@ -5,26 +5,32 @@ import (
 	commententity "harmoni/app/harmoni/internal/entity/comment"
 	"harmoni/app/harmoni/internal/entity/paginator"
 	"harmoni/app/harmoni/internal/entity/unique"
+	"harmoni/app/harmoni/internal/infrastructure/config"
 	commentpo "harmoni/app/harmoni/internal/infrastructure/po/comment"
 	"harmoni/app/harmoni/internal/pkg/reason"
 	"harmoni/internal/pkg/errorx"
+	"harmoni/internal/types/object"
+	"harmoni/internal/types/persistence"
 
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var _ commententity.CommentRepository = (*CommentRepo)(nil)
 
 type CommentRepo struct {
+	conf         *config.Comment
 	db           *gorm.DB
 	rdb          *redis.Client
 	uniqueIDRepo unique.UniqueIDRepo
 	logger       *zap.SugaredLogger
 }
 
-func NewCommentRepo(db *gorm.DB, rdb *redis.Client, uniqueIDRepo unique.UniqueIDRepo, logger *zap.SugaredLogger) *CommentRepo {
+func NewCommentRepo(conf *config.Comment, db *gorm.DB, rdb *redis.Client, uniqueIDRepo unique.UniqueIDRepo, logger *zap.SugaredLogger) *CommentRepo {
 	return &CommentRepo{
+		conf:         conf,
 		db:           db,
 		rdb:          rdb,
 		uniqueIDRepo: uniqueIDRepo,
@@ -39,15 +45,129 @@ func (r *CommentRepo) Create(ctx context.Context, comment *commententity.Comment
 	if err != nil {
 		return err
 	}
+	if cp.DialogID == commententity.NewDialog {
+		cp.DialogID = cp.CommentID
+	}
 
 	err = r.db.WithContext(ctx).Create(cp).Error
 	if err != nil {
 		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
 
+	comment.CommentID = cp.CommentID
+	comment.DialogID = cp.DialogID
 	comment.UpdatedAt = cp.UpdatedAt
 	comment.CreatedAt = cp.CreatedAt
-	return nil
+	if comment.ObjectType != object.ObjectTypePost {
+		return nil
+	}
+	return r.incrCommentCount(ctx, comment.ObjectID, 1)
+}
+
+func (r *CommentRepo) ListByObjectID(ctx context.Context, objectID int64) ([]*commententity.Comment, error) {
+	comments := []*commentpo.Comment{}
+	err := r.db.WithContext(ctx).
+		Scopes(persistence.ByObject(objectID, object.ObjectTypePost)).
+		Order("comment_id").
+		Find(&comments).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return commentpo.CommnetList(comments).ToDomain(), nil
+}
+
+func (r *CommentRepo) ListAfter(ctx context.Context, afterCommentID int64, limit int) ([]*commententity.Comment, error) {
+	comments := []*commentpo.Comment{}
+	err := r.db.WithContext(ctx).
+		Where("comment_id > ?", afterCommentID).
+		Order("comment_id").
+		Limit(limit).
+		Find(&comments).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return commentpo.CommnetList(comments).ToDomain(), nil
+}
+
+func (r *CommentRepo) CountByObjectIDs(ctx context.Context, objectIDs []int64) (map[int64]int64, error) {
+	counts := make(map[int64]int64, len(objectIDs))
+	if len(objectIDs) == 0 {
+		return counts, nil
+	}
+
+	rows := []struct {
+		ObjectID int64
+		Count    int64
+	}{}
+	err := r.db.WithContext(ctx).
+		Model(&commentpo.Comment{}).
+		Select("object_id, COUNT(*) AS count").
+		Where("object_id IN ? AND object_type = ? AND deleted = ?", objectIDs, object.ObjectTypePost, false).
+		Group("object_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	for _, row := range rows {
+		counts[row.ObjectID] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *CommentRepo) CountReplies(ctx context.Context, rootIDs []int64) (map[int64]int64, error) {
+	counts := make(map[int64]int64, len(rootIDs))
+	if len(rootIDs) == 0 {
+		return counts, nil
+	}
+
+	rows := []struct {
+		RootID int64
+		Count  int64
+	}{}
+	err := r.db.WithContext(ctx).
+		Model(&commentpo.Comment{}).
+		Select("root_id, COUNT(*) AS count").
+		Where("root_id IN ? AND deleted = ?", rootIDs, false).
+		Group("root_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	for _, row := range rows {
+		counts[row.RootID] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *CommentRepo) GetByCommentIDs(ctx context.Context, commentIDs []int64) ([]*commententity.Comment, error) {
+	if len(commentIDs) == 0 {
+		return []*commententity.Comment{}, nil
+	}
+
+	comments := []*commentpo.Comment{}
+	err := r.db.WithContext(ctx).Where("comment_id IN ?", commentIDs).Find(&comments).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return commentpo.CommnetList(comments).ToDomain(), nil
+}
+
+func (r *CommentRepo) ListByObjectAndRootID(ctx context.Context, objectID int64, objectType object.ObjectType, rootID int64) ([]*commententity.Comment, error) {
+	comments := []*commentpo.Comment{}
+	err := r.db.WithContext(ctx).
+		Scopes(persistence.ByObject(objectID, objectType)).
+		Where("root_id = ?", rootID).
+		Find(&comments).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return commentpo.CommnetList(comments).ToDomain(), nil
 }
 
 func (r *CommentRepo) GetByCommentID(ctx context.Context, commentID int64) (*commententity.Comment, bool, error) {
@@ -63,6 +183,97 @@ func (r *CommentRepo) GetByCommentID(ctx context.Context, commentID int64) (*com
 	return comment.ToDomain(), true, nil
 }
 
+func (r *CommentRepo) Update(ctx context.Context, comment *commententity.Comment) error {
+	cp := (&commentpo.Comment{}).FromDomain(comment)
+	err := r.db.WithContext(ctx).
+		Model(&commentpo.Comment{}).
+		Where("comment_id = ? AND deleted = ?", comment.CommentID, false).
+		Updates(map[string]interface{}{
+			"content":          cp.Content,
+			"mention_user_ids": cp.MentionUserIDs,
+		}).Error
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return nil
+}
+
+func (r *CommentRepo) Tombstone(ctx context.Context, comment *commententity.Comment) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&commentpo.Comment{}).
+		Where("comment_id = ? AND deleted = ?", comment.CommentID, false).
+		Updates(map[string]interface{}{
+			"deleted":          true,
+			"content":          "",
+			"mention_user_ids": "[]",
+		})
+	if result.Error != nil {
+		return false, errorx.InternalServer(reason.DatabaseError).WithError(result.Error).WithStack()
+	} else if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	comment.Deleted = true
+	if comment.ObjectType != object.ObjectTypePost {
+		return true, nil
+	}
+	return true, r.incrCommentCount(ctx, comment.ObjectID, -1)
+}
+
+func (r *CommentRepo) HardDelete(ctx context.Context, comment *commententity.Comment) ([]*commententity.Comment, error) {
+	comments := []*commentpo.Comment{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		db := tx.Unscoped().Where("comment_id = ?", comment.CommentID)
+		if comment.RootID == 0 {
+			db = db.Or("root_id = ?", comment.CommentID)
+		}
+		err := db.Find(&comments).Error
+		if err != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		if len(comments) == 0 {
+			return nil
+		}
+
+		commentIDs := make([]int64, len(comments))
+		for i := range comments {
+			commentIDs[i] = comments[i].CommentID
+		}
+		err = tx.Unscoped().Where("comment_id IN ?", commentIDs).Delete(&commentpo.Comment{}).Error
+		if err != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := commentpo.CommnetList(comments).ToDomain()
+	err = r.removeRanks(ctx, deleted)
+	if err != nil {
+		return nil, err
+	}
+
+	// tombstones have been subtracted from the count when they were deleted
+	var visible int64
+	for _, c := range comments {
+		if !c.Deleted {
+			visible++
+		}
+	}
+	if visible != 0 && comment.ObjectType == object.ObjectTypePost {
+		err = r.incrCommentCount(ctx, comment.ObjectID, -visible)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return deleted, nil
+}
+
 const (
 	n = 3
 )
@@ -85,6 +296,37 @@ func (r *CommentRepo) ListNSubComments(ctx context.Context, rootIDs []int64) ([]
 	return commentpo.CommnetList(comments).ToDomain(), nil
 }
 
+func (r *CommentRepo) ListByRootID(ctx context.Context, rootID int64, afterCommentID int64, limit int) ([]*commententity.Comment, error) {
+	comments := []*commentpo.Comment{}
+	err := r.db.WithContext(ctx).
+		Where("root_id = ? AND comment_id > ?", rootID, afterCommentID).
+		Order("comment_id").
+		Limit(limit).
+		Find(&comments).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return commentpo.CommnetList(comments).ToDomain(), nil
+}
+
+func (r *CommentRepo) ListByDialogID(ctx context.Context, dialogID int64, afterCommentID int64, limit int) ([]*commententity.Comment, error) {
+	comments := []*commentpo.Comment{}
+	err := r.db.WithContext(ctx).
+		// dialogs created before being keyed by their first reply are keyed by the replied comment instead
+		Where("(dialog_id = ? OR comment_id = ? OR comment_id = (?)) AND comment_id > ?", dialogID, dialogID,
+			r.db.Model(&commentpo.Comment{}).Select("parent_id").Where("comment_id = ? AND dialog_id = ?", dialogID, dialogID),
+			afterCommentID).
+		Order("comment_id").
+		Limit(limit).
+		Find(&comments).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return commentpo.CommnetList(comments).ToDomain(), nil
+}
+
 func (r *CommentRepo) GetLikeCount(ctx context.Context, commentID int64) (int64, bool, error) {
 	var count int64
 	if err := r.db.WithContext(ctx).
@@ -111,23 +353,56 @@ func (r *CommentRepo) UpdateLikeCount(ctx context.Context, commentID int64, coun
 	return nil
 }
 
-func (r *CommentRepo) List(ctx context.Context, commentQuery *commententity.CommentQuery) (paginator.Page[*commententity.Comment], error) {
-	commentPage := paginator.Page[*commentpo.Comment]{CurrentPage: commentQuery.Page, PageSize: commentQuery.PageSize}
-	db := r.db.WithContext(ctx).Where("object_id = ? AND root_id = ?", commentQuery.ObjectID, commentQuery.RootID)
+func (r *CommentRepo) UpdateDislikeCount(ctx context.Context, commentID int64, count int64) error {
+	if err := r.db.WithContext(ctx).
+		Table("comment").
+		Where("comment_id = ?", commentID).
+		Update("dislike_count", count).Error; err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
 
-	if commentQuery.UserID != 0 {
-		db = db.Where("user_id = ?", commentQuery.UserID)
+	return nil
+}
+
+func (r *CommentRepo) DeleteByObjectIDs(ctx context.Context, objectIDs []int64) ([]int64, error) {
+	if len(objectIDs) == 0 {
+		return nil, nil
 	}
 
-	switch commentQuery.QueryCond {
-	case "newest":
-		db.Order("created_at DESC")
-	case "score":
-		db.Order("like_count")
-	default:
-		db.Order("created_at DESC")
+	var commentIDs []int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Model(&commentpo.Comment{}).
+			Where("object_id IN ? AND object_type = ?", objectIDs, object.ObjectTypePost).
+			Pluck("comment_id", &commentIDs).Error
+		if err != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+
+		err = tx.Unscoped().
+			Where("object_id IN ? AND object_type = ?", objectIDs, object.ObjectTypePost).
+			Delete(&commentpo.Comment{}).Error
+		if err != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	return commentIDs, r.deleteCommentCounts(ctx, objectIDs)
+}
+
+func (r *CommentRepo) List(ctx context.Context, commentQuery *commententity.CommentQuery) (paginator.Page[*commententity.Comment], error) {
+	commentPage := paginator.Page[*commentpo.Comment]{CurrentPage: commentQuery.Page, PageSize: commentQuery.PageSize}
+	db := r.db.WithContext(ctx).
+		Scopes(persistence.ByObject(commentQuery.ObjectID, commentQuery.ObjectType)).
+		Where("root_id = ?", commentQuery.RootID)
+
+	// hot and best orders are read from the rankings
+	db.Order("created_at DESC")
+
 	err := commentPage.SelectPages(db)
 	if err != nil {
 		return paginator.Page[*commententity.Comment]{}, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
@@ -142,3 +417,30 @@ func (r *CommentRepo) List(ctx context.Context, commentQuery *commententity.Comm
 	}
 	return commentDomainPage, nil
 }
+
+func (r *CommentRepo) GetArea(ctx context.Context, objectID int64, objectType object.ObjectType) (*commententity.Area, error) {
+	area := commentpo.CommentArea{}
+	err := r.db.WithContext(ctx).Scopes(persistence.ByObject(objectID, objectType)).First(&area).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &commententity.Area{ObjectID: objectID, ObjectType: objectType, Status: commententity.AreaOpen}, nil
+		}
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return area.ToDomain(), nil
+}
+
+func (r *CommentRepo) SaveArea(ctx context.Context, area *commententity.Area) error {
+	err := r.db.WithContext(ctx).Clauses(
+		clause.OnConflict{
+			Columns:   []clause.Column{{Name: "object_id"}, {Name: "object_type"}},
+			DoUpdates: clause.AssignmentColumns([]string{"status", "updated_at"}),
+		},
+	).Create((&commentpo.CommentArea{}).FromDomain(area)).Error
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return nil
+}
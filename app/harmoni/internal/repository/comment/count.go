@@ -0,0 +1,74 @@
+package comment
+
+import (
+	"context"
+	"harmoni/app/harmoni/internal/pkg/counter"
+	"harmoni/app/harmoni/internal/pkg/reason"
+	"harmoni/internal/pkg/errorx"
+)
+
+const postCommentCountPrefix = "post:comment.count:"
+
+func (r *CommentRepo) incrCommentCount(ctx context.Context, objectID int64, delta int64) error {
+	err := counter.Incr(ctx, r.rdb, postCommentCountPrefix, objectID, delta)
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return nil
+}
+
+func (r *CommentRepo) cacheCommentCounts(ctx context.Context, counts map[int64]int64) error {
+	err := counter.Set(ctx, r.rdb, postCommentCountPrefix, counts, r.conf.CacheDuration)
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return nil
+}
+
+func (r *CommentRepo) deleteCommentCounts(ctx context.Context, objectIDs []int64) error {
+	err := counter.Delete(ctx, r.rdb, postCommentCountPrefix, objectIDs)
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return nil
+}
+
+func (r *CommentRepo) BatchCommentCountByObjectIDs(ctx context.Context, objectIDs []int64) (map[int64]int64, error) {
+	countMap, notCached, err := counter.Get(ctx, r.rdb, postCommentCountPrefix, objectIDs)
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if len(notCached) == 0 {
+		return countMap, nil
+	}
+
+	counts, err := r.CountByObjectIDs(ctx, notCached)
+	if err != nil {
+		return nil, err
+	}
+	// objects without comments are cached as zero
+	for _, objectID := range notCached {
+		if _, ok := counts[objectID]; !ok {
+			counts[objectID] = 0
+		}
+		countMap[objectID] = counts[objectID]
+	}
+
+	return countMap, r.cacheCommentCounts(ctx, counts)
+}
+
+func (r *CommentRepo) BatchCommentCount(ctx context.Context) (map[int64]int64, error) {
+	m, err := counter.All(ctx, r.rdb, postCommentCountPrefix)
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return m, nil
+}
+
+func (r *CommentRepo) ResetCommentCounts(ctx context.Context, counts map[int64]int64) error {
+	return r.cacheCommentCounts(ctx, counts)
+}
@@ -0,0 +1,130 @@
+package comment
+
+import (
+	"context"
+	"fmt"
+	"harmoni/app/harmoni/internal/entity"
+	commententity "harmoni/app/harmoni/internal/entity/comment"
+	"harmoni/app/harmoni/internal/pkg/reason"
+	"harmoni/internal/pkg/errorx"
+	"harmoni/internal/types/object"
+	"math"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// getRankKey 对象下同一层级评论的排序，rootID 为 0 时是根评论
+func getRankKey(order string, objectID int64, objectType object.ObjectType, rootID int64) string {
+	return fmt.Sprintf("comment:%d.%d:%d:rank.%s", objectType, objectID, rootID, order)
+}
+
+func getRankKeys(objectID int64, objectType object.ObjectType, rootID int64) (string, string) {
+	return getRankKey(commententity.CommentOrderByHot, objectID, objectType, rootID),
+		getRankKey(commententity.CommentOrderByBest, objectID, objectType, rootID)
+}
+
+// rankScript 只更新已经建立的排序，未建立的排序在读取时从数据库完整构建
+const rankScript = `
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	redis.call('ZADD', KEYS[1], ARGV[1], ARGV[2])
+end
+return 1
+`
+
+func (r *CommentRepo) RankExists(ctx context.Context, objectID int64, objectType object.ObjectType, rootID int64) (bool, error) {
+	hotKey, bestKey := getRankKeys(objectID, objectType, rootID)
+	n, err := r.rdb.Exists(ctx, hotKey, bestKey).Result()
+	if err != nil {
+		return false, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return n == 2, nil
+}
+
+// ResetRanks 排序中总是带一个分数最低的占位成员，没有评论的层级也视为已建立排序
+func (r *CommentRepo) ResetRanks(ctx context.Context, objectID int64, objectType object.ObjectType, rootID int64, ranks []commententity.Rank) error {
+	hotKey, bestKey := getRankKeys(objectID, objectType, rootID)
+	hot := make([]redis.Z, len(ranks), len(ranks)+1)
+	best := make([]redis.Z, len(ranks), len(ranks)+1)
+	for i, rank := range ranks {
+		member := strconv.FormatInt(rank.CommentID, 10)
+		hot[i] = redis.Z{Score: rank.Hot, Member: member}
+		best[i] = redis.Z{Score: rank.Best, Member: member}
+	}
+	placeholder := redis.Z{Score: math.Inf(-1), Member: entity.DefaultRedisValue}
+	hot = append(hot, placeholder)
+	best = append(best, placeholder)
+
+	pipe := r.rdb.TxPipeline()
+	pipe.Del(ctx, hotKey, bestKey)
+	pipe.ZAdd(ctx, hotKey, hot...)
+	pipe.ZAdd(ctx, bestKey, best...)
+	pipe.Expire(ctx, hotKey, r.conf.CacheDuration)
+	pipe.Expire(ctx, bestKey, r.conf.CacheDuration)
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return nil
+}
+
+func (r *CommentRepo) UpdateRank(ctx context.Context, comment *commententity.Comment, rank commententity.Rank) error {
+	hotKey, bestKey := getRankKeys(comment.ObjectID, comment.ObjectType, comment.RootID)
+	member := strconv.FormatInt(comment.CommentID, 10)
+	script := redis.NewScript(rankScript)
+	err := script.Run(ctx, r.rdb, []string{hotKey}, rank.Hot, member).Err()
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	err = script.Run(ctx, r.rdb, []string{bestKey}, rank.Best, member).Err()
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return nil
+}
+
+// removeRanks 彻底删除的评论从所在层级的排序中移除
+func (r *CommentRepo) removeRanks(ctx context.Context, comments []*commententity.Comment) error {
+	pipe := r.rdb.TxPipeline()
+	for _, comment := range comments {
+		hotKey, bestKey := getRankKeys(comment.ObjectID, comment.ObjectType, comment.RootID)
+		member := strconv.FormatInt(comment.CommentID, 10)
+		pipe.ZRem(ctx, hotKey, member)
+		pipe.ZRem(ctx, bestKey, member)
+	}
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return nil
+}
+
+func (r *CommentRepo) ListRankedIDs(ctx context.Context, order string, objectID int64, objectType object.ObjectType, rootID int64, start, stop int64) ([]int64, int64, error) {
+	key := getRankKey(order, objectID, objectType, rootID)
+	total, err := r.rdb.ZCard(ctx, key).Result()
+	if err != nil {
+		return nil, 0, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	// the placeholder is not counted
+	if total > 0 {
+		total--
+	}
+	members, err := r.rdb.ZRevRange(ctx, key, start, stop).Result()
+	if err != nil {
+		return nil, 0, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	commentIDs := make([]int64, 0, len(members))
+	for _, member := range members {
+		commentID, err := strconv.ParseInt(member, 10, 64)
+		if err != nil {
+			continue
+		}
+		commentIDs = append(commentIDs, commentID)
+	}
+	return commentIDs, total, nil
+}
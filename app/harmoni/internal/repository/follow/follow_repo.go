@@ -88,8 +88,8 @@ func (r *FollowRepo) FollowCancel(ctx context.Context, follow *followentity.Foll
 			return errorx.NotFound(reason.FollowNotFound)
 		}
 
-		err = r.db.WithContext(ctx).
-			Where("follower_id = ? AND following_id = ? AND followed_type = ?", follow.FollowerID, follow.FollowingID, follow.FollowedType).
+		// delete within the transaction so that the follow count is rolled back together
+		err = tx.Where("follower_id = ? AND following_id = ? AND followed_type = ?", follow.FollowerID, follow.FollowingID, follow.FollowedType).
 			Delete(follow).Error
 		if err != nil {
 			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
@@ -197,11 +197,57 @@ func (r *FollowRepo) GetFollowingsPage(ctx context.Context, followQuery *followe
 	return idPage, nil
 }
 
+func (r *FollowRepo) getFollowingAll(ctx context.Context, userID int64, followedType followentity.FollowedType) ([]int64, error) {
+	followings := []int64{}
+	err := r.db.WithContext(ctx).Table("follow").
+		Select("following_id").
+		Where("follower_id = ? AND followed_type = ? AND deleted_at is NULL", userID, followedType).
+		Find(&followings).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return followings, nil
+}
+
 func (r *FollowRepo) GetFollowingUsersAll(ctx context.Context, userID int64) ([]int64, error) {
+	return r.getFollowingAll(ctx, userID, followentity.FollowUser)
+}
+
+func (r *FollowRepo) GetFollowingTagsAll(ctx context.Context, userID int64) ([]int64, error) {
+	return r.getFollowingAll(ctx, userID, followentity.FollowTag)
+}
+
+func (r *FollowRepo) GetFollowingUsersFollowers(ctx context.Context, userID int64) (map[int64]int64, error) {
+	var followings []struct {
+		FollowingID int64
+		FollowCount int64
+	}
+	err := r.db.WithContext(ctx).Table("follow").
+		Select("follow.following_id", "user.follow_count").
+		Joins("JOIN `user` ON `user`.user_id = follow.following_id").
+		Where("follow.follower_id = ? AND follow.followed_type = ? AND follow.deleted_at is NULL", userID, followentity.FollowUser).
+		Find(&followings).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	followers := make(map[int64]int64, len(followings))
+	for _, f := range followings {
+		followers[f.FollowingID] = f.FollowCount
+	}
+	return followers, nil
+}
+
+func (r *FollowRepo) ListPopularFollowings(ctx context.Context, userID int64, minFollowers int64) ([]int64, error) {
 	followings := []int64{}
+	popular := r.db.Table("user").
+		Select("user_id").
+		Where("follow_count >= ? AND deleted_at IS NULL", minFollowers)
 	err := r.db.WithContext(ctx).Table("follow").
 		Select("following_id").
-		Where("follower_id = ? AND followed_type = ? AND deleted_at is NULL", userID, followentity.FollowUser).
+		Where("follower_id = ? AND followed_type = ? AND deleted_at IS NULL", userID, followentity.FollowUser).
+		Where("following_id IN (?)", popular).
 		Find(&followings).Error
 	if err != nil {
 		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
@@ -210,6 +256,77 @@ func (r *FollowRepo) GetFollowingUsersAll(ctx context.Context, userID int64) ([]
 	return followings, nil
 }
 
+func (r *FollowRepo) ListFollowersAfter(ctx context.Context, userID, after int64, limit int) ([]int64, error) {
+	followers := []int64{}
+	err := r.db.WithContext(ctx).Table("follow").
+		Select("follower_id").
+		Where("following_id = ? AND followed_type = ? AND follower_id > ? AND deleted_at is NULL", userID, followentity.FollowUser, after).
+		Order("follower_id").
+		Limit(limit).
+		Find(&followers).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return followers, nil
+}
+
+func (r *FollowRepo) BatchGetFollowingUsers(ctx context.Context, userIDs []int64) (map[int64][]int64, error) {
+	followings := make(map[int64][]int64, len(userIDs))
+	if len(userIDs) == 0 {
+		return followings, nil
+	}
+
+	var follows []followentity.Follow
+	err := r.db.WithContext(ctx).
+		Select("follower_id", "following_id").
+		Where("follower_id IN ? AND followed_type = ?", userIDs, followentity.FollowUser).
+		Find(&follows).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	for _, f := range follows {
+		followings[f.FollowerID] = append(followings[f.FollowerID], f.FollowingID)
+	}
+	return followings, nil
+}
+
+func (r *FollowRepo) BatchCountFollowingsOfFollowings(ctx context.Context, userIDs []int64, limit int) (map[int64]map[int64]int64, error) {
+	followings := make(map[int64]map[int64]int64, len(userIDs))
+	if len(userIDs) == 0 {
+		return followings, nil
+	}
+
+	// rank the followings of followings of each user and keep the top limit in SQL
+	ranked := r.db.Table("follow AS f1").
+		Select("f1.follower_id AS user_id", "f2.following_id", "COUNT(*) AS count",
+			"ROW_NUMBER() OVER (PARTITION BY f1.follower_id ORDER BY COUNT(*) DESC) AS rn").
+		Joins("JOIN follow AS f2 ON f2.follower_id = f1.following_id AND f2.followed_type = ? AND f2.deleted_at IS NULL", followentity.FollowUser).
+		Where("f1.follower_id IN ? AND f1.followed_type = ? AND f1.deleted_at IS NULL", userIDs, followentity.FollowUser).
+		Group("f1.follower_id, f2.following_id")
+	var counts []struct {
+		UserID      int64
+		FollowingID int64
+		Count       int64
+	}
+	err := r.db.WithContext(ctx).Table("(?) AS ranked", ranked).
+		Select("user_id", "following_id", "count").
+		Where("rn <= ?", limit).
+		Find(&counts).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	for _, c := range counts {
+		if followings[c.UserID] == nil {
+			followings[c.UserID] = make(map[int64]int64)
+		}
+		followings[c.UserID][c.FollowingID] = c.Count
+	}
+	return followings, nil
+}
+
 func (r *FollowRepo) IsFollowing(ctx context.Context, follow *followentity.Follow) (bool, error) {
 	var count int64
 	if err := r.db.WithContext(ctx).Model(follow).
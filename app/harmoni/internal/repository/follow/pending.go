@@ -0,0 +1,138 @@
+package follow
+
+import (
+	"context"
+	followentity "harmoni/app/harmoni/internal/entity/follow"
+	"harmoni/app/harmoni/internal/entity/paginator"
+	"harmoni/app/harmoni/internal/pkg/reason"
+	"harmoni/internal/pkg/errorx"
+
+	"gorm.io/gorm"
+)
+
+func (r *FollowRepo) CreatePending(ctx context.Context, pending *followentity.PendingFollow) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		exist, err := r.isFollowExist(ctx, tx, &followentity.Follow{
+			FollowerID:   pending.FollowerID,
+			FollowingID:  pending.FollowingID,
+			FollowedType: followentity.FollowUser,
+		})
+		if err != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		} else if exist {
+			return errorx.BadRequest(reason.FollowAlreadyExist)
+		}
+
+		var count int64
+		err = tx.Model(pending).
+			Where("follower_id = ? AND following_id = ?", pending.FollowerID, pending.FollowingID).
+			Count(&count).Error
+		if err != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		} else if count > 0 {
+			return errorx.BadRequest(reason.FollowRequestAlreadyExist)
+		}
+
+		err = tx.Create(pending).Error
+		if err != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *FollowRepo) DeletePending(ctx context.Context, followerID, followingID int64) (bool, error) {
+	db := r.db.WithContext(ctx).
+		Where("follower_id = ? AND following_id = ?", followerID, followingID).
+		Delete(&followentity.PendingFollow{})
+	if db.Error != nil {
+		return false, errorx.InternalServer(reason.DatabaseError).WithError(db.Error).WithStack()
+	}
+
+	return db.RowsAffected > 0, nil
+}
+
+func (r *FollowRepo) ApprovePending(ctx context.Context, followerID, followingID int64) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		db := tx.Where("follower_id = ? AND following_id = ?", followerID, followingID).
+			Delete(&followentity.PendingFollow{})
+		if db.Error != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(db.Error).WithStack()
+		} else if db.RowsAffected == 0 {
+			return errorx.NotFound(reason.FollowRequestNotFound)
+		}
+
+		follow := &followentity.Follow{
+			FollowerID:   followerID,
+			FollowingID:  followingID,
+			FollowedType: followentity.FollowUser,
+		}
+		exist, err := r.isFollowExist(ctx, tx, follow)
+		if err != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		} else if exist {
+			return nil
+		}
+
+		err = tx.Create(follow).Error
+		if err != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		err = r.updateFollows(ctx, tx, follow, 1)
+		if err != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *FollowRepo) GetPendingPage(ctx context.Context, followQuery *followentity.FollowQuery) (paginator.Page[int64], error) {
+	idPage := paginator.Page[int64]{
+		CurrentPage: followQuery.Page,
+		PageSize:    followQuery.PageSize,
+		Data:        make([]int64, 0, 8),
+	}
+	err := r.db.WithContext(ctx).Model(&followentity.PendingFollow{}).
+		Where("following_id = ?", followQuery.UserID).
+		Count(&idPage.Total).Error
+	if err != nil {
+		return paginator.Page[int64]{}, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	err = r.db.WithContext(ctx).Model(&followentity.PendingFollow{}).
+		Select("follower_id").
+		Where("following_id = ?", followQuery.UserID).
+		Order("id DESC").
+		Scopes(paginator.Paginate(&idPage)).
+		Find(&idPage.Data).Error
+	if err != nil {
+		return paginator.Page[int64]{}, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return idPage, nil
+}
+
+func (r *FollowRepo) ListPendingAfter(ctx context.Context, userID, after int64, limit int) ([]int64, error) {
+	followers := []int64{}
+	err := r.db.WithContext(ctx).Model(&followentity.PendingFollow{}).
+		Where("following_id = ? AND follower_id > ?", userID, after).
+		Order("follower_id").
+		Limit(limit).
+		Pluck("follower_id", &followers).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return followers, nil
+}
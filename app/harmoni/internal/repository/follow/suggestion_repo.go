@@ -0,0 +1,109 @@
+package follow
+
+import (
+	"context"
+	"fmt"
+	followentity "harmoni/app/harmoni/internal/entity/follow"
+	"harmoni/app/harmoni/internal/infrastructure/config"
+	"harmoni/app/harmoni/internal/pkg/reason"
+	"harmoni/internal/pkg/errorx"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+var _ followentity.SuggestionRepository = (*SuggestionRepo)(nil)
+
+// keyPopular 全站粉丝最多的用户，按粉丝数从多到少排列的列表
+const keyPopular = "follow:suggestions.popular"
+
+// suggestionKey 成员是用户ID，分数是推荐分
+func suggestionKey(userID int64) string {
+	return fmt.Sprintf("user:%d:follow.suggestions", userID)
+}
+
+type SuggestionRepo struct {
+	conf   *config.Follow
+	rdb    *redis.Client
+	logger *zap.SugaredLogger
+}
+
+func NewSuggestionRepo(conf *config.Follow, rdb *redis.Client, logger *zap.SugaredLogger) *SuggestionRepo {
+	return &SuggestionRepo{
+		conf:   conf,
+		rdb:    rdb,
+		logger: logger.With("module", "repository/follow"),
+	}
+}
+
+// expire 推荐在下一次计算失败时仍然可用
+func (r *SuggestionRepo) expire() time.Duration {
+	return 2 * r.conf.SuggestionInterval
+}
+
+func (r *SuggestionRepo) SetSuggestions(ctx context.Context, userID int64, suggestions []followentity.Suggestion) error {
+	key := suggestionKey(userID)
+	pipe := r.rdb.TxPipeline()
+	pipe.Del(ctx, key)
+	if len(suggestions) != 0 {
+		members := make([]redis.Z, len(suggestions))
+		for i, s := range suggestions {
+			members[i] = redis.Z{Score: s.Score, Member: s.UserID}
+		}
+		pipe.ZAdd(ctx, key, members...)
+		pipe.Expire(ctx, key, r.expire())
+	}
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func (r *SuggestionRepo) GetSuggestions(ctx context.Context, userID int64) ([]int64, error) {
+	members, err := r.rdb.ZRevRange(ctx, suggestionKey(userID), 0, -1).Result()
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return parseUserIDs(members)
+}
+
+func (r *SuggestionRepo) SetPopular(ctx context.Context, userIDs []int64) error {
+	pipe := r.rdb.TxPipeline()
+	pipe.Del(ctx, keyPopular)
+	if len(userIDs) != 0 {
+		values := make([]interface{}, len(userIDs))
+		for i, userID := range userIDs {
+			values[i] = userID
+		}
+		pipe.RPush(ctx, keyPopular, values...)
+		pipe.Expire(ctx, keyPopular, r.expire())
+	}
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func (r *SuggestionRepo) GetPopular(ctx context.Context) ([]int64, error) {
+	members, err := r.rdb.LRange(ctx, keyPopular, 0, -1).Result()
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return parseUserIDs(members)
+}
+
+func parseUserIDs(members []string) ([]int64, error) {
+	userIDs := make([]int64, len(members))
+	for i, member := range members {
+		userID, err := strconv.ParseInt(member, 10, 64)
+		if err != nil {
+			return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		userIDs[i] = userID
+	}
+	return userIDs, nil
+}
@@ -2,62 +2,87 @@ package like
 
 import (
 	"context"
-	"encoding/binary"
 	"fmt"
 	"harmoni/app/harmoni/internal/entity"
 	likeentity "harmoni/app/harmoni/internal/entity/like"
 	"harmoni/app/harmoni/internal/entity/paginator"
 	userentity "harmoni/app/harmoni/internal/entity/user"
 	"harmoni/app/harmoni/internal/infrastructure/config"
+	"harmoni/app/harmoni/internal/pkg/bloom"
+	"harmoni/app/harmoni/internal/pkg/counter"
 	"harmoni/app/harmoni/internal/pkg/reason"
 	"harmoni/internal/pkg/errorx"
-	"hash/crc32"
 	"strconv"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
 var _ likeentity.LikeRepository = (*LikeRepo)(nil)
 
-const (
-	shardCounts = 5
-)
-
 const (
 	postLikeCountPrefix    = "post:like.count:"
 	commentLikeCountPrefix = "comment:like.count:"
 	userLikeCountPrefix    = "user:like.count:"
-)
 
-func hashID(id int64) uint32 {
-	bs := make([]byte, 8)
-	binary.LittleEndian.PutUint64(bs, uint64(id))
-	return crc32.ChecksumIEEE(bs) % shardCounts
-}
+	postDislikeCountPrefix    = "post:dislike.count:"
+	commentDislikeCountPrefix = "comment:dislike.count:"
+)
 
 func postLikeCountKey(id int64) string {
-	hashedID := hashID(id)
-	return fmt.Sprintf("%s%d", postLikeCountPrefix, hashedID)
+	return counter.Key(postLikeCountPrefix, id)
 }
 
 func commentLikeCountKey(id int64) string {
-	hashedID := hashID(id)
-	return fmt.Sprintf("%s%d", commentLikeCountPrefix, hashedID)
+	return counter.Key(commentLikeCountPrefix, id)
 }
 
 func userLikeCountKey(id int64) string {
-	hashedID := hashID(id)
-	return fmt.Sprintf("%s%d", userLikeCountPrefix, hashedID)
+	return counter.Key(userLikeCountPrefix, id)
 }
 
 func userLikingSetKey(id int64, likeType likeentity.LikeType) string {
 	return fmt.Sprintf("user:%d:like.recent:%d", id, likeType)
 }
 
+func userDislikingSetKey(id int64, likeType likeentity.LikeType) string {
+	return fmt.Sprintf("user:%d:dislike.recent:%d", id, likeType)
+}
+
+// userVotingSetKey 用户最近投票的对象，赞和踩分开保存
+func userVotingSetKey(id int64, likeType likeentity.LikeType, direction likeentity.LikeDirection) string {
+	if direction == likeentity.LikeDown {
+		return userDislikingSetKey(id, likeType)
+	}
+	return userLikingSetKey(id, likeType)
+}
+
+func getCountPrefix(likeType likeentity.LikeType, direction likeentity.LikeDirection) string {
+	switch likeType {
+	case likeentity.LikePost:
+		if direction == likeentity.LikeDown {
+			return postDislikeCountPrefix
+		}
+		return postLikeCountPrefix
+	case likeentity.LikeComment:
+		if direction == likeentity.LikeDown {
+			return commentDislikeCountPrefix
+		}
+		return commentLikeCountPrefix
+	case likeentity.LikeUser:
+		return userLikeCountPrefix
+	}
+	return ""
+}
+
+func getDislikeCountCacheKey(like *likeentity.Like) string {
+	return counter.Key(getCountPrefix(like.LikeType, likeentity.LikeDown), like.LikingID)
+}
+
 func getCountCacheKey(like *likeentity.Like) string {
 	var key string
 	switch like.LikeType {
@@ -78,6 +103,9 @@ type LikeRepo struct {
 	rdb      redis.UniversalClient
 	userRepo userentity.UserRepository
 	logger   *zap.SugaredLogger
+	// filter 和 group 用于判断被赞对象是否存在，见 object.go
+	filter bloom.Filter
+	group  singleflight.Group
 }
 
 func NewLikeRepo(
@@ -93,14 +121,15 @@ func NewLikeRepo(
 		rdb:      rdb,
 		userRepo: userRepo,
 		logger:   logger.With("module", "repository/like"),
+		filter:   bloom.New(conf.ObjectFilterCapacity, conf.ObjectFilterFalsePositive),
 	}
 }
 
 func (r *LikeRepo) Save(ctx context.Context, like *likeentity.Like) error {
 	err := r.db.WithContext(ctx).Clauses(
 		clause.OnConflict{
-			Columns:   []clause.Column{{Name: "liking_id"}},
-			DoUpdates: clause.AssignmentColumns([]string{"canceled"}),
+			Columns:   []clause.Column{{Name: "liking_id"}, {Name: "like_type"}, {Name: "user_id"}, {Name: "reaction"}},
+			DoUpdates: clause.AssignmentColumns([]string{"canceled", "direction", "updated_at"}),
 		},
 	).Create(like).Error
 	if err != nil {
@@ -135,51 +164,75 @@ func (r *LikeRepo) cacheHSetLikeCount(ctx context.Context, key string, id int64,
 	return nil
 }
 
-const (
-	unlikeScript = `
-	local liked = redis.call('ZSCORE', KEYS[1], ARGV[1])
-	if not liked then
+// voteScript 投票并在改投时撤销相反方向的投票，对象作者收到的点赞数只计算赞。
+// 点赞用户时对象的赞数就是用户收到的点赞数，只累加一次。
+// 返回 0 表示没有变化，1 表示投票或取消成功，2 表示从相反方向改投
+const voteScript = `
+local up = ARGV[5] == '1'
+local credit = ARGV[6] ~= '1'
+if ARGV[3] == '1' then
+	if redis.call('ZREM', KEYS[1], ARGV[1]) == 0 then
 		return 0
 	end
-    local removed = redis.call('ZREM', KEYS[1], ARGV[1])
-    if removed == 1 then
-        redis.call('HINCRBY', KEYS[2], ARGV[1], ARGV[3])
-		redis.call('HINCRBY', KEYS[3], ARGV[4], ARGV[3])
-    end
-	return 1
-	`
-
-	likeScript = `
-	local liked = redis.call('ZSCORE', KEYS[1], ARGV[1])
-	if liked then
-		return 0
+	redis.call('HINCRBY', KEYS[3], ARGV[1], -1)
+	if up and credit then
+		redis.call('HINCRBY', KEYS[5], ARGV[4], -1)
 	end
-	local added = redis.call('ZADD', KEYS[1], ARGV[2], ARGV[1])
-    if added == 1 then
-    	redis.call('HINCRBY', KEYS[2], ARGV[1], ARGV[3])
-		redis.call('HINCRBY', KEYS[3], ARGV[4], ARGV[3])
-    end
 	return 1
-	`
-)
-
-func (r *LikeRepo) likeAction(ctx context.Context, key string, like *likeentity.Like, targetUserID int64, isCancel bool) error {
-	var (
-		count int64
-	)
+end
+if redis.call('ZSCORE', KEYS[1], ARGV[1]) then
+	return 0
+end
+redis.call('ZADD', KEYS[1], ARGV[2], ARGV[1])
+redis.call('HINCRBY', KEYS[3], ARGV[1], 1)
+local switched = redis.call('ZREM', KEYS[2], ARGV[1])
+if switched == 1 then
+	redis.call('HINCRBY', KEYS[4], ARGV[1], -1)
+end
+if credit and up then
+	redis.call('HINCRBY', KEYS[5], ARGV[4], 1)
+elseif credit and switched == 1 then
+	redis.call('HINCRBY', KEYS[5], ARGV[4], -1)
+end
+return 1 + switched
+`
+
+// cacheCountFromDB 计数未缓存时从数据库统计，避免在空字段上累加
+func (r *LikeRepo) cacheCountFromDB(ctx context.Context, key string, like *likeentity.Like, direction likeentity.LikeDirection) error {
+	err := r.rdb.HGet(ctx, key, strconv.FormatInt(like.LikingID, 10)).Err()
+	if err == nil {
+		return nil
+	} else if err != redis.Nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
 
-	objKey := getCountCacheKey(like)
-	if n, err := r.rdb.Exists(ctx, objKey).Result(); err != nil {
+	var count int64
+	err = r.db.WithContext(ctx).Table("like").
+		Where("liking_id = ? AND like_type = ? AND direction = ? AND reaction = '' AND canceled = 0", like.LikingID, like.LikeType, direction).
+		Count(&count).Error
+	if err != nil {
 		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
-	} else if n < 1 {
-		// retrieve count from DB
-		err = r.db.WithContext(ctx).Table("like").Where("liking_id = ? AND like_type = ? AND canceled = 0", like.LikingID, like.LikeType).Count(&count).Error
-		if err != nil {
-			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return r.cacheHSetLikeCount(ctx, key, like.LikingID, count)
+}
+
+func (r *LikeRepo) likeAction(ctx context.Context, like *likeentity.Like, targetUserID int64, isCancel bool) (bool, error) {
+	// users only have a like counter, a downvote would be counted as a like
+	if like.Direction == likeentity.LikeDown && like.LikeType == likeentity.LikeUser {
+		return false, errorx.BadRequest(reason.TypeNotSupport)
+	}
+	likeKey := getCountCacheKey(like)
+	dislikeKey := getDislikeCountCacheKey(like)
+	// the like count of a user is loaded below as the target user's count
+	shared := 0
+	if like.LikeType == likeentity.LikeUser {
+		shared = 1
+	} else {
+		if err := r.cacheCountFromDB(ctx, likeKey, like, likeentity.LikeUp); err != nil {
+			return false, err
 		}
-		err = r.cacheHSetLikeCount(ctx, objKey, like.LikingID, count)
-		if err != nil {
-			return err
+		if err := r.cacheCountFromDB(ctx, dislikeKey, like, likeentity.LikeDown); err != nil {
+			return false, err
 		}
 	}
 	userLikedKey := userLikeCountKey(targetUserID)
@@ -188,43 +241,49 @@ func (r *LikeRepo) likeAction(ctx context.Context, key string, like *likeentity.
 		// user is impossible not exist
 		count, _, err := r.userRepo.GetLikeCount(ctx, targetUserID)
 		if err != nil {
-			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+			return false, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 		}
 		// set count to cache
 		err = r.cacheHSetLikeCount(ctx, userLikedKey, targetUserID, count)
 		if err != nil {
-			return err
+			return false, err
 		}
 	} else if err != nil {
-		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		return false, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
 
+	keys := []string{
+		userVotingSetKey(like.UserID, like.LikeType, like.Direction),
+		userVotingSetKey(like.UserID, like.LikeType, -like.Direction),
+		likeKey, dislikeKey, userLikedKey,
+	}
+	if like.Direction == likeentity.LikeDown {
+		keys[2], keys[3] = dislikeKey, likeKey
+	}
+	cancel := 0
 	if isCancel {
-		score, err := redis.NewScript(unlikeScript).Run(ctx, r.rdb, []string{key, objKey, userLikedKey}, like.LikingID, time.Now().Unix(), -1, targetUserID).Int()
-		if err != nil {
-			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
-		} else if score == 0 {
-			return errorx.BadRequest(reason.LikeCancelFailToNotLiked)
-		}
-	} else {
-		score, err := redis.NewScript(likeScript).Run(ctx, r.rdb, []string{key, objKey, userLikedKey}, like.LikingID, time.Now().Unix(), 1, targetUserID).Int()
-		if err != nil {
-			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
-		} else if score == 0 {
-			return errorx.BadRequest(reason.LikeAlreadyExist)
-		}
+		cancel = 1
+	}
+	result, err := redis.NewScript(voteScript).Run(ctx, r.rdb, keys,
+		like.LikingID, time.Now().Unix(), cancel, targetUserID, int(like.Direction), shared).Int()
+	if err != nil {
+		return false, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	} else if result == 0 && isCancel {
+		return false, errorx.BadRequest(reason.LikeCancelFailToNotLiked)
+	} else if result == 0 {
+		return false, errorx.BadRequest(reason.LikeAlreadyExist)
 	}
 
-	return nil
+	return result == 2, nil
 }
 
-func (r *LikeRepo) transLikingsFromDBToCache(ctx context.Context, key string, like *likeentity.Like) ([]likeentity.LikeCacheInfo, error) {
+func (r *LikeRepo) transLikingsFromDBToCache(ctx context.Context, key string, like *likeentity.Like, direction likeentity.LikeDirection) ([]likeentity.LikeCacheInfo, error) {
 	// user recent likes
 	likes := []likeentity.LikeCacheInfo{}
 	err := r.db.WithContext(ctx).
 		Table("like").
 		Select("liking_id", "updated_at").
-		Where("user_id = ? AND like_type = ? AND canceled = 0", like.UserID, like.LikeType).
+		Where("user_id = ? AND like_type = ? AND direction = ? AND reaction = '' AND canceled = 0", like.UserID, like.LikeType, direction).
 		Order("updated_at DESC").
 		Limit(600).
 		Find(&likes).Error
@@ -258,34 +317,36 @@ func (r *LikeRepo) transLikingsFromDBToCache(ctx context.Context, key string, li
 	return likes, nil
 }
 
-func (r *LikeRepo) Like(ctx context.Context, like *likeentity.Like, targetUserID int64, isCancel bool) error {
-	var (
-		err error
-	)
-
-	key := userLikingSetKey(like.UserID, like.LikeType)
-	if n, err := r.rdb.Exists(ctx, key).Result(); err != nil {
+// loadVotingSet 用户最近投票的对象未缓存时从数据库加载
+func (r *LikeRepo) loadVotingSet(ctx context.Context, like *likeentity.Like, direction likeentity.LikeDirection) error {
+	key := userVotingSetKey(like.UserID, like.LikeType, direction)
+	n, err := r.rdb.Exists(ctx, key).Result()
+	if err != nil {
 		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	} else if n > 0 {
-		err = r.likeAction(ctx, key, like, targetUserID, isCancel)
-		if err != nil {
-			return err
-		}
 		return nil
 	}
 
-	// user recent likes
-	_, err = r.transLikingsFromDBToCache(ctx, key, like)
-	if err != nil {
-		return err
+	_, err = r.transLikingsFromDBToCache(ctx, key, like, direction)
+	return err
+}
+
+func (r *LikeRepo) Like(ctx context.Context, like *likeentity.Like, targetUserID int64, isCancel bool) (bool, error) {
+	if like.Direction == 0 {
+		like.Direction = likeentity.LikeUp
 	}
 
-	err = r.likeAction(ctx, key, like, targetUserID, isCancel)
+	// switching direction needs both sets
+	err := r.loadVotingSet(ctx, like, likeentity.LikeUp)
+	if err != nil {
+		return false, err
+	}
+	err = r.loadVotingSet(ctx, like, likeentity.LikeDown)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	return nil
+	return r.likeAction(ctx, like, targetUserID, isCancel)
 }
 
 func (r *LikeRepo) CacheLikeCount(ctx context.Context, like *likeentity.Like, count int64) error {
@@ -322,127 +383,117 @@ func (r *LikeRepo) LikeCount(ctx context.Context, like *likeentity.Like) (int64,
 	return count, true, err
 }
 
-func (r *LikeRepo) BatchLikeCount(ctx context.Context, likeType likeentity.LikeType) (map[int64]int64, error) {
-	keys := make([]string, 0, shardCounts)
-	switch likeType {
-	case likeentity.LikePost:
-		for i := 0; i < shardCounts; i++ {
-			keys = append(keys, fmt.Sprintf("%s%d", postLikeCountPrefix, i))
-		}
-	case likeentity.LikeComment:
-		for i := 0; i < shardCounts; i++ {
-			keys = append(keys, fmt.Sprintf("%s%d", commentLikeCountPrefix, i))
-		}
-	case likeentity.LikeUser:
-		for i := 0; i < shardCounts; i++ {
-			keys = append(keys, fmt.Sprintf("%s%d", userLikeCountPrefix, i))
-		}
+func (r *LikeRepo) batchCount(ctx context.Context, prefix string) (map[int64]int64, error) {
+	if prefix == "" {
+		return map[int64]int64{}, nil
 	}
-
-	m := map[int64]int64{}
-	for _, key := range keys {
-		kvs, err := r.rdb.HGetAll(ctx, key).Result()
-		if err != nil {
-			return nil, err
-		}
-		for k, v := range kvs {
-			if v == entity.DefaultRedisValue {
-				continue
-			}
-			vNum, _ := strconv.ParseInt(v, 10, 64)
-
-			kNum, _ := strconv.ParseInt(k, 10, 64)
-			m[kNum] = vNum
-		}
+	m, err := counter.All(ctx, r.rdb, prefix)
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
 
 	return m, nil
 }
 
-func (r *LikeRepo) BatchLikeCountByIDs(ctx context.Context, likingIDs []int64, likeType likeentity.LikeType) (map[int64]int64, error) {
-	keys := make(map[string][]string)
-	countMap := make(map[int64]int64)
+func (r *LikeRepo) BatchLikeCount(ctx context.Context, likeType likeentity.LikeType) (map[int64]int64, error) {
+	return r.batchCount(ctx, getCountPrefix(likeType, likeentity.LikeUp))
+}
 
-	switch likeType {
-	case likeentity.LikePost:
-		for _, likingID := range likingIDs {
-			hashedID := postLikeCountKey(likingID)
-			keys[hashedID] = append(keys[hashedID], strconv.FormatInt(likingID, 10))
-		}
-	case likeentity.LikeComment:
-		for _, likingID := range likingIDs {
-			hashedID := commentLikeCountKey(likingID)
-			keys[hashedID] = append(keys[hashedID], strconv.FormatInt(likingID, 10))
-		}
+func (r *LikeRepo) BatchDislikeCount(ctx context.Context, likeType likeentity.LikeType) (map[int64]int64, error) {
+	if likeType == likeentity.LikeUser {
+		return map[int64]int64{}, nil
 	}
+	return r.batchCount(ctx, getCountPrefix(likeType, likeentity.LikeDown))
+}
 
-	notCachedIDMap := make(map[string][]string)
-	for key, ids := range keys {
-		counts, err := r.rdb.HMGet(ctx, key, ids...).Result()
-		if err != nil {
-			return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
-		}
-
-		for i, idStr := range ids {
-			id, _ := strconv.ParseInt(idStr, 10, 64)
-			value := counts[i]
-			if value == nil {
-				notCachedIDMap[key] = append(notCachedIDMap[key], idStr)
-			}
-			switch t := value.(type) {
-			case string:
-				if t != entity.DefaultRedisValue {
-					likeCount, _ := strconv.ParseInt(t, 10, 64)
-					countMap[id] = likeCount
-				}
-			}
-		}
+func (r *LikeRepo) batchCountByIDs(ctx context.Context, likingIDs []int64, likeType likeentity.LikeType, direction likeentity.LikeDirection) (map[int64]int64, error) {
+	if likeType != likeentity.LikePost && likeType != likeentity.LikeComment {
+		return map[int64]int64{}, nil
 	}
 
-	var likeCounts []struct {
-		LikingID  int64 `json:"liking_id,omitempty"`
-		LikeCount int64 `json:"like_count,omitempty"`
+	prefix := getCountPrefix(likeType, direction)
+	countMap, notCached, err := counter.Get(ctx, r.rdb, prefix, likingIDs)
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
-
-	if len(notCachedIDMap) == 0 {
+	if len(notCached) == 0 {
 		return countMap, nil
 	}
 
-	notCacheIDs := make([]string, 0, 24)
-	for _, v := range notCachedIDMap {
-		notCacheIDs = append(notCacheIDs, v...)
+	var likeCounts []struct {
+		LikingID  int64 `json:"liking_id,omitempty"`
+		LikeCount int64 `json:"like_count,omitempty"`
 	}
-	err := r.db.WithContext(ctx).Table("like").
+	err = r.db.WithContext(ctx).Table("like").
 		Select("liking_id", "COUNT(*) AS like_count").
-		Where("liking_id IN (?) AND canceled = 0", notCacheIDs).
+		Where("liking_id IN (?) AND like_type = ? AND direction = ? AND reaction = '' AND canceled = 0", notCached, likeType, direction).
 		Group("liking_id").Find(&likeCounts).Error
-
 	if err != nil {
 		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
 
-	keyvals := make(map[string][]interface{})
-	likeCountsMap := make(map[string]int64)
+	counts := make(map[int64]int64, len(notCached))
+	for _, likingID := range notCached {
+		counts[likingID] = 0
+	}
 	for _, v := range likeCounts {
+		counts[v.LikingID] = v.LikeCount
 		countMap[v.LikingID] = v.LikeCount
-		likeCountsMap[strconv.FormatInt(v.LikingID, 10)] = v.LikeCount
 	}
-	for key, ids := range notCachedIDMap {
-		keyvals[key] = make([]interface{}, 0, len(ids)*2)
-		for _, id := range ids {
-			keyvals[key] = append(keyvals[key], id, likeCountsMap[id])
-		}
-		err = r.rdb.HMSet(ctx, key, keyvals[key]...).Err()
+	err = counter.Set(ctx, r.rdb, prefix, counts, r.conf.CacheDuration)
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return countMap, nil
+}
+
+func (r *LikeRepo) BatchLikeCountByIDs(ctx context.Context, likingIDs []int64, likeType likeentity.LikeType) (map[int64]int64, error) {
+	return r.batchCountByIDs(ctx, likingIDs, likeType, likeentity.LikeUp)
+}
+
+func (r *LikeRepo) BatchDislikeCountByIDs(ctx context.Context, likingIDs []int64, likeType likeentity.LikeType) (map[int64]int64, error) {
+	return r.batchCountByIDs(ctx, likingIDs, likeType, likeentity.LikeDown)
+}
+
+func (r *LikeRepo) BatchVotes(ctx context.Context, userID int64, likeType likeentity.LikeType, likingIDs []int64) (map[int64]likeentity.LikeDirection, error) {
+	votes := make(map[int64]likeentity.LikeDirection, len(likingIDs))
+	if len(likingIDs) == 0 {
+		return votes, nil
+	}
+
+	members := make([]string, len(likingIDs))
+	for i, likingID := range likingIDs {
+		members[i] = strconv.FormatInt(likingID, 10)
+	}
+	directions := []likeentity.LikeDirection{likeentity.LikeUp, likeentity.LikeDown}
+	like := &likeentity.Like{UserID: userID, LikeType: likeType}
+	for _, direction := range directions {
+		err := r.loadVotingSet(ctx, like, direction)
 		if err != nil {
-			return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+			return nil, err
 		}
-		err = r.rdb.Expire(ctx, key, r.conf.CacheDuration).Err()
-		if err != nil {
-			return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	pipe := r.rdb.Pipeline()
+	cmds := make([]*redis.FloatSliceCmd, len(directions))
+	for i, direction := range directions {
+		cmds[i] = pipe.ZMScore(ctx, userVotingSetKey(userID, likeType, direction), members...)
+	}
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	// ZMSCORE returns 0 for missing members, liked objects are scored by time
+	for i, direction := range directions {
+		for j, score := range cmds[i].Val() {
+			if score > 0 {
+				votes[likingIDs[j]] = direction
+			}
 		}
 	}
 
-	return countMap, nil
+	return votes, nil
 }
 
 func (r *LikeRepo) paginate(page *entity.PageCond) (int64, int64) {
@@ -510,7 +561,7 @@ func (r *LikeRepo) ListLikingIDs(ctx context.Context, query *likeentity.LikeQuer
 		return idPage, nil
 	}
 
-	likes, err := r.transLikingsFromDBToCache(ctx, key, &likeentity.Like{UserID: query.UserID, LikingID: query.ObjectID, LikeType: query.Type})
+	likes, err := r.transLikingsFromDBToCache(ctx, key, &likeentity.Like{UserID: query.UserID, LikingID: query.ObjectID, LikeType: query.Type}, likeentity.LikeUp)
 	if err != nil {
 		return paginator.Page[int64]{}, err
 	}
@@ -544,7 +595,7 @@ func (r *LikeRepo) IsLiking(ctx context.Context, like *likeentity.Like) (bool, e
 	key := userLikingSetKey(like.UserID, like.LikeType)
 	err := r.rdb.ZScore(ctx, key, strconv.FormatInt(like.LikingID, 10)).Err()
 	if err == redis.Nil {
-		_, err = r.transLikingsFromDBToCache(ctx, key, like)
+		_, err = r.transLikingsFromDBToCache(ctx, key, like, likeentity.LikeUp)
 		if err != nil {
 			return false, err
 		}
@@ -562,3 +613,42 @@ func (r *LikeRepo) IsLiking(ctx context.Context, like *likeentity.Like) (bool, e
 
 	return true, nil
 }
+
+func (r *LikeRepo) DeleteByLikingIDs(ctx context.Context, likeType likeentity.LikeType, likingIDs []int64) error {
+	if len(likingIDs) == 0 {
+		return nil
+	}
+
+	err := r.db.WithContext(ctx).
+		Where("like_type = ? AND liking_id IN ?", likeType, likingIDs).
+		Delete(&likeentity.Like{}).Error
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	fields := make(map[string][]string)
+	for _, likingID := range likingIDs {
+		like := &likeentity.Like{LikeType: likeType, LikingID: likingID}
+		keys := []string{getCountCacheKey(like)}
+		if likeType != likeentity.LikeUser {
+			keys = append(keys, getDislikeCountCacheKey(like))
+		}
+		for _, key := range keys {
+			fields[key] = append(fields[key], strconv.FormatInt(likingID, 10))
+		}
+	}
+
+	pipe := r.rdb.Pipeline()
+	for key, ids := range fields {
+		pipe.HDel(ctx, key, ids...)
+	}
+	for _, likingID := range likingIDs {
+		pipe.Del(ctx, reactionCountKey(likeType, likingID))
+	}
+	_, err = pipe.Exec(ctx)
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return nil
+}
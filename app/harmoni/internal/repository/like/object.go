@@ -0,0 +1,184 @@
+package like
+
+import (
+	"context"
+	"fmt"
+	"harmoni/app/harmoni/internal/entity"
+	likeentity "harmoni/app/harmoni/internal/entity/like"
+	"harmoni/app/harmoni/internal/pkg/reason"
+	"harmoni/internal/pkg/errorx"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// objectFilterKey 一种对象的布隆过滤器，重建时先写入 building 键再替换
+func objectFilterKey(likeType likeentity.LikeType) string {
+	return fmt.Sprintf("object.filter:%d", likeType)
+}
+
+func objectFilterBuildingKey(likeType likeentity.LikeType) string {
+	return fmt.Sprintf("object.filter.building:%d", likeType)
+}
+
+// objectMetaKey 对象的作者，不存在的对象缓存为 entity.DefaultRedisValue
+func objectMetaKey(likeType likeentity.LikeType, likingID int64) string {
+	return fmt.Sprintf("object.meta:%d:%d", likeType, likingID)
+}
+
+// addObjectScript 只在过滤器已经建立时写入，否则只有这一个对象的过滤器会拒绝其他所有对象。
+// 正在重建时同时写入新的过滤器，避免重建期间创建的对象丢失
+const addObjectScript = `
+local building = redis.call('EXISTS', KEYS[2]) == 1
+if redis.call('EXISTS', KEYS[1]) == 0 and not building then
+	return 0
+end
+for i = 1, #ARGV do
+	redis.call('SETBIT', KEYS[1], ARGV[i], 1)
+	if building then
+		redis.call('SETBIT', KEYS[2], ARGV[i], 1)
+	end
+end
+return 1
+`
+
+// objectFilterBuildTimeout 重建中断后 building 键过期，之后的写入不再重复写两份
+const objectFilterBuildTimeout = time.Hour
+
+func (r *LikeRepo) GetObjectMeta(ctx context.Context, likeType likeentity.LikeType, likingID int64,
+	load func(ctx context.Context) (*likeentity.ObjectMeta, error)) (*likeentity.ObjectMeta, error) {
+	// the filter and the cache are read in a single round trip
+	filterKey := objectFilterKey(likeType)
+	pipe := r.rdb.Pipeline()
+	built := pipe.Exists(ctx, filterKey)
+	offsets := r.filter.Offsets(likingID)
+	bits := make([]*redis.IntCmd, len(offsets))
+	for i, offset := range offsets {
+		bits[i] = pipe.GetBit(ctx, filterKey, int64(offset))
+	}
+	cached := pipe.Get(ctx, objectMetaKey(likeType, likingID))
+	_, err := pipe.Exec(ctx)
+	if err != nil && err != redis.Nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	if built.Val() > 0 {
+		for _, bit := range bits {
+			if bit.Val() == 0 {
+				return &likeentity.ObjectMeta{}, nil
+			}
+		}
+	}
+	if cached.Err() == nil {
+		if cached.Val() == entity.DefaultRedisValue {
+			return &likeentity.ObjectMeta{}, nil
+		}
+		authorID, err := strconv.ParseInt(cached.Val(), 10, 64)
+		if err == nil {
+			return &likeentity.ObjectMeta{AuthorID: authorID, Exist: true}, nil
+		}
+		r.logger.Warn(err)
+	}
+
+	v, err, _ := r.group.Do(objectMetaKey(likeType, likingID), func() (interface{}, error) {
+		meta, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		value, expiration := entity.DefaultRedisValue, r.conf.NegativeCacheDuration
+		if meta.Exist {
+			value, expiration = strconv.FormatInt(meta.AuthorID, 10), r.conf.CacheDuration
+		}
+		err = r.rdb.Set(ctx, objectMetaKey(likeType, likingID), value, expiration).Err()
+		if err != nil {
+			return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		return meta, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*likeentity.ObjectMeta), nil
+}
+
+func (r *LikeRepo) AddObject(ctx context.Context, likeType likeentity.LikeType, likingID, authorID int64) error {
+	offsets := r.filter.Offsets(likingID)
+	args := make([]interface{}, len(offsets))
+	for i, offset := range offsets {
+		args[i] = offset
+	}
+	keys := []string{objectFilterKey(likeType), objectFilterBuildingKey(likeType)}
+	err := redis.NewScript(addObjectScript).Run(ctx, r.rdb, keys, args...).Err()
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	// overwrite the negative cache left by likes before the object was published
+	err = r.rdb.Set(ctx, objectMetaKey(likeType, likingID), authorID, r.conf.CacheDuration).Err()
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func (r *LikeRepo) RemoveObject(ctx context.Context, likeType likeentity.LikeType, likingIDs ...int64) error {
+	if len(likingIDs) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(likingIDs))
+	for i, likingID := range likingIDs {
+		keys[i] = objectMetaKey(likeType, likingID)
+	}
+	err := r.rdb.Del(ctx, keys...).Err()
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func (r *LikeRepo) RebuildObjectFilter(ctx context.Context, likeType likeentity.LikeType, list func(after int64) ([]int64, error)) error {
+	key, buildingKey := objectFilterKey(likeType), objectFilterBuildingKey(likeType)
+	// create the building key before listing, objects added from now on are written to both filters
+	pipe := r.rdb.TxPipeline()
+	pipe.Del(ctx, buildingKey)
+	pipe.SetBit(ctx, buildingKey, 0, 0)
+	pipe.Expire(ctx, buildingKey, objectFilterBuildTimeout)
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	var after int64
+	for {
+		ids, err := list(after)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			break
+		}
+
+		pipe := r.rdb.Pipeline()
+		for _, id := range ids {
+			for _, offset := range r.filter.Offsets(id) {
+				pipe.SetBit(ctx, buildingKey, int64(offset), 1)
+			}
+		}
+		_, err = pipe.Exec(ctx)
+		if err != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		after = ids[len(ids)-1]
+	}
+
+	pipe = r.rdb.TxPipeline()
+	pipe.Rename(ctx, buildingKey, key)
+	pipe.Persist(ctx, key)
+	_, err = pipe.Exec(ctx)
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
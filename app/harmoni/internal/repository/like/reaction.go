@@ -0,0 +1,244 @@
+package like
+
+import (
+	"context"
+	"fmt"
+	"harmoni/app/harmoni/internal/entity"
+	likeentity "harmoni/app/harmoni/internal/entity/like"
+	"harmoni/app/harmoni/internal/pkg/reason"
+	"harmoni/internal/pkg/errorx"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// reactionCountKey 对象收到的表情回应数，field 为表情
+func reactionCountKey(likeType likeentity.LikeType, likingID int64) string {
+	return fmt.Sprintf("reaction.count:%d:%d", likeType, likingID)
+}
+
+// userReactionKey 用户最近的表情回应，field 为对象ID，value 为逗号分隔的表情
+func userReactionKey(userID int64, likeType likeentity.LikeType) string {
+	return fmt.Sprintf("user:%d:reaction.recent:%d", userID, likeType)
+}
+
+// reactScript 添加或取消表情回应，不允许多个回应时新的回应替换已有的所有回应。
+// 返回 {0} 表示没有变化，{1, 逗号分隔的被替换的回应} 表示成功
+const reactScript = `
+local current = redis.call('HGET', KEYS[1], ARGV[1]) or ''
+local held = {}
+local pos = 0
+for r in string.gmatch(current, '[^,]+') do
+	held[#held + 1] = r
+	if r == ARGV[2] then
+		pos = #held
+	end
+end
+local replaced = {}
+if ARGV[3] == '1' then
+	if pos == 0 then
+		return {0, ''}
+	end
+	table.remove(held, pos)
+	redis.call('HINCRBY', KEYS[2], ARGV[2], -1)
+else
+	if pos ~= 0 and (ARGV[4] == '1' or #held == 1) then
+		return {0, ''}
+	end
+	if ARGV[4] ~= '1' then
+		-- reactions held before multiple reactions were disabled are all replaced
+		for _, r in ipairs(held) do
+			if r ~= ARGV[2] then
+				replaced[#replaced + 1] = r
+				redis.call('HINCRBY', KEYS[2], r, -1)
+			end
+		end
+		held = {}
+	end
+	held[#held + 1] = ARGV[2]
+	if pos == 0 then
+		redis.call('HINCRBY', KEYS[2], ARGV[2], 1)
+	end
+end
+if #held == 0 then
+	redis.call('HDEL', KEYS[1], ARGV[1])
+else
+	redis.call('HSET', KEYS[1], ARGV[1], table.concat(held, ','))
+end
+return {1, table.concat(replaced, ',')}
+`
+
+// loadReactionCount 对象的表情回应数未缓存时从数据库统计
+func (r *LikeRepo) loadReactionCount(ctx context.Context, likeType likeentity.LikeType, likingID int64) error {
+	key := reactionCountKey(likeType, likingID)
+	n, err := r.rdb.Exists(ctx, key).Result()
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	} else if n > 0 {
+		return nil
+	}
+
+	var counts []struct {
+		Reaction string
+		Count    int64
+	}
+	err = r.db.WithContext(ctx).Table("like").
+		Select("reaction", "COUNT(*) AS count").
+		Where("liking_id = ? AND like_type = ? AND reaction <> '' AND canceled = 0", likingID, likeType).
+		Group("reaction").Find(&counts).Error
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	values := make([]interface{}, 0, len(counts)*2+2)
+	values = append(values, entity.DefaultRedisValue, entity.DefaultRedisValue)
+	for _, c := range counts {
+		values = append(values, c.Reaction, c.Count)
+	}
+	pipe := r.rdb.Pipeline()
+	pipe.HSet(ctx, key, values...)
+	pipe.Expire(ctx, key, r.conf.CacheDuration)
+	_, err = pipe.Exec(ctx)
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// loadUserReactions 用户最近的表情回应未缓存时从数据库加载
+func (r *LikeRepo) loadUserReactions(ctx context.Context, userID int64, likeType likeentity.LikeType) error {
+	key := userReactionKey(userID, likeType)
+	n, err := r.rdb.Exists(ctx, key).Result()
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	} else if n > 0 {
+		return nil
+	}
+
+	var reactions []struct {
+		LikingID int64
+		Reaction string
+	}
+	err = r.db.WithContext(ctx).Table("like").
+		Select("liking_id", "reaction").
+		Where("user_id = ? AND like_type = ? AND reaction <> '' AND canceled = 0", userID, likeType).
+		Order("updated_at DESC").
+		Limit(600).
+		Find(&reactions).Error
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	held := make(map[int64][]string)
+	for i := len(reactions) - 1; i >= 0; i-- {
+		held[reactions[i].LikingID] = append(held[reactions[i].LikingID], reactions[i].Reaction)
+	}
+	values := make([]interface{}, 0, len(held)*2+2)
+	values = append(values, entity.DefaultRedisValue, entity.DefaultRedisValue)
+	for likingID, rs := range held {
+		values = append(values, strconv.FormatInt(likingID, 10), strings.Join(rs, ","))
+	}
+	pipe := r.rdb.Pipeline()
+	pipe.HSet(ctx, key, values...)
+	pipe.Expire(ctx, key, r.conf.CacheDuration)
+	_, err = pipe.Exec(ctx)
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func (r *LikeRepo) React(ctx context.Context, like *likeentity.Like, isCancel bool) (string, error) {
+	err := r.loadReactionCount(ctx, like.LikeType, like.LikingID)
+	if err != nil {
+		return "", err
+	}
+	err = r.loadUserReactions(ctx, like.UserID, like.LikeType)
+	if err != nil {
+		return "", err
+	}
+
+	cancel, multiple := 0, 0
+	if isCancel {
+		cancel = 1
+	}
+	if r.conf.MultipleReactions {
+		multiple = 1
+	}
+	keys := []string{userReactionKey(like.UserID, like.LikeType), reactionCountKey(like.LikeType, like.LikingID)}
+	result, err := redis.NewScript(reactScript).Run(ctx, r.rdb, keys,
+		like.LikingID, like.Reaction, cancel, multiple).Slice()
+	if err != nil {
+		return "", errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if ok, _ := result[0].(int64); ok == 0 {
+		if isCancel {
+			return "", errorx.BadRequest(reason.LikeCancelFailToNotLiked)
+		}
+		return "", errorx.BadRequest(reason.LikeAlreadyExist)
+	}
+
+	replaced, _ := result[1].(string)
+	return replaced, nil
+}
+
+func (r *LikeRepo) BatchReactionCountByIDs(ctx context.Context, likingIDs []int64, likeType likeentity.LikeType) (map[int64]map[string]int64, error) {
+	for _, likingID := range likingIDs {
+		err := r.loadReactionCount(ctx, likeType, likingID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pipe := r.rdb.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, len(likingIDs))
+	for i, likingID := range likingIDs {
+		cmds[i] = pipe.HGetAll(ctx, reactionCountKey(likeType, likingID))
+	}
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	counts := make(map[int64]map[string]int64, len(likingIDs))
+	for i, likingID := range likingIDs {
+		m := make(map[string]int64)
+		for reaction, v := range cmds[i].Val() {
+			if reaction == entity.DefaultRedisValue {
+				continue
+			}
+			if count, _ := strconv.ParseInt(v, 10, 64); count > 0 {
+				m[reaction] = count
+			}
+		}
+		counts[likingID] = m
+	}
+	return counts, nil
+}
+
+func (r *LikeRepo) BatchUserReactions(ctx context.Context, userID int64, likeType likeentity.LikeType, likingIDs []int64) (map[int64][]string, error) {
+	reactions := make(map[int64][]string, len(likingIDs))
+	if len(likingIDs) == 0 {
+		return reactions, nil
+	}
+
+	err := r.loadUserReactions(ctx, userID, likeType)
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]string, len(likingIDs))
+	for i, likingID := range likingIDs {
+		fields[i] = strconv.FormatInt(likingID, 10)
+	}
+	values, err := r.rdb.HMGet(ctx, userReactionKey(userID, likeType), fields...).Result()
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	for i, v := range values {
+		if s, ok := v.(string); ok && s != "" {
+			reactions[likingIDs[i]] = strings.Split(s, ",")
+		}
+	}
+	return reactions, nil
+}
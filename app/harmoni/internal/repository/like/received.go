@@ -0,0 +1,57 @@
+package like
+
+import (
+	"context"
+	likeentity "harmoni/app/harmoni/internal/entity/like"
+	"harmoni/app/harmoni/internal/pkg/reason"
+	"harmoni/internal/pkg/errorx"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// receivedScope 用户收到的有效的赞，表情回应也算作赞
+func receivedScope(targetUserID int64, since, until time.Time) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Where("target_user_id = ? AND canceled = 0 AND direction = ?", targetUserID, likeentity.LikeUp)
+		if !since.IsZero() {
+			db = db.Where("updated_at >= ?", since)
+		}
+		if !until.IsZero() {
+			db = db.Where("updated_at < ?", until)
+		}
+		return db
+	}
+}
+
+func (r *LikeRepo) ListReceived(ctx context.Context, query *likeentity.ReceivedLikeQuery) ([]*likeentity.Like, error) {
+	db := r.db.WithContext(ctx).
+		Scopes(receivedScope(query.TargetUserID, query.Since, query.Until))
+	if query.Type != 0 {
+		db = db.Where("like_type = ?", query.Type)
+	}
+	if !query.Before.IsZero() {
+		db = db.Where("updated_at < ? OR (updated_at = ? AND id < ?)", query.Before, query.Before, query.BeforeID)
+	}
+
+	likes := []*likeentity.Like{}
+	err := db.Order("updated_at DESC, id DESC").Limit(query.Limit).Find(&likes).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return likes, nil
+}
+
+func (r *LikeRepo) CountReceivedByObject(ctx context.Context, targetUserID int64, since, until time.Time) ([]likeentity.ReceivedLikeCount, error) {
+	counts := []likeentity.ReceivedLikeCount{}
+	err := r.db.WithContext(ctx).Table("like").
+		Select("like_type", "liking_id", "COUNT(*) AS count").
+		Scopes(receivedScope(targetUserID, since, until)).
+		Group("like_type, liking_id").
+		Order("count DESC").
+		Find(&counts).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return counts, nil
+}
@@ -0,0 +1,109 @@
+package like
+
+import (
+	"context"
+	likeentity "harmoni/app/harmoni/internal/entity/like"
+	"harmoni/app/harmoni/internal/pkg/counter"
+	"harmoni/app/harmoni/internal/pkg/reason"
+	"harmoni/internal/pkg/errorx"
+)
+
+func (r *LikeRepo) CountByLikingIDs(ctx context.Context, likeType likeentity.LikeType, likingIDs []int64) (map[int64]int64, map[int64]int64, error) {
+	likes, dislikes := make(map[int64]int64), make(map[int64]int64)
+	if len(likingIDs) == 0 {
+		return likes, dislikes, nil
+	}
+
+	var counts []struct {
+		LikingID  int64
+		Direction likeentity.LikeDirection
+		Count     int64
+	}
+	err := r.db.WithContext(ctx).Table("like").
+		Select("liking_id", "direction", "COUNT(*) AS count").
+		Where("liking_id IN ? AND like_type = ? AND reaction = '' AND canceled = 0", likingIDs, likeType).
+		Group("liking_id, direction").
+		Find(&counts).Error
+	if err != nil {
+		return nil, nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	for _, c := range counts {
+		if c.Direction == likeentity.LikeDown {
+			dislikes[c.LikingID] = c.Count
+		} else {
+			likes[c.LikingID] = c.Count
+		}
+	}
+	return likes, dislikes, nil
+}
+
+func (r *LikeRepo) CountByTargetUserIDs(ctx context.Context, userIDs []int64) (map[int64]int64, error) {
+	likes := make(map[int64]int64)
+	if len(userIDs) == 0 {
+		return likes, nil
+	}
+
+	var counts []struct {
+		TargetUserID int64
+		Count        int64
+	}
+	err := r.db.WithContext(ctx).Table("like").
+		Select("target_user_id", "COUNT(*) AS count").
+		Where("target_user_id IN ? AND direction = ? AND reaction = '' AND canceled = 0", userIDs, likeentity.LikeUp).
+		Group("target_user_id").
+		Find(&counts).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	for _, c := range counts {
+		likes[c.TargetUserID] = c.Count
+	}
+	return likes, nil
+}
+
+// cachedCounts 只读取缓存，不从数据库加载
+func (r *LikeRepo) cachedCounts(ctx context.Context, prefix string, likingIDs []int64) (map[int64]int64, error) {
+	if prefix == "" {
+		return map[int64]int64{}, nil
+	}
+
+	counts, _, err := counter.Get(ctx, r.rdb, prefix, likingIDs)
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return counts, nil
+}
+
+func (r *LikeRepo) CachedCounts(ctx context.Context, likeType likeentity.LikeType, likingIDs []int64) (map[int64]int64, map[int64]int64, error) {
+	likes, err := r.cachedCounts(ctx, getCountPrefix(likeType, likeentity.LikeUp), likingIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if likeType == likeentity.LikeUser {
+		return likes, map[int64]int64{}, nil
+	}
+	dislikes, err := r.cachedCounts(ctx, getCountPrefix(likeType, likeentity.LikeDown), likingIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return likes, dislikes, nil
+}
+
+func (r *LikeRepo) ResetCounts(ctx context.Context, likeType likeentity.LikeType, likes, dislikes []counter.Swap) error {
+	for direction, swaps := range map[likeentity.LikeDirection][]counter.Swap{
+		likeentity.LikeUp:   likes,
+		likeentity.LikeDown: dislikes,
+	} {
+		prefix := getCountPrefix(likeType, direction)
+		if prefix == "" || len(swaps) == 0 || (likeType == likeentity.LikeUser && direction == likeentity.LikeDown) {
+			continue
+		}
+		_, err := counter.CompareAndSet(ctx, r.rdb, prefix, swaps)
+		if err != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package like
+
+import (
+	"context"
+	likeentity "harmoni/app/harmoni/internal/entity/like"
+	postreltagentity "harmoni/app/harmoni/internal/entity/post_rel_tag"
+	"harmoni/app/harmoni/internal/pkg/reason"
+	"harmoni/internal/pkg/errorx"
+
+	"gorm.io/gorm"
+)
+
+// likedPostTagsScope 有效的帖子赞关联到帖子的话题，表情回应也算作赞
+func likedPostTagsScope(db *gorm.DB) *gorm.DB {
+	return db.Table("`like`").
+		Joins("JOIN "+postreltagentity.TableName+" AS pt ON pt.post_id = `like`.liking_id AND pt.deleted_at IS NULL").
+		Where("`like`.like_type = ? AND `like`.canceled = 0 AND `like`.direction = ?", likeentity.LikePost, likeentity.LikeUp)
+}
+
+func (r *LikeRepo) BatchListLikedTagIDs(ctx context.Context, userIDs []int64, limit int) (map[int64][]int64, error) {
+	tagIDs := make(map[int64][]int64, len(userIDs))
+	if len(userIDs) == 0 {
+		return tagIDs, nil
+	}
+
+	ranked := r.db.
+		Scopes(likedPostTagsScope).
+		Select("`like`.user_id", "pt.tag_id",
+			"ROW_NUMBER() OVER (PARTITION BY `like`.user_id ORDER BY COUNT(DISTINCT `like`.liking_id) DESC) AS rn").
+		Where("`like`.user_id IN ?", userIDs).
+		Group("`like`.user_id, pt.tag_id")
+	var tags []struct {
+		UserID int64
+		TagID  int64
+	}
+	err := r.db.WithContext(ctx).Table("(?) AS ranked", ranked).
+		Select("user_id", "tag_id").
+		Where("rn <= ?", limit).
+		Order("user_id, rn").
+		Find(&tags).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	for _, t := range tags {
+		tagIDs[t.UserID] = append(tagIDs[t.UserID], t.TagID)
+	}
+	return tagIDs, nil
+}
+
+func (r *LikeRepo) ListTopLikersByTags(ctx context.Context, tagIDs []int64, limit int) (map[int64][]int64, error) {
+	likers := make(map[int64][]int64, len(tagIDs))
+	if len(tagIDs) == 0 {
+		return likers, nil
+	}
+
+	ranked := r.db.
+		Scopes(likedPostTagsScope).
+		Select("pt.tag_id", "`like`.user_id",
+			"ROW_NUMBER() OVER (PARTITION BY pt.tag_id ORDER BY COUNT(DISTINCT `like`.liking_id) DESC) AS rn").
+		Where("pt.tag_id IN ?", tagIDs).
+		Group("pt.tag_id, `like`.user_id")
+	var tagLikers []struct {
+		TagID  int64
+		UserID int64
+	}
+	err := r.db.WithContext(ctx).Table("(?) AS ranked", ranked).
+		Select("tag_id", "user_id").
+		Where("rn <= ?", limit).
+		Find(&tagLikers).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	for _, l := range tagLikers {
+		likers[l.TagID] = append(likers[l.TagID], l.UserID)
+	}
+	return likers, nil
+}
@@ -0,0 +1,220 @@
+package post
+
+import (
+	"context"
+	"fmt"
+	"harmoni/app/harmoni/internal/entity/paginator"
+	postentity "harmoni/app/harmoni/internal/entity/post"
+	"harmoni/app/harmoni/internal/pkg/reason"
+	"harmoni/internal/pkg/errorx"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// keyHotRank 全站热榜，member 是帖子ID，score 是热度分
+	keyHotRank = "post:hot"
+	// keyHotPoints 帖子的互动分值，field 是帖子ID
+	keyHotPoints = "post:hot.points"
+)
+
+func getTagHotRankKey(tagID int64) string {
+	return fmt.Sprintf("tag:%d:post.hot", tagID)
+}
+
+// getHotTagsKey 帖子所在的话题热榜，话题修改后用于从旧话题的热榜中移除
+func getHotTagsKey(postID int64) string {
+	return fmt.Sprintf("post:%d:hot.tags", postID)
+}
+
+func getHotRankKey(tagID int64) string {
+	if tagID == 0 {
+		return keyHotRank
+	}
+	return getTagHotRankKey(tagID)
+}
+
+func (r *PostRepo) IncrHotPoints(ctx context.Context, postID int64, delta float64) (float64, error) {
+	points, err := r.rdb.HIncrByFloat(ctx, keyHotPoints, strconv.FormatInt(postID, 10), delta).Result()
+	if err != nil {
+		return 0, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return points, nil
+}
+
+func (r *PostRepo) SetHotPoints(ctx context.Context, postID int64, points float64) error {
+	err := r.rdb.HSet(ctx, keyHotPoints, strconv.FormatInt(postID, 10), points).Err()
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return nil
+}
+
+func (r *PostRepo) UpdateHotRank(ctx context.Context, postID int64, score float64, size int) error {
+	tags, err := r.tagRepo.GetTagsByPostID(ctx, postID)
+	if err != nil {
+		return err
+	}
+	oldTags, err := r.rdb.SMembers(ctx, getHotTagsKey(postID)).Result()
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	member := strconv.FormatInt(postID, 10)
+	current := make(map[string]bool, len(tags))
+	keys := []string{keyHotRank}
+	tagIDs := make([]interface{}, len(tags))
+	for i, tag := range tags {
+		current[strconv.FormatInt(tag.TagID, 10)] = true
+		keys = append(keys, getTagHotRankKey(tag.TagID))
+		tagIDs[i] = tag.TagID
+	}
+
+	pipe := r.rdb.TxPipeline()
+	for _, key := range keys {
+		pipe.ZAdd(ctx, key, redis.Z{Score: score, Member: member})
+		// keep only the top entries, older posts fall out of the ranking
+		pipe.ZRemRangeByRank(ctx, key, 0, int64(-size-1))
+	}
+	for _, tag := range oldTags {
+		if current[tag] {
+			continue
+		}
+		tagID, err := strconv.ParseInt(tag, 10, 64)
+		if err != nil {
+			continue
+		}
+		pipe.ZRem(ctx, getTagHotRankKey(tagID), member)
+	}
+	pipe.Del(ctx, getHotTagsKey(postID))
+	if len(tagIDs) != 0 {
+		pipe.SAdd(ctx, getHotTagsKey(postID), tagIDs...)
+	}
+	_, err = pipe.Exec(ctx)
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return nil
+}
+
+func (r *PostRepo) RemoveHotRank(ctx context.Context, postID int64) error {
+	tags, err := r.rdb.SMembers(ctx, getHotTagsKey(postID)).Result()
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	member := strconv.FormatInt(postID, 10)
+	pipe := r.rdb.TxPipeline()
+	pipe.ZRem(ctx, keyHotRank, member)
+	for _, tag := range tags {
+		tagID, err := strconv.ParseInt(tag, 10, 64)
+		if err != nil {
+			continue
+		}
+		pipe.ZRem(ctx, getTagHotRankKey(tagID), member)
+	}
+	pipe.Del(ctx, getHotTagsKey(postID))
+	_, err = pipe.Exec(ctx)
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return nil
+}
+
+// removeHotPoints 帖子彻底删除后清除互动分值
+func (r *PostRepo) removeHotPoints(ctx context.Context, postIDs []int64) error {
+	if len(postIDs) == 0 {
+		return nil
+	}
+
+	fields := make([]string, len(postIDs))
+	for i, postID := range postIDs {
+		fields[i] = strconv.FormatInt(postID, 10)
+	}
+	err := r.rdb.HDel(ctx, keyHotPoints, fields...).Err()
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return nil
+}
+
+// getHotPage 从热榜中分页读取帖子，保持热榜中的顺序
+func (r *PostRepo) getHotPage(ctx context.Context, queryCond *postentity.PostQuery) (paginator.Page[postentity.Post], error) {
+	postPage := paginator.Page[postentity.Post]{CurrentPage: queryCond.Page, PageSize: queryCond.PageSize}
+	if postPage.CurrentPage <= 0 {
+		postPage.CurrentPage = 1
+	}
+	if postPage.PageSize <= 0 {
+		postPage.PageSize = 10
+	}
+
+	key := getHotRankKey(queryCond.TagID)
+	total, err := r.rdb.ZCard(ctx, key).Result()
+	if err != nil {
+		return paginator.Page[postentity.Post]{}, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	postPage.Total = total
+	postPage.Pages = (total + postPage.PageSize - 1) / postPage.PageSize
+
+	start := (postPage.CurrentPage - 1) * postPage.PageSize
+	members, err := r.rdb.ZRevRange(ctx, key, start, start+postPage.PageSize-1).Result()
+	if err != nil {
+		return paginator.Page[postentity.Post]{}, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if len(members) == 0 {
+		postPage.Data = []postentity.Post{}
+		return postPage, nil
+	}
+
+	postIDs := make([]int64, 0, len(members))
+	for _, member := range members {
+		postID, err := strconv.ParseInt(member, 10, 64)
+		if err != nil {
+			continue
+		}
+		postIDs = append(postIDs, postID)
+	}
+
+	postPage.Data, err = r.GetByPostIDs(ctx, postIDs)
+	if err != nil {
+		return paginator.Page[postentity.Post]{}, err
+	}
+	if queryCond.HidePrivate {
+		// the rank is shared by all viewers, hidden posts are dropped from the page instead
+		postPage.Data, err = r.excludeHidden(ctx, queryCond.UserID, postPage.Data)
+		if err != nil {
+			return paginator.Page[postentity.Post]{}, err
+		}
+	}
+
+	return postPage, nil
+}
+
+func (r *PostRepo) excludeHidden(ctx context.Context, viewerID int64, posts []postentity.Post) ([]postentity.Post, error) {
+	authorIDs := make([]int64, len(posts))
+	for i, post := range posts {
+		authorIDs[i] = post.AuthorID
+	}
+	hidden, err := r.ListHiddenAuthors(ctx, viewerID, authorIDs)
+	if err != nil || len(hidden) == 0 {
+		return posts, err
+	}
+
+	set := make(map[int64]bool, len(hidden))
+	for _, authorID := range hidden {
+		set[authorID] = true
+	}
+	shown := make([]postentity.Post, 0, len(posts))
+	for _, post := range posts {
+		if !set[post.AuthorID] {
+			shown = append(shown, post)
+		}
+	}
+	return shown, nil
+}
@@ -2,6 +2,9 @@ package post
 
 import (
 	"context"
+	"encoding/json"
+	"harmoni/app/harmoni/internal/entity"
+	followentity "harmoni/app/harmoni/internal/entity/follow"
 	"harmoni/app/harmoni/internal/entity/paginator"
 	postentity "harmoni/app/harmoni/internal/entity/post"
 	postreltagentity "harmoni/app/harmoni/internal/entity/post_rel_tag"
@@ -10,10 +13,12 @@ import (
 	"harmoni/app/harmoni/internal/pkg/reason"
 	"harmoni/internal/pkg/errorx"
 	"html"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var _ postentity.PostRepository = (*PostRepo)(nil)
@@ -41,6 +46,12 @@ func NewPostRepo(
 	}
 }
 
+// mentionIDsValue map 形式的更新不经过字段的 json serializer，需要手动序列化
+func mentionIDsValue(mentionIDs []int64) string {
+	data, _ := json.Marshal(mentionIDs)
+	return string(data)
+}
+
 func (r *PostRepo) Create(ctx context.Context, post *postentity.Post) (err error) {
 	post.PostID, err = r.uniqueIDRepo.GenUniqueID(ctx)
 	if err != nil {
@@ -50,6 +61,14 @@ func (r *PostRepo) Create(ctx context.Context, post *postentity.Post) (err error
 	post.Title = html.EscapeString(post.Title)
 	post.Content = html.EscapeString(post.Content)
 
+	// drafts have no revision until they are published
+	if post.IsPublished() {
+		now := time.Now()
+		post.Revision = 1
+		post.CreatedAt = now
+		post.RepliedAt = &now
+	}
+
 	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		err := tx.Create(&post).Error
 		if err != nil {
@@ -62,7 +81,10 @@ func (r *PostRepo) Create(ctx context.Context, post *postentity.Post) (err error
 			return err
 		}
 
-		return nil
+		if !post.IsPublished() {
+			return nil
+		}
+		return r.createRevision(ctx, tx, post, &postentity.PostRevision{EditorID: post.AuthorID})
 	})
 	if err != nil {
 		return err
@@ -71,25 +93,343 @@ func (r *PostRepo) Create(ctx context.Context, post *postentity.Post) (err error
 	return nil
 }
 
-func (r *PostRepo) Delete(ctx context.Context, postID int64) error {
+func (r *PostRepo) Update(ctx context.Context, post *postentity.Post, revision *postentity.PostRevision) error {
+	post.Title = html.EscapeString(post.Title)
+	post.Content = html.EscapeString(post.Content)
+
 	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		err := tx.Where("post_id = ?", postID).
-			Delete(&postentity.Post{}).Error
+		current := postentity.Post{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("post_id = ?", post.PostID).
+			First(&current).Error
 		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errorx.NotFound(reason.PostNotFound)
+			}
 			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 		}
 
-		err = r.removeAllTagsFromPost(ctx, tx, postID)
+		// posts created before revisions existed have no history,
+		// keep their original state as the first revision
+		if current.Revision == 0 {
+			tags, err := r.tagRepo.GetTagsByPostID(ctx, current.PostID)
+			if err != nil {
+				return err
+			}
+			current.TagIDs = make([]int64, len(tags))
+			for i := range tags {
+				current.TagIDs[i] = tags[i].TagID
+			}
+			current.Revision = 1
+			err = r.createRevision(ctx, tx, &current, &postentity.PostRevision{
+				BaseModelWithNoSoftDelete: entity.BaseModelWithNoSoftDelete{
+					TimeMixin: entity.TimeMixin{
+						CreatedAt: current.CreatedAt,
+					},
+				},
+				EditorID: current.AuthorID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		post.Revision = current.Revision + 1
+		err = tx.Model(&postentity.Post{}).
+			Where("post_id = ?", post.PostID).
+			Updates(map[string]interface{}{
+				"title":       post.Title,
+				"content":     post.Content,
+				"mention_ids": mentionIDsValue(post.MentionIDs),
+				"revision":    post.Revision,
+			}).Error
+		if err != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+
+		err = r.replaceTags(ctx, tx, post.PostID, post.TagIDs)
 		if err != nil {
 			return err
 		}
 
-		return nil
+		return r.createRevision(ctx, tx, post, revision)
 	})
 
 	return err
 }
 
+func (r *PostRepo) SaveDraft(ctx context.Context, post *postentity.Post) error {
+	post.Title = html.EscapeString(post.Title)
+	post.Content = html.EscapeString(post.Content)
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&postentity.Post{}).
+			Where("post_id = ?", post.PostID).
+			Updates(map[string]interface{}{
+				"title":       post.Title,
+				"content":     post.Content,
+				"mention_ids": mentionIDsValue(post.MentionIDs),
+			}).Error
+		if err != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+
+		// autosaves usually keep the tags, only the changed ones are touched
+		return r.replaceTags(ctx, tx, post.PostID, post.TagIDs)
+	})
+
+	return err
+}
+
+func (r *PostRepo) Publish(ctx context.Context, postID int64, publishedAt time.Time) (bool, error) {
+	published := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		current := postentity.Post{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("post_id = ?", postID).
+			First(&current).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil
+			}
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		} else if current.IsPublished() {
+			return nil
+		}
+
+		// the post is shown in feeds by its publish time rather than the time the draft was started
+		current.Revision = 1
+		err = tx.Model(&postentity.Post{}).
+			Where("post_id = ?", postID).
+			Updates(map[string]interface{}{
+				"status":       postentity.PostStatusPublished,
+				"scheduled_at": nil,
+				"revision":     current.Revision,
+				"created_at":   publishedAt,
+				"replied_at":   publishedAt,
+			}).Error
+		if err != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+
+		tags, err := r.tagRepo.GetTagsByPostID(ctx, postID)
+		if err != nil {
+			return err
+		}
+		current.TagIDs = make([]int64, len(tags))
+		for i := range tags {
+			current.TagIDs[i] = tags[i].TagID
+		}
+		err = r.createRevision(ctx, tx, &current, &postentity.PostRevision{EditorID: current.AuthorID})
+		if err != nil {
+			return err
+		}
+
+		published = true
+		return nil
+	})
+
+	return published, err
+}
+
+func (r *PostRepo) Schedule(ctx context.Context, postID int64, scheduledAt time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&postentity.Post{}).
+		Where("post_id = ? AND status <> ?", postID, postentity.PostStatusPublished).
+		Updates(map[string]interface{}{
+			"status":       postentity.PostStatusScheduled,
+			"scheduled_at": scheduledAt,
+		}).Error
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return nil
+}
+
+func (r *PostRepo) GetDraftPage(ctx context.Context, authorID int64, pageCond *entity.PageCond) (paginator.Page[postentity.Post], error) {
+	postPage := paginator.Page[postentity.Post]{CurrentPage: pageCond.Page, PageSize: pageCond.PageSize}
+	db := r.db.WithContext(ctx).
+		Where("author_id = ? AND status <> ?", authorID, postentity.PostStatusPublished).
+		Order("updated_at DESC")
+	err := postPage.SelectPages(db)
+	if err != nil {
+		return paginator.Page[postentity.Post]{}, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return postPage, nil
+}
+
+func (r *PostRepo) ListScheduledBefore(ctx context.Context, before time.Time, limit int) ([]postentity.Post, error) {
+	posts := []postentity.Post{}
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND scheduled_at <= ?", postentity.PostStatusScheduled, before).
+		Order("scheduled_at").
+		Limit(limit).
+		Find(&posts).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return posts, nil
+}
+
+func (r *PostRepo) createRevision(ctx context.Context, tx *gorm.DB, post *postentity.Post, revision *postentity.PostRevision) (err error) {
+	revision.RevisionID, err = r.uniqueIDRepo.GenUniqueID(ctx)
+	if err != nil {
+		return err
+	}
+
+	revision.PostID = post.PostID
+	revision.Revision = post.Revision
+	revision.Title = post.Title
+	revision.Content = post.Content
+	revision.TagIDs = post.TagIDs
+	if revision.TagIDs == nil {
+		revision.TagIDs = []int64{}
+	}
+
+	err = tx.Create(revision).Error
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return nil
+}
+
+func (r *PostRepo) GetRevisionPage(ctx context.Context, postID int64, pageCond *entity.PageCond) (paginator.Page[postentity.PostRevision], error) {
+	revisionPage := paginator.Page[postentity.PostRevision]{CurrentPage: pageCond.Page, PageSize: pageCond.PageSize}
+	db := r.db.WithContext(ctx).Where("post_id = ?", postID).Order("revision DESC")
+	err := revisionPage.SelectPages(db)
+	if err != nil {
+		return paginator.Page[postentity.PostRevision]{}, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return revisionPage, nil
+}
+
+func (r *PostRepo) GetRevision(ctx context.Context, postID int64, revision int64) (*postentity.PostRevision, bool, error) {
+	postRevision := &postentity.PostRevision{}
+	err := r.db.WithContext(ctx).
+		Where("post_id = ? AND revision = ?", postID, revision).
+		First(postRevision).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, false, nil
+		}
+		return nil, false, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return postRevision, true, nil
+}
+
+// Delete 软删除帖子，帖子进入回收站，话题关联保留以便恢复
+func (r *PostRepo) Delete(ctx context.Context, postID int64, operatorID int64) error {
+	err := r.db.WithContext(ctx).
+		Model(&postentity.Post{}).
+		Where("post_id = ?", postID).
+		Updates(map[string]interface{}{
+			"deleted_at": time.Now(),
+			"deleted_by": operatorID,
+		}).Error
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return nil
+}
+
+func (r *PostRepo) Restore(ctx context.Context, postID int64) error {
+	err := r.db.WithContext(ctx).Unscoped().
+		Model(&postentity.Post{}).
+		Where("post_id = ?", postID).
+		Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"deleted_by": 0,
+		}).Error
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return nil
+}
+
+func (r *PostRepo) GetTrashedByPostID(ctx context.Context, postID int64) (*postentity.Post, bool, error) {
+	post := &postentity.Post{}
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("post_id = ? AND deleted_at IS NOT NULL", postID).
+		First(post).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, false, nil
+		}
+		return nil, false, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return post, true, nil
+}
+
+func (r *PostRepo) GetTrashPage(ctx context.Context, authorID int64, pageCond *entity.PageCond) (paginator.Page[postentity.Post], error) {
+	postPage := paginator.Page[postentity.Post]{CurrentPage: pageCond.Page, PageSize: pageCond.PageSize}
+	db := r.db.WithContext(ctx).Unscoped().
+		Where("author_id = ? AND deleted_at IS NOT NULL", authorID).
+		Order("deleted_at DESC")
+	err := postPage.SelectPages(db)
+	if err != nil {
+		return paginator.Page[postentity.Post]{}, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return postPage, nil
+}
+
+func (r *PostRepo) ListTrashedBefore(ctx context.Context, before time.Time, limit int) ([]postentity.Post, error) {
+	posts := []postentity.Post{}
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("deleted_at").
+		Limit(limit).
+		Find(&posts).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return posts, nil
+}
+
+// Purge 彻底删除帖子及其话题关联和编辑记录
+func (r *PostRepo) Purge(ctx context.Context, postIDs []int64) error {
+	if len(postIDs) == 0 {
+		return nil
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Where("post_id IN ?", postIDs).Delete(&postentity.Post{}).Error
+		if err != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+
+		err = tx.Where("post_id IN ?", postIDs).Delete(&postentity.PostRevision{}).Error
+		if err != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+
+		// post_tags rows are soft deleted elsewhere, purge them for good together with the post
+		err = tx.Unscoped().Table(postreltagentity.TableName).
+			Where("post_id IN ?", postIDs).
+			Delete(&postreltagentity.PostTag{}).Error
+		if err != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.removeHotPoints(ctx, postIDs)
+}
+
 func (r *PostRepo) GetBasicInfoByPostID(ctx context.Context, postID int64) (*postentity.Post, bool, error) {
 	post := &postentity.Post{}
 	err := r.db.WithContext(ctx).Where("post_id = ?", postID).First(post).Error
@@ -114,7 +454,9 @@ func (r *PostRepo) GetByPostID(ctx context.Context, postID int64) (*postentity.P
 
 func (r *PostRepo) GetByUserID(ctx context.Context, userID int64, queryCond *postentity.PostQuery) (paginator.Page[postentity.Post], error) {
 	postPage := paginator.Page[postentity.Post]{CurrentPage: queryCond.Page, PageSize: queryCond.PageSize}
-	db := r.db.WithContext(ctx).Where("author_id = ?", userID).Order("created_at DESC")
+	db := r.db.WithContext(ctx).
+		Where("author_id = ? AND status = ?", userID, postentity.PostStatusPublished).
+		Order("created_at DESC")
 	err := postPage.SelectPages(db)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -128,7 +470,9 @@ func (r *PostRepo) GetByUserID(ctx context.Context, userID int64, queryCond *pos
 
 func (r *PostRepo) GetByUserIDs(ctx context.Context, userIDs []int64, queryCond *postentity.PostQuery) (paginator.Page[postentity.Post], error) {
 	postPage := paginator.Page[postentity.Post]{CurrentPage: queryCond.Page, PageSize: queryCond.PageSize}
-	db := r.db.WithContext(ctx).Where("author_id IN ?", userIDs).Order("created_at DESC")
+	db := r.db.WithContext(ctx).
+		Where("author_id IN ? AND status = ?", userIDs, postentity.PostStatusPublished).
+		Order("created_at DESC")
 	err := postPage.SelectPages(db)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -154,6 +498,32 @@ func (r *PostRepo) BatchByIDs(ctx context.Context, postIDs []int64) ([]postentit
 	return posts, nil
 }
 
+func (r *PostRepo) GetByPostIDs(ctx context.Context, postIDs []int64) ([]postentity.Post, error) {
+	if len(postIDs) == 0 {
+		return []postentity.Post{}, nil
+	}
+
+	found := []postentity.Post{}
+	err := r.db.WithContext(ctx).
+		Where("post_id IN ? AND status = ?", postIDs, postentity.PostStatusPublished).
+		Find(&found).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	byID := make(map[int64]postentity.Post, len(found))
+	for _, post := range found {
+		byID[post.PostID] = post
+	}
+	posts := make([]postentity.Post, 0, len(found))
+	for _, postID := range postIDs {
+		if post, ok := byID[postID]; ok {
+			posts = append(posts, post)
+		}
+	}
+	return posts, nil
+}
+
 func (r *PostRepo) GetLikeCount(ctx context.Context, postID int64) (int64, bool, error) {
 	var count int64
 	if err := r.db.WithContext(ctx).
@@ -180,24 +550,103 @@ func (r *PostRepo) UpdateLikeCount(ctx context.Context, postID int64, count int6
 	return nil
 }
 
+func (r *PostRepo) UpdateDislikeCount(ctx context.Context, postID int64, count int64) error {
+	if err := r.db.WithContext(ctx).
+		Table("post").
+		Where("post_id = ?", postID).
+		Update("dislike_count", count).Error; err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return nil
+}
+
+func (r *PostRepo) UpdateCollectCount(ctx context.Context, postID int64, count int64) error {
+	if err := r.db.WithContext(ctx).
+		Table("post").
+		Where("post_id = ?", postID).
+		Update("collect_count", count).Error; err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return nil
+}
+
+func (r *PostRepo) UpdateCommentCount(ctx context.Context, postID int64, count int64) error {
+	if err := r.db.WithContext(ctx).
+		Table("post").
+		Where("post_id = ?", postID).
+		Update("comment_count", count).Error; err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return nil
+}
+
+// hiddenAuthors 和 User.VisibleTo 相同的规则：viewerID 既不是作者本人也没有关注的私密账号
+func (r *PostRepo) hiddenAuthors(viewerID int64) *gorm.DB {
+	followings := r.db.Table("follow").
+		Select("following_id").
+		Where("follower_id = ? AND followed_type = ? AND deleted_at IS NULL", viewerID, followentity.FollowUser)
+	return r.db.Table("`user`").
+		Select("user_id").
+		Where("private = ? AND user_id <> ? AND deleted_at IS NULL", true, viewerID).
+		Where("user_id NOT IN (?)", followings)
+}
+
+func (r *PostRepo) ListHiddenAuthors(ctx context.Context, viewerID int64, authorIDs []int64) ([]int64, error) {
+	hidden := []int64{}
+	if len(authorIDs) == 0 {
+		return hidden, nil
+	}
+
+	err := r.hiddenAuthors(viewerID).WithContext(ctx).
+		Where("user_id IN ?", authorIDs).
+		Pluck("user_id", &hidden).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return hidden, nil
+}
+
 func (r *PostRepo) GetPage(ctx context.Context, queryCond *postentity.PostQuery) (paginator.Page[postentity.Post], error) {
-	db := r.db.WithContext(ctx)
-	if len(queryCond.AuthorIDs) != 0 {
+	db := r.db.WithContext(ctx).Where("post.status = ?", postentity.PostStatusPublished)
+	if len(queryCond.ExcludeAuthorIDs) != 0 {
+		db = db.Where("post.author_id NOT IN ?", queryCond.ExcludeAuthorIDs)
+	}
+	if queryCond.HidePrivate {
+		db = db.Where("post.author_id NOT IN (?)", r.hiddenAuthors(queryCond.UserID))
+	}
+	if len(queryCond.TagIDs) != 0 {
+		// a subquery rather than a join, posts with several followed tags are listed once
+		tagged := r.db.Table(postreltagentity.TableName).
+			Select("post_id").
+			Where("tag_id IN ? AND deleted_at IS NULL", queryCond.TagIDs)
+		cond := r.db.Where("post.post_id IN (?)", tagged)
+		if len(queryCond.AuthorIDs) != 0 {
+			cond = cond.Or("author_id IN ?", queryCond.AuthorIDs)
+		}
+		db = db.Where(cond).Order("created_at DESC")
+	} else if len(queryCond.AuthorIDs) != 0 {
 		if len(queryCond.AuthorIDs) == 1 {
-			db = db.Where("author_id in ?", queryCond.AuthorIDs)
-		} else {
 			db = db.Where("author_id = ?", queryCond.AuthorIDs[0])
+		} else {
+			db = db.Where("author_id IN ?", queryCond.AuthorIDs)
 		}
 		db = db.Order("created_at DESC")
 	} else {
 		if queryCond.TagID != 0 {
-			db = db.Joins("INNER JOIN post_tags AS pt ON pt.post_id = post.post_id").Where("pt.tag_id = ?", queryCond.TagID)
+			db = db.Joins("INNER JOIN post_tags AS pt ON pt.post_id = post.post_id AND pt.deleted_at IS NULL").Where("pt.tag_id = ?", queryCond.TagID)
 		}
 		switch queryCond.QueryCond {
 		case postentity.PostOrderByCreatedTime:
 			db = db.Order("created_at DESC")
+		case postentity.PostOrderByReplyTime:
+			db = db.Order("replied_at DESC").Order("post.created_at DESC")
 		case postentity.PostOrderByLike:
-			db = db.Order("like_count DESC")
+			db = db.Order("like_count DESC").Order("post.created_at DESC")
+		case postentity.PostOrderByHot:
+			return r.getHotPage(ctx, queryCond)
 		default:
 			db = db.Order("created_at DESC")
 		}
@@ -212,18 +661,109 @@ func (r *PostRepo) GetPage(ctx context.Context, queryCond *postentity.PostQuery)
 	return postPage, nil
 }
 
+func (r *PostRepo) ListPublishedAfter(ctx context.Context, afterPostID int64, limit int) ([]postentity.Post, error) {
+	posts := []postentity.Post{}
+	err := r.db.WithContext(ctx).
+		Where("post_id > ? AND status = ?", afterPostID, postentity.PostStatusPublished).
+		Order("post_id").
+		Limit(limit).
+		Find(&posts).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return posts, nil
+}
+
+func (r *PostRepo) ListRefsBefore(ctx context.Context, queryCond *postentity.PostQuery, cursor postentity.PostRef, limit int) ([]postentity.PostRef, error) {
+	refs := []postentity.PostRef{}
+	if len(queryCond.AuthorIDs) == 0 && len(queryCond.TagIDs) == 0 {
+		return refs, nil
+	}
+
+	var cond *gorm.DB
+	if len(queryCond.AuthorIDs) != 0 {
+		cond = r.db.Where("author_id IN ?", queryCond.AuthorIDs)
+	}
+	if len(queryCond.TagIDs) != 0 {
+		tagged := r.db.Table(postreltagentity.TableName).
+			Select("post_id").
+			Where("tag_id IN ? AND deleted_at IS NULL", queryCond.TagIDs)
+		if cond == nil {
+			cond = r.db.Where("post.post_id IN (?)", tagged)
+		} else {
+			cond = cond.Or("post.post_id IN (?)", tagged)
+		}
+	}
+
+	db := r.db.WithContext(ctx).Model(&postentity.Post{}).
+		Where("post.status = ?", postentity.PostStatusPublished).
+		Where(cond)
+	if !cursor.IsZero() {
+		db = db.Where("post.created_at < ? OR (post.created_at = ? AND post.post_id < ?)",
+			cursor.CreatedAt, cursor.CreatedAt, cursor.PostID)
+	}
+	if len(queryCond.ExcludeAuthorIDs) != 0 {
+		db = db.Where("post.author_id NOT IN ?", queryCond.ExcludeAuthorIDs)
+	}
+	if queryCond.HidePrivate {
+		db = db.Where("post.author_id NOT IN (?)", r.hiddenAuthors(queryCond.UserID))
+	}
+	err := db.Select("post.post_id, post.created_at").
+		Order("post.created_at DESC, post.post_id DESC").
+		Limit(limit).
+		Scan(&refs).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return refs, nil
+}
+
+func (r *PostRepo) CountByAuthorsSince(ctx context.Context, since time.Time) (map[int64]int64, error) {
+	var counts []struct {
+		AuthorID int64
+		Count    int64
+	}
+	err := r.db.WithContext(ctx).Model(&postentity.Post{}).
+		Select("author_id", "COUNT(*) AS count").
+		Where("status = ? AND created_at >= ?", postentity.PostStatusPublished, since).
+		Group("author_id").
+		Find(&counts).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	posts := make(map[int64]int64, len(counts))
+	for _, c := range counts {
+		posts[c.AuthorID] = c.Count
+	}
+	return posts, nil
+}
+
+func (r *PostRepo) UpdateRepliedAt(ctx context.Context, postID int64, repliedAt time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&postentity.Post{}).
+		Where("post_id = ? AND (replied_at IS NULL OR replied_at < ?)", postID, repliedAt).
+		Update("replied_at", repliedAt).Error
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return nil
+}
+
 func (r *PostRepo) associateTags(ctx context.Context, tx *gorm.DB, postID int64, tagIDs []int64) error {
 	if len(tagIDs) == 0 {
 		return nil
 	}
 
 	prt := make([]postreltagentity.PostTag, len(tagIDs))
-	prtID, err := r.uniqueIDRepo.GenUniqueID(ctx)
-	if err != nil {
-		return err
-	}
-
 	for i, tagID := range tagIDs {
+		prtID, err := r.uniqueIDRepo.GenUniqueID(ctx)
+		if err != nil {
+			return err
+		}
 		prt[i] = postreltagentity.PostTag{
 			PostTagID: prtID,
 			PostID:    postID,
@@ -231,7 +771,7 @@ func (r *PostRepo) associateTags(ctx context.Context, tx *gorm.DB, postID int64,
 		}
 	}
 
-	err = tx.Create(&prt).Error
+	err := tx.Create(&prt).Error
 	if err != nil {
 		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
@@ -239,6 +779,27 @@ func (r *PostRepo) associateTags(ctx context.Context, tx *gorm.DB, postID int64,
 	return nil
 }
 
+// replaceTags 只软删除去掉的话题并关联新增的话题，没有变化的关联保持不变
+func (r *PostRepo) replaceTags(ctx context.Context, tx *gorm.DB, postID int64, tagIDs []int64) error {
+	current := []int64{}
+	err := tx.Model(&postreltagentity.PostTag{}).
+		Where("post_id = ?", postID).
+		Pluck("tag_id", &current).Error
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	added, removed := postentity.DiffTagIDs(current, tagIDs)
+	if len(removed) != 0 {
+		err = tx.Where("post_id = ? AND tag_id IN ?", postID, removed).
+			Delete(&postreltagentity.PostTag{}).Error
+		if err != nil {
+			return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+	}
+	return r.associateTags(ctx, tx, postID, added)
+}
+
 func (r *PostRepo) GetPostsByTagID(ctx context.Context, tagID int64) ([]postentity.Post, error) {
 	posts := []postentity.Post{}
 	err := r.db.Table(postreltagentity.TableName).
@@ -261,14 +822,3 @@ func (r *PostRepo) GetPostsByTagID(ctx context.Context, tagID int64) ([]postenti
 
 	return nil
 } */
-
-func (r *PostRepo) removeAllTagsFromPost(ctx context.Context, tx *gorm.DB, postID int64) error {
-	err := r.db.Table(postreltagentity.TableName).
-		Where("post_id = ?", postID).
-		Delete(&postreltagentity.PostTag{}).Error
-	if err != nil {
-		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
-	}
-
-	return nil
-}
@@ -2,6 +2,8 @@ package repository
 
 import (
 	authentity "harmoni/app/harmoni/internal/entity/auth"
+	blockentity "harmoni/app/harmoni/internal/entity/block"
+	collectionentity "harmoni/app/harmoni/internal/entity/collection"
 	commententity "harmoni/app/harmoni/internal/entity/comment"
 	eamilentity "harmoni/app/harmoni/internal/entity/email"
 	fileentity "harmoni/app/harmoni/internal/entity/file"
@@ -9,10 +11,13 @@ import (
 	likeentity "harmoni/app/harmoni/internal/entity/like"
 	postentity "harmoni/app/harmoni/internal/entity/post"
 	tagentity "harmoni/app/harmoni/internal/entity/tag"
+	timelineentity "harmoni/app/harmoni/internal/entity/timeline"
 	uniqueentity "harmoni/app/harmoni/internal/entity/unique"
 	userentity "harmoni/app/harmoni/internal/entity/user"
 
 	"harmoni/app/harmoni/internal/repository/auth"
+	"harmoni/app/harmoni/internal/repository/block"
+	"harmoni/app/harmoni/internal/repository/collection"
 	"harmoni/app/harmoni/internal/repository/comment"
 	"harmoni/app/harmoni/internal/repository/email"
 	"harmoni/app/harmoni/internal/repository/file"
@@ -20,6 +25,7 @@ import (
 	"harmoni/app/harmoni/internal/repository/like"
 	"harmoni/app/harmoni/internal/repository/post"
 	"harmoni/app/harmoni/internal/repository/tag"
+	"harmoni/app/harmoni/internal/repository/timeline"
 	"harmoni/app/harmoni/internal/repository/unique"
 	"harmoni/app/harmoni/internal/repository/user"
 
@@ -33,11 +39,15 @@ var ProviderSetRepo = wire.NewSet(
 	wire.Bind(new(commententity.CommentRepository), new(*comment.CommentRepo)),
 	wire.Bind(new(eamilentity.EmailRepo), new(*email.EmailRepo)),
 	wire.Bind(new(followentity.FollowRepository), new(*follow.FollowRepo)),
+	wire.Bind(new(followentity.SuggestionRepository), new(*follow.SuggestionRepo)),
 	wire.Bind(new(postentity.PostRepository), new(*post.PostRepo)),
 	wire.Bind(new(tagentity.TagRepository), new(*tag.TagRepo)),
 	wire.Bind(new(uniqueentity.UniqueIDRepo), new(*unique.UniqueIDRepo)),
 	wire.Bind(new(userentity.UserRepository), new(*user.UserRepo)),
 	wire.Bind(new(likeentity.LikeRepository), new(*like.LikeRepo)),
+	wire.Bind(new(collectionentity.CollectionRepository), new(*collection.CollectionRepo)),
+	wire.Bind(new(timelineentity.TimelineRepository), new(*timeline.TimelineRepo)),
+	wire.Bind(new(blockentity.BlockRepository), new(*block.BlockRepo)),
 
 	wire.Bind(new(fileentity.FileRepository), new(*file.FileRepo)),
 
@@ -45,10 +55,14 @@ var ProviderSetRepo = wire.NewSet(
 	comment.NewCommentRepo,
 	email.NewEmailRepo,
 	follow.NewFollowRepo,
+	follow.NewSuggestionRepo,
 	post.NewPostRepo,
 	tag.NewTagRepo,
 	unique.NewUniqueIDRepo,
 	user.NewUserRepo,
 	like.NewLikeRepo,
+	collection.NewCollectionRepo,
 	file.NewFileRepository,
+	timeline.NewTimelineRepo,
+	block.NewBlockRepo,
 )
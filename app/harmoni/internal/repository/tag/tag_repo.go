@@ -105,7 +105,7 @@ func (r *TagRepo) GetTagsByPostID(ctx context.Context, postID int64) ([]tagentit
 	tags := []tagentity.Tag{}
 	err := r.db.Table(postreltagentity.TableName).
 		Select("tag.tag_id", "tag.tag_name").
-		Where("post_id = ?", postID).
+		Where("post_tags.post_id = ? AND post_tags.deleted_at IS NULL", postID).
 		Joins("JOIN tag on post_tags.tag_id = tag.tag_id").
 		Find(&tags).Error
 	if err != nil {
@@ -114,3 +114,32 @@ func (r *TagRepo) GetTagsByPostID(ctx context.Context, postID int64) ([]tagentit
 
 	return tags, nil
 }
+
+// GetTagsByPostIDs 一次查询多个帖子的标签，没有标签的帖子不在结果中
+func (r *TagRepo) GetTagsByPostIDs(ctx context.Context, postIDs []int64) (map[int64][]tagentity.Tag, error) {
+	tagMap := make(map[int64][]tagentity.Tag, len(postIDs))
+	if len(postIDs) == 0 {
+		return tagMap, nil
+	}
+
+	var postTags []struct {
+		PostID  int64
+		TagID   int64
+		TagName string
+	}
+	// scan through the PostTag model so that removed tags are filtered out
+	err := r.db.WithContext(ctx).Model(&postreltagentity.PostTag{}).
+		Select("post_tags.post_id", "tag.tag_id", "tag.tag_name").
+		Where("post_tags.post_id IN ?", postIDs).
+		Joins("JOIN tag on post_tags.tag_id = tag.tag_id").
+		Order("post_tags.id").
+		Find(&postTags).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	for _, pt := range postTags {
+		tagMap[pt.PostID] = append(tagMap[pt.PostID], tagentity.Tag{TagID: pt.TagID, TagName: pt.TagName})
+	}
+	return tagMap, nil
+}
@@ -0,0 +1,187 @@
+package timeline
+
+import (
+	"context"
+	"fmt"
+	"harmoni/app/harmoni/internal/entity"
+	postentity "harmoni/app/harmoni/internal/entity/post"
+	timelineentity "harmoni/app/harmoni/internal/entity/timeline"
+	"harmoni/app/harmoni/internal/infrastructure/config"
+	"harmoni/app/harmoni/internal/pkg/reason"
+	"harmoni/internal/pkg/errorx"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+var _ timelineentity.TimelineRepository = (*TimelineRepo)(nil)
+
+// inboxKey 收件箱的分数是帖子的发布时间（毫秒），成员是补零到相同长度的帖子ID，
+// 分数相同时按字典序排列即按ID排列。雪花ID超出了 float64 的精度，不能直接作为分数
+func inboxKey(userID int64) string {
+	return fmt.Sprintf("user:%d:timeline.home", userID)
+}
+
+func inboxMember(postID int64) string {
+	return fmt.Sprintf("%019d", postID)
+}
+
+// pushScript 只推送到已经存在的收件箱，并截断到 ARGV[1] 条，之后的参数是成对的分数和成员。
+// 占位成员 entity.DefaultRedisValue 的分数为 0，排在最前面，截断时保留
+const pushScript = `
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+for i = 2, #ARGV, 2 do
+	redis.call('ZADD', KEYS[1], ARGV[i], ARGV[i + 1])
+end
+redis.call('ZREMRANGEBYRANK', KEYS[1], 1, -tonumber(ARGV[1]) - 1)
+return 1
+`
+
+// listScript 读取收件箱中排在游标之后的帖子，返回成对的成员和分数，收件箱不存在时返回 nil。
+// ARGV 依次为游标的分数（0 表示从最新的开始）、游标的成员、数量和过期时间（秒）。
+// 和游标同一毫秒发布的帖子可能排在游标前面，需要多取出来再过滤
+const listScript = `
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return false
+end
+redis.call('EXPIRE', KEYS[1], ARGV[4])
+local max, ties = '+inf', 0
+if ARGV[1] ~= '0' then
+	max = ARGV[1]
+	ties = redis.call('ZCOUNT', KEYS[1], max, max)
+end
+local limit = tonumber(ARGV[3])
+local items = redis.call('ZREVRANGEBYSCORE', KEYS[1], max, '(0', 'WITHSCORES', 'LIMIT', 0, limit + ties)
+local result = {}
+for i = 1, #items, 2 do
+	if #result == limit * 2 then
+		break
+	end
+	if ARGV[1] == '0' or tonumber(items[i + 1]) ~= tonumber(ARGV[1]) or items[i] < ARGV[2] then
+		table.insert(result, items[i])
+		table.insert(result, items[i + 1])
+	end
+end
+return result
+`
+
+type TimelineRepo struct {
+	conf   *config.Timeline
+	rdb    *redis.Client
+	logger *zap.SugaredLogger
+}
+
+func NewTimelineRepo(conf *config.Timeline, rdb *redis.Client, logger *zap.SugaredLogger) *TimelineRepo {
+	return &TimelineRepo{
+		conf:   conf,
+		rdb:    rdb,
+		logger: logger.With("module", "repository/timeline"),
+	}
+}
+
+func (r *TimelineRepo) Push(ctx context.Context, userIDs []int64, posts ...postentity.PostRef) error {
+	if len(userIDs) == 0 || len(posts) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, 0, 2*len(posts)+1)
+	args = append(args, r.conf.InboxSize)
+	for _, post := range posts {
+		args = append(args, post.CreatedAt.UnixMilli(), inboxMember(post.PostID))
+	}
+	script := redis.NewScript(pushScript)
+	// load the script once so that the pipeline can use EVALSHA
+	err := script.Load(ctx, r.rdb).Err()
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	pipe := r.rdb.Pipeline()
+	for _, userID := range userIDs {
+		script.EvalSha(ctx, pipe, []string{inboxKey(userID)}, args...)
+	}
+	_, err = pipe.Exec(ctx)
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func (r *TimelineRepo) Remove(ctx context.Context, userIDs []int64, postIDs ...int64) error {
+	if len(userIDs) == 0 || len(postIDs) == 0 {
+		return nil
+	}
+
+	members := make([]interface{}, len(postIDs))
+	for i, postID := range postIDs {
+		members[i] = inboxMember(postID)
+	}
+	pipe := r.rdb.Pipeline()
+	for _, userID := range userIDs {
+		pipe.ZRem(ctx, inboxKey(userID), members...)
+	}
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func (r *TimelineRepo) Rebuild(ctx context.Context, userID int64, posts []postentity.PostRef) error {
+	key := inboxKey(userID)
+	members := make([]redis.Z, 0, len(posts)+1)
+	members = append(members, redis.Z{Member: entity.DefaultRedisValue})
+	for _, post := range posts {
+		members = append(members, redis.Z{
+			Score:  float64(post.CreatedAt.UnixMilli()),
+			Member: inboxMember(post.PostID),
+		})
+	}
+
+	pipe := r.rdb.TxPipeline()
+	pipe.Del(ctx, key)
+	pipe.ZAdd(ctx, key, members...)
+	pipe.ZRemRangeByRank(ctx, key, 1, -int64(r.conf.InboxSize)-1)
+	pipe.Expire(ctx, key, r.conf.InboxExpire)
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func (r *TimelineRepo) List(ctx context.Context, userID int64, cursor postentity.PostRef, limit int) ([]postentity.PostRef, bool, error) {
+	var maxScore int64
+	var maxMember string
+	if !cursor.IsZero() {
+		maxScore, maxMember = cursor.CreatedAt.UnixMilli(), inboxMember(cursor.PostID)
+	}
+
+	// reading an inbox keeps it alive
+	items, err := redis.NewScript(listScript).Run(ctx, r.rdb, []string{inboxKey(userID)},
+		maxScore, maxMember, limit, int64(r.conf.InboxExpire/time.Second)).StringSlice()
+	if err == redis.Nil {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	posts := make([]postentity.PostRef, 0, len(items)/2)
+	for i := 0; i+1 < len(items); i += 2 {
+		postID, err := strconv.ParseInt(items[i], 10, 64)
+		if err != nil {
+			r.logger.Warn(err)
+			continue
+		}
+		score, err := strconv.ParseFloat(items[i+1], 64)
+		if err != nil {
+			r.logger.Warn(err)
+			continue
+		}
+		posts = append(posts, postentity.PostRef{PostID: postID, CreatedAt: time.UnixMilli(int64(score))})
+	}
+	return posts, true, nil
+}
@@ -10,6 +10,7 @@ import (
 	"harmoni/app/harmoni/internal/pkg/reason"
 	"harmoni/internal/pkg/errorx"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -94,6 +95,36 @@ func (r *UserRepo) GetByUserIDs(ctx context.Context, userIDs []int64) ([]userent
 	return users, nil
 }
 
+func (r *UserRepo) GetByNames(ctx context.Context, names []string) ([]userentity.User, error) {
+	users := make([]userentity.User, 0, len(names))
+	if len(names) == 0 {
+		return users, nil
+	}
+
+	err := r.db.WithContext(ctx).Where("name IN ?", names).Order("id").Find(&users).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return users, nil
+}
+
+func (r *UserRepo) ListByNamePrefix(ctx context.Context, prefix string, limit int) ([]userentity.User, error) {
+	users := make([]userentity.User, 0, limit)
+	// escape wildcards so that the prefix is matched literally
+	prefix = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(prefix)
+	err := r.db.WithContext(ctx).
+		Where("name LIKE ?", prefix+"%").
+		Order("name").
+		Limit(limit).
+		Find(&users).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return users, nil
+}
+
 // GetPage get user page TODO: Add Condition
 func (r *UserRepo) GetPage(ctx context.Context, pageSize, pageNum int64) (paginator.Page[userentity.User], error) {
 	userPage := paginator.Page[userentity.User]{CurrentPage: int64(pageNum), PageSize: int64(pageSize)}
@@ -105,6 +136,52 @@ func (r *UserRepo) GetPage(ctx context.Context, pageSize, pageNum int64) (pagina
 	return userPage, nil
 }
 
+func (r *UserRepo) ListAfter(ctx context.Context, afterUserID int64, limit int) ([]userentity.User, error) {
+	users := []userentity.User{}
+	err := r.db.WithContext(ctx).
+		Where("user_id > ?", afterUserID).
+		Order("user_id").
+		Limit(limit).
+		Find(&users).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return users, nil
+}
+
+func (r *UserRepo) ListActiveIDsAfter(ctx context.Context, afterUserID int64, since time.Time, limit int) ([]int64, error) {
+	userIDs := []int64{}
+	err := r.db.WithContext(ctx).Model(&userentity.User{}).
+		Where("user_id > ?", afterUserID).
+		Where("EXISTS (SELECT 1 FROM post WHERE post.author_id = `user`.user_id AND post.created_at >= ?) "+
+			"OR EXISTS (SELECT 1 FROM `like` WHERE `like`.user_id = `user`.user_id AND `like`.updated_at >= ?) "+
+			"OR EXISTS (SELECT 1 FROM follow WHERE follow.follower_id = `user`.user_id AND follow.created_at >= ?)",
+			since, since, since).
+		Order("user_id").
+		Limit(limit).
+		Pluck("user_id", &userIDs).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return userIDs, nil
+}
+
+func (r *UserRepo) ListPopularIDs(ctx context.Context, limit int) ([]int64, error) {
+	userIDs := []int64{}
+	err := r.db.WithContext(ctx).Model(&userentity.User{}).
+		Where("follow_count > 0").
+		Order("follow_count DESC").
+		Limit(limit).
+		Pluck("user_id", &userIDs).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	return userIDs, nil
+}
+
 func (r *UserRepo) GetModifyStaus(ctx context.Context, userID int64, verifyType userentity.VerifyType, actionType accountentity.AccountActionType) (userentity.ModifyStatus, error) {
 	key := userVerifyKey(userID, verifyType, actionType)
 	statusTmp, err := r.rdb.Get(ctx, key).Result()
@@ -186,6 +263,16 @@ func (r *UserRepo) SetAvatarID(ctx context.Context, userID int64, avatarID int64
 	return nil
 }
 
+func (r *UserRepo) SetPrivate(ctx context.Context, userID int64, private bool) error {
+	if err := r.db.WithContext(ctx).
+		Model(&userentity.User{}).
+		Where("user_id = ?", userID).
+		UpdateColumn("private", private).Error; err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
 func (r *UserRepo) GetAvatarID(ctx context.Context, userID int64) (int64, error) {
 	user := userentity.User{}
 	if err := r.db.WithContext(ctx).
@@ -7,15 +7,18 @@ import (
 )
 
 type HarmoniAPIRouter struct {
-	accountHandler  *handler.AccountHandler
-	followHandler   *handler.FollowHandler
-	fileHandler     *handler.FileHandler
-	userHandler     *handler.UserHandler
-	postHandler     *handler.PostHandler
-	tagHandler      *handler.TagHandler
-	commentHandler  *handler.CommentHandler
-	likeHandler     *handler.LikeHandler
-	timelineHandler *handler.TimeLineHandler
+	accountHandler    *handler.AccountHandler
+	followHandler     *handler.FollowHandler
+	fileHandler       *handler.FileHandler
+	userHandler       *handler.UserHandler
+	postHandler       *handler.PostHandler
+	tagHandler        *handler.TagHandler
+	commentHandler    *handler.CommentHandler
+	likeHandler       *handler.LikeHandler
+	timelineHandler   *handler.TimeLineHandler
+	searchHandler     *handler.SearchHandler
+	collectionHandler *handler.CollectionHandler
+	blockHandler      *handler.BlockHandler
 }
 
 func NewHarmoniAPIRouter(
@@ -28,17 +31,23 @@ func NewHarmoniAPIRouter(
 	commentHandler *handler.CommentHandler,
 	likeHandler *handler.LikeHandler,
 	timelineHandler *handler.TimeLineHandler,
+	searchHandler *handler.SearchHandler,
+	collectionHandler *handler.CollectionHandler,
+	blockHandler *handler.BlockHandler,
 ) *HarmoniAPIRouter {
 	return &HarmoniAPIRouter{
-		accountHandler:  accountHandler,
-		followHandler:   followHandler,
-		fileHandler:     fileHandler,
-		userHandler:     userHandler,
-		postHandler:     postHandler,
-		tagHandler:      tagHandler,
-		commentHandler:  commentHandler,
-		likeHandler:     likeHandler,
-		timelineHandler: timelineHandler,
+		accountHandler:    accountHandler,
+		followHandler:     followHandler,
+		fileHandler:       fileHandler,
+		userHandler:       userHandler,
+		postHandler:       postHandler,
+		tagHandler:        tagHandler,
+		commentHandler:    commentHandler,
+		likeHandler:       likeHandler,
+		timelineHandler:   timelineHandler,
+		searchHandler:     searchHandler,
+		collectionHandler: collectionHandler,
+		blockHandler:      blockHandler,
 	}
 }
 
@@ -56,16 +65,24 @@ func (h *HarmoniAPIRouter) RegisterHarmoniAPIRouter(r fiber.Router) {
 	follow.Get("/followings", h.followHandler.GetFollowings)
 	follow.Get("/isFollowing", h.followHandler.IsFollowing)
 	follow.Get("/arefolloweachother", h.followHandler.AreFollowEachOther)
+	follow.Get("/suggestions", h.followHandler.GetSuggestions)
+	follow.Get("/requests", h.followHandler.GetFollowRequests)
+	follow.Post("/requests", h.followHandler.HandleFollowRequest)
 
 	like := r.Group("/like")
 	like.Post("", h.likeHandler.Like)
+	like.Post("/reaction", h.likeHandler.React)
 
 	like.Get("/list", h.likeHandler.LikingList)
 	like.Get("/isLiking", h.likeHandler.IsLiking)
+	like.Get("/received", h.likeHandler.GetReceived)
+	like.Get("/received/stats", h.likeHandler.GetReceivedStats)
+	like.Post("/reconcile", h.likeHandler.Reconcile)
 
 	// user
 	r.Get("/user", h.userHandler.GetUsers)
 	r.Post("/user/avatar", h.fileHandler.UploadAvatar)
+	r.Put("/user/privacy", h.followHandler.SetPrivacy)
 
 	r.Post("/logout", h.userHandler.Logout)
 
@@ -74,13 +91,45 @@ func (h *HarmoniAPIRouter) RegisterHarmoniAPIRouter(r fiber.Router) {
 
 	// post
 	r.Post("/post", h.postHandler.CreatePost)
+	r.Put("/post/:id", h.postHandler.UpdatePost)
+	r.Delete("/post/:id", h.postHandler.DeletePost)
+	r.Post("/post/:id/restore", h.postHandler.RestorePost)
+	r.Post("/post/:id/revisions/:revision/rollback", h.postHandler.RollbackPost)
+
+	// draft
+	r.Get("/draft", h.postHandler.GetDrafts)
+	r.Post("/draft", h.postHandler.CreateDraft)
+	r.Put("/draft/:id", h.postHandler.SaveDraft)
+	r.Post("/draft/:id/publish", h.postHandler.PublishDraft)
+
+	// block and mute
+	r.Post("/block", h.blockHandler.Block)
+	r.Post("/mute", h.blockHandler.Mute)
+	r.Get("/blocks", h.blockHandler.GetBlocks)
+
+	// trash
+	r.Get("/trash/post", h.postHandler.GetTrash)
 
 	// timeline
 	r.Get("/timeline", h.timelineHandler.GetUserTimeLine)
 	r.Get("/timeline/home", h.timelineHandler.GetHomeTimeLine)
+	r.Get("/timeline/tags", h.timelineHandler.GetTagTimeLine)
 
 	// commnet
 	r.Post("/comment", h.commentHandler.CreateComment)
+	r.Put("/comment/area", h.commentHandler.UpdateCommentArea)
+	r.Put("/comment/:id", h.commentHandler.UpdateComment)
+	r.Delete("/comment/:id", h.commentHandler.DeleteComment)
+
+	// search
+	r.Post("/search/reindex", h.searchHandler.Reindex)
+
+	// collection
+	r.Post("/collection", h.collectionHandler.CreateCollection)
+	r.Put("/collection/:id", h.collectionHandler.UpdateCollection)
+	r.Delete("/collection/:id", h.collectionHandler.DeleteCollection)
+	r.Post("/collection/:id/post", h.collectionHandler.CollectPost)
+	r.Delete("/collection/:id/post/:post_id", h.collectionHandler.UncollectPost)
 
 	// file upload
 	r.Get("/file/uploaded", h.fileHandler.IsObjectUploaded)
@@ -103,6 +152,7 @@ func (h *HarmoniAPIRouter) RegisterUnAuthHarmoniAPIRouter(r fiber.Router) {
 	account.Post("/mail/check", h.accountHandler.MailCheck)
 
 	// user
+	r.Get("/user/suggest", h.userHandler.SuggestUsers)
 	r.Get("/user/:id", h.userHandler.GetUser)
 	r.Post("/user/token/refresh", h.userHandler.RefreshToken)
 
@@ -116,9 +166,25 @@ func (h *HarmoniAPIRouter) RegisterUnAuthHarmoniAPIRouter(r fiber.Router) {
 	// post
 	r.Get("/post", h.postHandler.GetPosts)
 	r.Get("/post/:id", h.postHandler.GetPostInfo)
+	r.Get("/post/:id/revisions", h.postHandler.GetPostRevisions)
+	r.Get("/post/:id/revisions/diff", h.postHandler.GetPostRevisionDiff)
 
 	// commnet
 	r.Get("/comment", h.commentHandler.GetComments)
+	r.Get("/comment/area", h.commentHandler.GetCommentArea)
+	r.Get("/comment/:id/replies", h.commentHandler.GetCommentReplies)
+	r.Get("/comment/:id/dialog", h.commentHandler.GetCommentDialog)
+
+	// like
+	r.Get("/like/reactions", h.likeHandler.GetReactions)
+
+	// search
+	r.Get("/search", h.searchHandler.Search)
+
+	// collection
+	r.Get("/collection", h.collectionHandler.GetCollections)
+	r.Get("/collection/:id", h.collectionHandler.GetCollection)
+	r.Get("/collection/:id/post", h.collectionHandler.GetCollectionPosts)
 
 	// file
 	r.Get("/file/get/:filepath", h.fileHandler.GetFileContent)
@@ -2,9 +2,12 @@ package comment
 
 import (
 	"encoding/json"
+	v1comment "harmoni/app/harmoni/api/mq/v1/comment"
+	v1 "harmoni/app/harmoni/api/mq/v1/like"
 	"harmoni/app/harmoni/internal/infrastructure/config"
 	"harmoni/app/harmoni/internal/infrastructure/mq"
 	"harmoni/app/harmoni/internal/infrastructure/mq/subscriber"
+	eventcomment "harmoni/app/harmoni/internal/types/events/comment"
 	eventlike "harmoni/app/harmoni/internal/types/events/like"
 	"harmoni/app/harmoni/internal/usecase/comment/events"
 
@@ -39,5 +42,26 @@ func NewCommentGroup(
 		}
 		return commentEventsHandler.HandleLikeStore(msg.Context(), &m)
 	})
+	g.Handle(eventlike.TopicLikeCreated, func(msg *message.Message) error {
+		var m v1.LikeCreatedMessage
+		if err := json.Unmarshal(msg.Payload, &m); err != nil {
+			return err
+		}
+		return commentEventsHandler.HandleLikeCreated(msg.Context(), &m)
+	})
+	g.Handle(eventcomment.TopicCommentCreated, func(msg *message.Message) error {
+		var m v1comment.CommentCreatedMessage
+		if err := json.Unmarshal(msg.Payload, &m); err != nil {
+			return err
+		}
+		return commentEventsHandler.HandleCommentCreated(msg.Context(), &m)
+	})
+	g.Handle(eventcomment.TopicCommentDeleted, func(msg *message.Message) error {
+		var m eventcomment.CommentDeletedMessage
+		if err := json.Unmarshal(msg.Payload, &m); err != nil {
+			return err
+		}
+		return commentEventsHandler.HandleCommentDeleted(msg.Context(), &m)
+	})
 	return nil
 }
@@ -2,10 +2,15 @@ package post
 
 import (
 	"encoding/json"
+	v1comment "harmoni/app/harmoni/api/mq/v1/comment"
+	v1 "harmoni/app/harmoni/api/mq/v1/like"
 	"harmoni/app/harmoni/internal/infrastructure/config"
 	"harmoni/app/harmoni/internal/infrastructure/mq"
 	"harmoni/app/harmoni/internal/infrastructure/mq/subscriber"
+	eventcollection "harmoni/app/harmoni/internal/types/events/collection"
+	eventcomment "harmoni/app/harmoni/internal/types/events/comment"
 	eventlike "harmoni/app/harmoni/internal/types/events/like"
+	eventpost "harmoni/app/harmoni/internal/types/events/post"
 	"harmoni/app/harmoni/internal/usecase/post/events"
 
 	"github.com/ThreeDotsLabs/watermill/message"
@@ -39,5 +44,61 @@ func NewPostGroup(
 		}
 		return postEventsHandler.HandleLikeStore(msg.Context(), &m)
 	})
+	g.Handle(eventcollection.TopicCollectStore, func(msg *message.Message) error {
+		var m eventcollection.CollectStoreMessage
+		if err := json.Unmarshal(msg.Payload, &m); err != nil {
+			return err
+		}
+		return postEventsHandler.HandleCollectStore(msg.Context(), &m)
+	})
+	g.Handle(eventcomment.TopicCommentStore, func(msg *message.Message) error {
+		var m eventcomment.CommentStoreMessage
+		if err := json.Unmarshal(msg.Payload, &m); err != nil {
+			return err
+		}
+		return postEventsHandler.HandleCommentStore(msg.Context(), &m)
+	})
+	g.Handle(eventlike.TopicLikeCreated, func(msg *message.Message) error {
+		var m v1.LikeCreatedMessage
+		if err := json.Unmarshal(msg.Payload, &m); err != nil {
+			return err
+		}
+		return postEventsHandler.HandleLikeCreated(msg.Context(), &m)
+	})
+	g.Handle(eventcomment.TopicCommentCreated, func(msg *message.Message) error {
+		var m v1comment.CommentCreatedMessage
+		if err := json.Unmarshal(msg.Payload, &m); err != nil {
+			return err
+		}
+		return postEventsHandler.HandleCommentCreated(msg.Context(), &m)
+	})
+	g.Handle(eventcomment.TopicCommentDeleted, func(msg *message.Message) error {
+		var m eventcomment.CommentDeletedMessage
+		if err := json.Unmarshal(msg.Payload, &m); err != nil {
+			return err
+		}
+		return postEventsHandler.HandleCommentDeleted(msg.Context(), &m)
+	})
+	g.Handle(eventpost.TopicPostCreated, func(msg *message.Message) error {
+		var m eventpost.PostCreatedMessage
+		if err := json.Unmarshal(msg.Payload, &m); err != nil {
+			return err
+		}
+		return postEventsHandler.HandlePostCreated(msg.Context(), &m)
+	})
+	g.Handle(eventpost.TopicPostUpdated, func(msg *message.Message) error {
+		var m eventpost.PostUpdatedMessage
+		if err := json.Unmarshal(msg.Payload, &m); err != nil {
+			return err
+		}
+		return postEventsHandler.HandlePostUpdated(msg.Context(), &m)
+	})
+	g.Handle(eventpost.TopicPostDeleted, func(msg *message.Message) error {
+		var m eventpost.PostDeletedMessage
+		if err := json.Unmarshal(msg.Payload, &m); err != nil {
+			return err
+		}
+		return postEventsHandler.HandlePostDeleted(msg.Context(), &m)
+	})
 	return nil
 }
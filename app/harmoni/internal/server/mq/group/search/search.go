@@ -0,0 +1,91 @@
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	v1 "harmoni/app/harmoni/api/mq/v1/comment"
+	"harmoni/app/harmoni/internal/infrastructure/config"
+	"harmoni/app/harmoni/internal/infrastructure/mq"
+	"harmoni/app/harmoni/internal/infrastructure/mq/subscriber"
+	eventcomment "harmoni/app/harmoni/internal/types/events/comment"
+	eventpost "harmoni/app/harmoni/internal/types/events/post"
+	eventsearch "harmoni/app/harmoni/internal/types/events/search"
+	"harmoni/app/harmoni/internal/usecase/search/events"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"go.uber.org/zap"
+)
+
+const (
+	groupName = "search"
+)
+
+// NewSearchGroup 搜索索引保存在每个实例的内存中，每个实例使用独立的队列，都能收到全部事件
+func NewSearchGroup(
+	conf *config.MessageQueue,
+	appConf *config.App,
+	r *message.Router,
+	searchEventsHandler *events.SearchEventsHandler,
+	logger *zap.Logger,
+	m ...message.HandlerMiddleware,
+) error {
+	sub, err := subscriber.NewSubscriber(conf, fmt.Sprintf("%s_%d", groupName, appConf.AppID), logger)
+	if err != nil {
+		return err
+	}
+	g := &mq.Group{
+		Router: r,
+		Name:   groupName,
+		Sub:    sub,
+	}
+	g.Handle(eventpost.TopicPostCreated, func(msg *message.Message) error {
+		var m eventpost.PostCreatedMessage
+		if err := json.Unmarshal(msg.Payload, &m); err != nil {
+			return err
+		}
+		return searchEventsHandler.HandlePostCreated(msg.Context(), &m)
+	})
+	g.Handle(eventpost.TopicPostUpdated, func(msg *message.Message) error {
+		var m eventpost.PostUpdatedMessage
+		if err := json.Unmarshal(msg.Payload, &m); err != nil {
+			return err
+		}
+		return searchEventsHandler.HandlePostUpdated(msg.Context(), &m)
+	})
+	g.Handle(eventpost.TopicPostDeleted, func(msg *message.Message) error {
+		var m eventpost.PostDeletedMessage
+		if err := json.Unmarshal(msg.Payload, &m); err != nil {
+			return err
+		}
+		return searchEventsHandler.HandlePostDeleted(msg.Context(), &m)
+	})
+	g.Handle(eventcomment.TopicCommentCreated, func(msg *message.Message) error {
+		var m v1.CommentCreatedMessage
+		if err := json.Unmarshal(msg.Payload, &m); err != nil {
+			return err
+		}
+		return searchEventsHandler.HandleCommentCreated(msg.Context(), &m)
+	})
+	g.Handle(eventcomment.TopicCommentUpdated, func(msg *message.Message) error {
+		var m eventcomment.CommentUpdatedMessage
+		if err := json.Unmarshal(msg.Payload, &m); err != nil {
+			return err
+		}
+		return searchEventsHandler.HandleCommentUpdated(msg.Context(), &m)
+	})
+	g.Handle(eventcomment.TopicCommentDeleted, func(msg *message.Message) error {
+		var m eventcomment.CommentDeletedMessage
+		if err := json.Unmarshal(msg.Payload, &m); err != nil {
+			return err
+		}
+		return searchEventsHandler.HandleCommentDeleted(msg.Context(), &m)
+	})
+	g.Handle(eventsearch.TopicSearchReindex, func(msg *message.Message) error {
+		var m eventsearch.ReindexMessage
+		if err := json.Unmarshal(msg.Payload, &m); err != nil {
+			return err
+		}
+		return searchEventsHandler.HandleReindex(msg.Context(), &m)
+	})
+	return nil
+}
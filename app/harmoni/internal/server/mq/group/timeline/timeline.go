@@ -0,0 +1,50 @@
+package timeline
+
+import (
+	"encoding/json"
+	"harmoni/app/harmoni/internal/infrastructure/config"
+	"harmoni/app/harmoni/internal/infrastructure/mq"
+	"harmoni/app/harmoni/internal/infrastructure/mq/subscriber"
+	eventpost "harmoni/app/harmoni/internal/types/events/post"
+	"harmoni/app/harmoni/internal/usecase/timeline/events"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"go.uber.org/zap"
+)
+
+const (
+	groupName = "timeline"
+)
+
+func NewTimelineGroup(
+	conf *config.MessageQueue,
+	r *message.Router,
+	timelineEventsHandler *events.TimelineEventsHandler,
+	logger *zap.Logger,
+	m ...message.HandlerMiddleware,
+) error {
+	sub, err := subscriber.NewSubscriber(conf, groupName, logger)
+	if err != nil {
+		return err
+	}
+	g := &mq.Group{
+		Router: r,
+		Name:   groupName,
+		Sub:    sub,
+	}
+	g.Handle(eventpost.TopicPostCreated, func(msg *message.Message) error {
+		var m eventpost.PostCreatedMessage
+		if err := json.Unmarshal(msg.Payload, &m); err != nil {
+			return err
+		}
+		return timelineEventsHandler.HandlePostCreated(msg.Context(), &m)
+	})
+	g.Handle(eventpost.TopicPostDeleted, func(msg *message.Message) error {
+		var m eventpost.PostDeletedMessage
+		if err := json.Unmarshal(msg.Payload, &m); err != nil {
+			return err
+		}
+		return timelineEventsHandler.HandlePostDeleted(msg.Context(), &m)
+	})
+	return nil
+}
@@ -5,10 +5,14 @@ import (
 	"harmoni/app/harmoni/internal/server/mq/group/comment"
 	"harmoni/app/harmoni/internal/server/mq/group/like"
 	"harmoni/app/harmoni/internal/server/mq/group/post"
+	"harmoni/app/harmoni/internal/server/mq/group/search"
+	"harmoni/app/harmoni/internal/server/mq/group/timeline"
 	"harmoni/app/harmoni/internal/server/mq/group/user"
 	commentevent "harmoni/app/harmoni/internal/usecase/comment/events"
 	likeevent "harmoni/app/harmoni/internal/usecase/like/events"
 	postevent "harmoni/app/harmoni/internal/usecase/post/events"
+	searchevent "harmoni/app/harmoni/internal/usecase/search/events"
+	timelineevent "harmoni/app/harmoni/internal/usecase/timeline/events"
 	userevent "harmoni/app/harmoni/internal/usecase/user/events"
 	"harmoni/internal/pkg/server"
 
@@ -26,10 +30,13 @@ var ProviderSetMQ = wire.NewSet(
 
 func NewMQRouter(
 	conf *config.MessageQueue,
+	appConf *config.App,
 	commentEventsHandler *commentevent.CommentEventsHandler,
 	likeevent *likeevent.LikeEventsHandler,
 	postEventsHandler *postevent.PostEventsHandler,
 	userevent *userevent.UserEventsHandler,
+	searchEventsHandler *searchevent.SearchEventsHandler,
+	timelineEventsHandler *timelineevent.TimelineEventsHandler,
 	logger *zap.Logger,
 ) (*message.Router, error) {
 	r, err := message.NewRouter(message.RouterConfig{}, watermillzap.NewLogger(logger))
@@ -63,5 +70,17 @@ func NewMQRouter(
 			return nil, err
 		}
 	}
+	{
+		err = search.NewSearchGroup(conf, appConf, r, searchEventsHandler, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+	{
+		err = timeline.NewTimelineGroup(conf, r, timelineEventsHandler, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return r, nil
 }
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	blockentity "harmoni/app/harmoni/internal/entity/block"
+	"harmoni/app/harmoni/internal/entity/paginator"
+	userentity "harmoni/app/harmoni/internal/entity/user"
+	"harmoni/app/harmoni/internal/usecase/block"
+	"harmoni/app/harmoni/internal/usecase/user"
+
+	"go.uber.org/zap"
+)
+
+type BlockService struct {
+	bc     *block.BlockUseCase
+	uc     *user.UserUseCase
+	logger *zap.SugaredLogger
+}
+
+func NewBlockService(bc *block.BlockUseCase, uc *user.UserUseCase, logger *zap.SugaredLogger) *BlockService {
+	return &BlockService{
+		bc:     bc,
+		uc:     uc,
+		logger: logger,
+	}
+}
+
+func (s *BlockService) Block(ctx context.Context, req *blockentity.BlockRequest) (*blockentity.BlockReply, error) {
+	var err error
+	if req.IsCancel {
+		err = s.bc.Unblock(ctx, req.UserID, req.TargetID)
+	} else {
+		err = s.bc.Block(ctx, req.UserID, req.TargetID)
+	}
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+
+	return &blockentity.BlockReply{}, nil
+}
+
+func (s *BlockService) Mute(ctx context.Context, req *blockentity.MuteRequest) (*blockentity.MuteReply, error) {
+	var err error
+	if req.IsCancel {
+		err = s.bc.Unmute(ctx, req.UserID, req.TargetID)
+	} else {
+		err = s.bc.Mute(ctx, req.UserID, req.TargetID)
+	}
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+
+	return &blockentity.MuteReply{}, nil
+}
+
+// GetBlocks 按屏蔽时间倒序列出拉黑或静音的用户
+func (s *BlockService) GetBlocks(ctx context.Context, req *blockentity.GetBlocksRequest) (*blockentity.GetBlocksReply, error) {
+	idPage, err := s.bc.GetPage(ctx, &blockentity.BlockQuery{
+		PageCond:  req.PageCond,
+		UserID:    req.UserID,
+		BlockType: req.Type,
+	})
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+
+	users, err := s.uc.ListBasicByUserIDs(ctx, idPage.Data)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+	userMap := make(map[int64]*userentity.UserBasicInfo, len(users))
+	for _, u := range users {
+		userMap[u.UserID] = u
+	}
+
+	res := paginator.Page[userentity.UserBasicInfo]{
+		CurrentPage: idPage.CurrentPage,
+		PageSize:    idPage.PageSize,
+		Total:       idPage.Total,
+		Pages:       idPage.Pages,
+		Data:        make([]userentity.UserBasicInfo, 0, len(idPage.Data)),
+	}
+	for _, userID := range idPage.Data {
+		if u, ok := userMap[userID]; ok {
+			res.Data = append(res.Data, *u)
+		}
+	}
+
+	return &blockentity.GetBlocksReply{Page: res}, nil
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	collectionentity "harmoni/app/harmoni/internal/entity/collection"
+	"harmoni/app/harmoni/internal/usecase/collection"
+
+	"go.uber.org/zap"
+)
+
+type CollectionService struct {
+	cc     *collection.CollectionUseCase
+	logger *zap.SugaredLogger
+}
+
+func NewCollectionService(
+	cc *collection.CollectionUseCase,
+	logger *zap.SugaredLogger,
+) *CollectionService {
+	return &CollectionService{
+		cc:     cc,
+		logger: logger,
+	}
+}
+
+func (s *CollectionService) Create(ctx context.Context, req *collectionentity.CreateCollectionRequest) (*collectionentity.CreateCollectionReply, error) {
+	info, err := s.cc.Create(ctx, &collectionentity.Collection{
+		UserID:      req.UserID,
+		Name:        req.Name,
+		Description: req.Description,
+		Public:      req.Public,
+	})
+	if err != nil {
+		s.logger.Errorln(err)
+		return nil, err
+	}
+
+	return &collectionentity.CreateCollectionReply{
+		CollectionInfo: *info,
+	}, nil
+}
+
+func (s *CollectionService) Update(ctx context.Context, req *collectionentity.UpdateCollectionRequest) (*collectionentity.UpdateCollectionReply, error) {
+	info, err := s.cc.Update(ctx, req.UserID, &collectionentity.Collection{
+		CollectionID: req.CollectionID,
+		Name:         req.Name,
+		Description:  req.Description,
+		Public:       req.Public,
+	})
+	if err != nil {
+		s.logger.Errorln(err)
+		return nil, err
+	}
+
+	return &collectionentity.UpdateCollectionReply{
+		CollectionInfo: *info,
+	}, nil
+}
+
+func (s *CollectionService) Delete(ctx context.Context, req *collectionentity.DeleteCollectionRequest) (*collectionentity.DeleteCollectionReply, error) {
+	err := s.cc.Delete(ctx, req.UserID, req.CollectionID)
+	if err != nil {
+		s.logger.Errorln(err)
+		return nil, err
+	}
+
+	return &collectionentity.DeleteCollectionReply{}, nil
+}
+
+func (s *CollectionService) GetCollection(ctx context.Context, req *collectionentity.GetCollectionRequest) (*collectionentity.GetCollectionReply, error) {
+	info, err := s.cc.GetByCollectionID(ctx, req.UserID, req.CollectionID)
+	if err != nil {
+		s.logger.Errorln(err)
+		return nil, err
+	}
+
+	return &collectionentity.GetCollectionReply{
+		CollectionInfo: *info,
+	}, nil
+}
+
+func (s *CollectionService) GetCollections(ctx context.Context, req *collectionentity.GetCollectionsRequest) (*collectionentity.GetCollectionsReply, error) {
+	collections, err := s.cc.GetPage(ctx, req.UserID, req.TargetUserID, &req.PageCond)
+	if err != nil {
+		s.logger.Errorln(err)
+		return nil, err
+	}
+
+	return &collectionentity.GetCollectionsReply{
+		Page: collections,
+	}, nil
+}
+
+func (s *CollectionService) CollectPost(ctx context.Context, req *collectionentity.CollectPostRequest) (*collectionentity.CollectPostReply, error) {
+	err := s.cc.AddPost(ctx, req.UserID, req.CollectionID, req.PostID)
+	if err != nil {
+		s.logger.Errorln(err)
+		return nil, err
+	}
+
+	return &collectionentity.CollectPostReply{}, nil
+}
+
+func (s *CollectionService) UncollectPost(ctx context.Context, req *collectionentity.UncollectPostRequest) (*collectionentity.UncollectPostReply, error) {
+	err := s.cc.RemovePost(ctx, req.UserID, req.CollectionID, req.PostID)
+	if err != nil {
+		s.logger.Errorln(err)
+		return nil, err
+	}
+
+	return &collectionentity.UncollectPostReply{}, nil
+}
+
+func (s *CollectionService) GetCollectionPosts(ctx context.Context, req *collectionentity.GetCollectionPostsRequest) (*collectionentity.GetCollectionPostsReply, error) {
+	posts, err := s.cc.GetPostPage(ctx, req.UserID, req.CollectionID, &req.PageCond)
+	if err != nil {
+		s.logger.Errorln(err)
+		return nil, err
+	}
+
+	return &collectionentity.GetCollectionPostsReply{
+		Page: posts,
+	}, nil
+}
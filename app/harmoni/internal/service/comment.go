@@ -36,6 +36,30 @@ func (s *CommentService) GetComments(ctx context.Context, req *commententity.Get
 	}, nil
 }
 
+func (s *CommentService) GetReplies(ctx context.Context, req *commententity.GetCommentRepliesRequest) (commententity.GetCommentRepliesReply, error) {
+	page, err := s.cc.ListReplies(ctx, req.UserID, req.CommentID, req.Cursor, req.Limit)
+	if err != nil {
+		s.logger.Errorln(err)
+		return commententity.GetCommentRepliesReply{}, err
+	}
+
+	return commententity.GetCommentRepliesReply{
+		CursorPage: *page,
+	}, nil
+}
+
+func (s *CommentService) GetDialog(ctx context.Context, req *commententity.GetCommentDialogRequest) (commententity.GetCommentDialogReply, error) {
+	page, err := s.cc.ListDialog(ctx, req.UserID, req.CommentID, req.Cursor, req.Limit)
+	if err != nil {
+		s.logger.Errorln(err)
+		return commententity.GetCommentDialogReply{}, err
+	}
+
+	return commententity.GetCommentDialogReply{
+		CursorPage: *page,
+	}, nil
+}
+
 func (s *CommentService) Create(ctx context.Context, req *commententity.CreateCommentRequest) (commententity.CreateCommentReply, error) {
 	comment := req.ToDomain()
 
@@ -49,3 +73,50 @@ func (s *CommentService) Create(ctx context.Context, req *commententity.CreateCo
 		Comment: *comment,
 	}, nil
 }
+
+func (s *CommentService) GetArea(ctx context.Context, req *commententity.GetCommentAreaRequest) (commententity.GetCommentAreaReply, error) {
+	area, err := s.cc.GetArea(ctx, req.ObjectID, req.ToObjectType())
+	if err != nil {
+		s.logger.Errorln(err)
+		return commententity.GetCommentAreaReply{}, err
+	}
+
+	return commententity.GetCommentAreaReply{
+		Area: *area,
+	}, nil
+}
+
+func (s *CommentService) UpdateArea(ctx context.Context, req *commententity.UpdateCommentAreaRequest) (commententity.UpdateCommentAreaReply, error) {
+	area := req.ToDomain()
+	err := s.cc.UpdateArea(ctx, req.UserID, area)
+	if err != nil {
+		s.logger.Errorln(err)
+		return commententity.UpdateCommentAreaReply{}, err
+	}
+
+	return commententity.UpdateCommentAreaReply{
+		Area: *area,
+	}, nil
+}
+
+func (s *CommentService) Update(ctx context.Context, req *commententity.UpdateCommentRequest) (commententity.UpdateCommentReply, error) {
+	comment, err := s.cc.Update(ctx, req.UserID, req.CommentID, req.Content)
+	if err != nil {
+		s.logger.Errorln(err)
+		return commententity.UpdateCommentReply{}, err
+	}
+
+	return commententity.UpdateCommentReply{
+		Comment: *comment,
+	}, nil
+}
+
+func (s *CommentService) Delete(ctx context.Context, req *commententity.DeleteCommentRequest) (commententity.DeleteCommentReply, error) {
+	err := s.cc.Delete(ctx, req.UserID, req.CommentID, req.Hard)
+	if err != nil {
+		s.logger.Errorln(err)
+		return commententity.DeleteCommentReply{}, err
+	}
+
+	return commententity.DeleteCommentReply{}, nil
+}
@@ -13,20 +13,25 @@ import (
 
 type FollowService struct {
 	fc     *follow.FollowUseCase
+	sc     *follow.SuggestionUseCase
 	uc     *user.UserUseCase
 	logger *zap.SugaredLogger
 }
 
-func NewFollowService(fc *follow.FollowUseCase, uc *user.UserUseCase, logger *zap.SugaredLogger) *FollowService {
+func NewFollowService(fc *follow.FollowUseCase, sc *follow.SuggestionUseCase, uc *user.UserUseCase, logger *zap.SugaredLogger) *FollowService {
 	return &FollowService{
 		fc:     fc,
+		sc:     sc,
 		uc:     uc,
 		logger: logger,
 	}
 }
 
 func (s *FollowService) Follow(ctx context.Context, req *followentity.FollowRequest) (*followentity.FollowReply, error) {
-	var err error
+	var (
+		pending bool
+		err     error
+	)
 	if req.IsCancel {
 		err = s.fc.FollowCancel(ctx, &followentity.Follow{
 			FollowerID:   req.UserID,
@@ -34,7 +39,7 @@ func (s *FollowService) Follow(ctx context.Context, req *followentity.FollowRequ
 			FollowedType: req.Type,
 		})
 	} else {
-		err = s.fc.Follow(ctx, &followentity.Follow{
+		pending, err = s.fc.Follow(ctx, &followentity.Follow{
 			FollowerID:   req.UserID,
 			FollowingID:  req.ObjectID,
 			FollowedType: req.Type,
@@ -45,7 +50,7 @@ func (s *FollowService) Follow(ctx context.Context, req *followentity.FollowRequ
 		return nil, err
 	}
 
-	return &followentity.FollowReply{}, nil
+	return &followentity.FollowReply{Pending: pending}, nil
 }
 
 func (s *FollowService) GetFollowers(ctx context.Context, req *followentity.GetFollowersRequest) (*followentity.GetFollowerReply, error) {
@@ -135,3 +140,92 @@ func (s *FollowService) AreFollowEachOther(ctx context.Context, req *followentit
 
 	return &followentity.AreFollowEachOtherReply{FollowedEachOther: following}, nil
 }
+
+// GetSuggestions 按推荐顺序列出推荐关注的用户
+func (s *FollowService) GetSuggestions(ctx context.Context, req *followentity.GetSuggestionsRequest) (*followentity.GetSuggestionsReply, error) {
+	userIDs, err := s.sc.Suggest(ctx, req.UserID, req.Limit)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+
+	users, err := s.uc.ListBasicByUserIDs(ctx, userIDs)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+	userMap := make(map[int64]*userentity.UserBasicInfo, len(users))
+	for _, u := range users {
+		userMap[u.UserID] = u
+	}
+
+	res := make([]*userentity.UserBasicInfo, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if u, ok := userMap[userID]; ok {
+			res = append(res, u)
+		}
+	}
+	return &followentity.GetSuggestionsReply{Users: res}, nil
+}
+
+// GetFollowRequests 按请求时间倒序列出待批准的关注请求
+func (s *FollowService) GetFollowRequests(ctx context.Context, req *followentity.GetFollowRequestsRequest) (*followentity.GetFollowRequestsReply, error) {
+	idPage, err := s.fc.GetPending(ctx, &followentity.FollowQuery{
+		PageCond: req.PageCond,
+		UserID:   req.UserID,
+		Type:     followentity.FollowUser,
+	})
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+
+	users, err := s.uc.ListBasicByUserIDs(ctx, idPage.Data)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+	userMap := make(map[int64]*userentity.UserBasicInfo, len(users))
+	for _, u := range users {
+		userMap[u.UserID] = u
+	}
+
+	res := paginator.Page[userentity.UserBasicInfo]{
+		CurrentPage: idPage.CurrentPage,
+		PageSize:    idPage.PageSize,
+		Total:       idPage.Total,
+		Pages:       idPage.Pages,
+		Data:        make([]userentity.UserBasicInfo, 0, len(idPage.Data)),
+	}
+	for _, userID := range idPage.Data {
+		if u, ok := userMap[userID]; ok {
+			res.Data = append(res.Data, *u)
+		}
+	}
+	return &followentity.GetFollowRequestsReply{Page: res}, nil
+}
+
+func (s *FollowService) HandleFollowRequest(ctx context.Context, req *followentity.HandleFollowRequestRequest) (*followentity.HandleFollowRequestReply, error) {
+	var err error
+	if req.Approve {
+		err = s.fc.Approve(ctx, req.UserID, req.FollowerID)
+	} else {
+		err = s.fc.Reject(ctx, req.UserID, req.FollowerID)
+	}
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+
+	return &followentity.HandleFollowRequestReply{}, nil
+}
+
+func (s *FollowService) SetPrivacy(ctx context.Context, req *followentity.SetPrivacyRequest) (*followentity.SetPrivacyReply, error) {
+	err := s.fc.SetPrivate(ctx, req.UserID, req.Private)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+
+	return &followentity.SetPrivacyReply{}, nil
+}
@@ -4,36 +4,137 @@ import (
 	"context"
 	likeentity "harmoni/app/harmoni/internal/entity/like"
 	"harmoni/app/harmoni/internal/entity/paginator"
+	"harmoni/app/harmoni/internal/pkg/reason"
 	"harmoni/app/harmoni/internal/usecase/like"
+	"harmoni/app/harmoni/internal/usecase/user"
+	"harmoni/internal/pkg/errorx"
+	"time"
 
 	"go.uber.org/zap"
 )
 
 type LikeService struct {
 	lc     *like.LikeUsecase
+	uc     *user.UserUseCase
 	logger *zap.SugaredLogger
 }
 
 func NewLikeUsecase(
 	lc *like.LikeUsecase,
+	uc *user.UserUseCase,
 	logger *zap.SugaredLogger) *LikeService {
 	return &LikeService{
 		lc:     lc,
+		uc:     uc,
 		logger: logger.With("module", "service/like"),
 	}
 }
 func (s *LikeService) Like(ctx context.Context, req *likeentity.LikeRequest) (*likeentity.LikeReply, error) {
 	err := s.lc.Like(ctx, &likeentity.Like{
+		UserID:    req.UserID,
+		LikingID:  req.ObjectID,
+		LikeType:  req.Type,
+		Direction: req.Direction,
+	}, req.IsCancel)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+
+	return &likeentity.LikeReply{}, nil
+}
+
+func (s *LikeService) React(ctx context.Context, req *likeentity.ReactRequest) (*likeentity.ReactReply, error) {
+	err := s.lc.React(ctx, &likeentity.Like{
 		UserID:   req.UserID,
 		LikingID: req.ObjectID,
 		LikeType: req.Type,
+		Reaction: req.Reaction,
 	}, req.IsCancel)
 	if err != nil {
 		s.logger.Error(err)
 		return nil, err
 	}
 
-	return &likeentity.LikeReply{}, nil
+	return &likeentity.ReactReply{}, nil
+}
+
+func (s *LikeService) GetReactions(ctx context.Context, req *likeentity.GetReactionsRequest) (*likeentity.GetReactionsReply, error) {
+	reply, err := s.lc.GetReactions(ctx, req.UserID, req.Type, req.ObjectID)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+func (s *LikeService) GetReceived(ctx context.Context, req *likeentity.GetReceivedLikesRequest) (*likeentity.GetReceivedLikesReply, error) {
+	query := &likeentity.ReceivedLikeQuery{
+		TargetUserID: req.UserID,
+		Type:         req.Type,
+		Limit:        req.Limit,
+	}
+	if req.Since != 0 {
+		query.Since = time.Unix(req.Since, 0)
+	}
+	if req.Until != 0 {
+		query.Until = time.Unix(req.Until, 0)
+	}
+	if req.Cursor != 0 {
+		query.Before = time.UnixMilli(req.Cursor)
+		query.BeforeID = req.CursorID
+	}
+	page, err := s.lc.ListReceived(ctx, query)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+
+	reply := &likeentity.GetReceivedLikesReply{CursorPage: *page}
+	if len(page.Data) != 0 {
+		reply.NextCursorID = page.Data[len(page.Data)-1].ID
+	}
+	return reply, nil
+}
+
+func (s *LikeService) GetReceivedStats(ctx context.Context, req *likeentity.GetReceivedLikeStatsRequest) (*likeentity.GetReceivedLikeStatsReply, error) {
+	day := time.Now().AddDate(0, 0, -1)
+	if req.Date != "" {
+		day, _ = time.ParseInLocation(time.DateOnly, req.Date, time.Local)
+	}
+	reply, err := s.lc.ReceivedStats(ctx, req.UserID, day)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+// Reconcile 版主手动触发点赞数对账，对账需要等待点赞记录落库，在后台进行并把结果写入日志。
+// 和定时任务共用同一把锁，已经在对账时直接返回错误
+func (s *LikeService) Reconcile(ctx context.Context, req *likeentity.ReconcileLikesRequest) (*likeentity.ReconcileLikesReply, error) {
+	isModerator, err := s.uc.IsModerator(ctx, req.UserID)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	} else if !isModerator {
+		return nil, errorx.Forbidden(reason.ModeratorRequired)
+	}
+
+	err = s.lc.ReconcileInBackground(func(drifts []likeentity.LikeDrift, err error) {
+		if err != nil {
+			s.logger.Errorf("reconcile like counts requested by user %d failed: %s", req.UserID, err)
+			return
+		}
+		s.logger.Infof("reconciled like counts requested by user %d: %+v", req.UserID, drifts)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &likeentity.ReconcileLikesReply{Started: true}, nil
 }
 
 func (s *LikeService) GetLikings(ctx context.Context, req *likeentity.GetLikingsRequest) (*likeentity.GetLikingsReply[any], error) {
@@ -30,7 +30,7 @@ func NewPostService(
 }
 
 func (s *PostService) GetPosts(ctx context.Context, req *postentity.GetPostsRequest) (*postentity.GetPostsReply, error) {
-	posts, err := s.pc.GetPage(ctx, &postentity.PostQuery{PageCond: req.PageCond, QueryCond: req.Order, TagID: req.TagID, UserID: req.UserID})
+	posts, err := s.pc.GetPage(ctx, &postentity.PostQuery{PageCond: req.PageCond, QueryCond: req.Order, TagID: req.TagID, UserID: req.UserID, HidePrivate: true})
 	if err != nil {
 		s.logger.Errorln(err)
 		return nil, err
@@ -73,3 +73,154 @@ func (s *PostService) Create(ctx context.Context, req *postentity.CreatePostRequ
 		PostInfo: *postInfo,
 	}, nil
 }
+
+func (s *PostService) Update(ctx context.Context, req *postentity.UpdatePostRequest) (*postentity.UpdatePostReply, error) {
+	post := postentity.Post{
+		PostID:  req.PostID,
+		TagIDs:  req.TagIDs,
+		Title:   req.Title,
+		Content: req.Content,
+	}
+
+	postInfo, err := s.pc.Update(ctx, req.UserID, &post, &postentity.PostRevision{Summary: req.Summary})
+	if err != nil {
+		s.logger.Errorln(err)
+		return nil, err
+	}
+
+	return &postentity.UpdatePostReply{
+		PostInfo: *postInfo,
+	}, nil
+}
+
+func (s *PostService) GetRevisions(ctx context.Context, req *postentity.GetPostRevisionsRequest) (*postentity.GetPostRevisionsReply, error) {
+	revisions, err := s.pc.GetRevisionPage(ctx, req.PostID, &req.PageCond)
+	if err != nil {
+		s.logger.Errorln(err)
+		return nil, err
+	}
+
+	return &postentity.GetPostRevisionsReply{
+		Page: revisions,
+	}, nil
+}
+
+func (s *PostService) GetRevisionDiff(ctx context.Context, req *postentity.GetPostRevisionDiffRequest) (*postentity.GetPostRevisionDiffReply, error) {
+	revisionDiff, err := s.pc.DiffRevisions(ctx, req.PostID, req.From, req.To)
+	if err != nil {
+		s.logger.Errorln(err)
+		return nil, err
+	}
+
+	return &postentity.GetPostRevisionDiffReply{
+		PostRevisionDiff: *revisionDiff,
+	}, nil
+}
+
+func (s *PostService) Rollback(ctx context.Context, req *postentity.RollbackPostRequest) (*postentity.RollbackPostReply, error) {
+	postInfo, err := s.pc.Rollback(ctx, req.UserID, req.PostID, req.Revision)
+	if err != nil {
+		s.logger.Errorln(err)
+		return nil, err
+	}
+
+	return &postentity.RollbackPostReply{
+		PostInfo: *postInfo,
+	}, nil
+}
+
+func (s *PostService) Delete(ctx context.Context, req *postentity.DeletePostRequest) (*postentity.DeletePostReply, error) {
+	err := s.pc.Delete(ctx, req.UserID, req.PostID)
+	if err != nil {
+		s.logger.Errorln(err)
+		return nil, err
+	}
+
+	return &postentity.DeletePostReply{}, nil
+}
+
+func (s *PostService) Restore(ctx context.Context, req *postentity.RestorePostRequest) (*postentity.RestorePostReply, error) {
+	postInfo, err := s.pc.Restore(ctx, req.UserID, req.PostID)
+	if err != nil {
+		s.logger.Errorln(err)
+		return nil, err
+	}
+
+	return &postentity.RestorePostReply{
+		PostInfo: *postInfo,
+	}, nil
+}
+
+func (s *PostService) GetTrash(ctx context.Context, req *postentity.GetTrashRequest) (*postentity.GetTrashReply, error) {
+	posts, err := s.pc.GetTrashPage(ctx, req.UserID, &req.PageCond)
+	if err != nil {
+		s.logger.Errorln(err)
+		return nil, err
+	}
+
+	return &postentity.GetTrashReply{
+		Page: posts,
+	}, nil
+}
+
+func (s *PostService) CreateDraft(ctx context.Context, req *postentity.CreateDraftRequest) (*postentity.CreateDraftReply, error) {
+	post := postentity.Post{
+		AuthorID: req.UserID,
+		TagIDs:   req.TagIDs,
+		Title:    req.Title,
+		Content:  req.Content,
+	}
+
+	postInfo, err := s.pc.CreateDraft(ctx, &post)
+	if err != nil {
+		s.logger.Errorln(err)
+		return nil, err
+	}
+
+	return &postentity.CreateDraftReply{
+		PostInfo: *postInfo,
+	}, nil
+}
+
+func (s *PostService) SaveDraft(ctx context.Context, req *postentity.SaveDraftRequest) (*postentity.SaveDraftReply, error) {
+	post := postentity.Post{
+		PostID:  req.PostID,
+		TagIDs:  req.TagIDs,
+		Title:   req.Title,
+		Content: req.Content,
+	}
+
+	postInfo, err := s.pc.SaveDraft(ctx, req.UserID, &post)
+	if err != nil {
+		s.logger.Errorln(err)
+		return nil, err
+	}
+
+	return &postentity.SaveDraftReply{
+		PostInfo: *postInfo,
+	}, nil
+}
+
+func (s *PostService) PublishDraft(ctx context.Context, req *postentity.PublishDraftRequest) (*postentity.PublishDraftReply, error) {
+	postInfo, err := s.pc.Publish(ctx, req.UserID, req.PostID, req.PublishAt)
+	if err != nil {
+		s.logger.Errorln(err)
+		return nil, err
+	}
+
+	return &postentity.PublishDraftReply{
+		PostInfo: *postInfo,
+	}, nil
+}
+
+func (s *PostService) GetDrafts(ctx context.Context, req *postentity.GetDraftsRequest) (*postentity.GetDraftsReply, error) {
+	posts, err := s.pc.GetDraftPage(ctx, req.UserID, &req.PageCond)
+	if err != nil {
+		s.logger.Errorln(err)
+		return nil, err
+	}
+
+	return &postentity.GetDraftsReply{
+		Page: posts,
+	}, nil
+}
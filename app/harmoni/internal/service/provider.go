@@ -16,5 +16,8 @@ var ProviderSetService = wire.NewSet(
 	NewLikeUsecase,
 	NewTimeLineService,
 	NewFileService,
+	NewSearchService,
+	NewCollectionService,
+	NewBlockService,
 	user.ProviderSetUserService,
 )
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	searchentity "harmoni/app/harmoni/internal/entity/search"
+	"harmoni/app/harmoni/internal/pkg/reason"
+	"harmoni/app/harmoni/internal/usecase/search"
+	"harmoni/app/harmoni/internal/usecase/user"
+	"harmoni/internal/pkg/errorx"
+
+	"go.uber.org/zap"
+)
+
+type SearchService struct {
+	sc     *search.SearchUseCase
+	uc     *user.UserUseCase
+	logger *zap.SugaredLogger
+}
+
+func NewSearchService(
+	sc *search.SearchUseCase,
+	uc *user.UserUseCase,
+	logger *zap.SugaredLogger,
+) *SearchService {
+	return &SearchService{
+		sc:     sc,
+		uc:     uc,
+		logger: logger,
+	}
+}
+
+func (s *SearchService) Search(ctx context.Context, req *searchentity.SearchRequest) (*searchentity.SearchReply, error) {
+	results, err := s.sc.Search(ctx, &searchentity.SearchQuery{
+		PageCond: req.PageCond,
+		Query:    req.Query,
+		Type:     req.Type,
+		UserID:   req.UserID,
+	})
+	if err != nil {
+		s.logger.Errorln(err)
+		return nil, err
+	}
+
+	return &searchentity.SearchReply{
+		Page: results,
+	}, nil
+}
+
+func (s *SearchService) Reindex(ctx context.Context, req *searchentity.ReindexRequest) (*searchentity.ReindexReply, error) {
+	isModerator, err := s.uc.IsModerator(ctx, req.UserID)
+	if err != nil {
+		s.logger.Errorln(err)
+		return nil, err
+	} else if !isModerator {
+		return nil, errorx.Forbidden(reason.ModeratorRequired)
+	}
+
+	reply, err := s.sc.ReindexAll(ctx)
+	if err != nil {
+		s.logger.Errorln(err)
+		return nil, err
+	}
+
+	return reply, nil
+}
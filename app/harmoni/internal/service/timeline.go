@@ -5,6 +5,7 @@ import (
 	postentity "harmoni/app/harmoni/internal/entity/post"
 	timelineentity "harmoni/app/harmoni/internal/entity/timeline"
 	"harmoni/app/harmoni/internal/usecase/timeline"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -37,13 +38,27 @@ func (s *TimeLineService) GetUserTimeLine(ctx context.Context, req *timelineenti
 }
 
 func (s *TimeLineService) GetHomeTimeLine(ctx context.Context, req *timelineentity.GetHomeTimeLineRequest) (*timelineentity.GetHomeTimeLineReply, error) {
-	timeline, err := s.tc.GetTimeLine(ctx, req.UserID, &postentity.PostQuery{PageCond: req.PageCond})
+	cursor := postentity.PostRef{}
+	if req.MaxID > 0 {
+		cursor = postentity.PostRef{PostID: req.MaxID, CreatedAt: time.UnixMilli(req.MaxTime)}
+	}
+	timeline, err := s.tc.GetTimeLine(ctx, req.UserID, cursor, req.Limit)
+	if err != nil {
+		s.logger.Errorln(err)
+		return nil, err
+	}
+
+	return timeline, nil
+}
+
+func (s *TimeLineService) GetTagTimeLine(ctx context.Context, req *timelineentity.GetTagTimeLineRequest) (*timelineentity.GetTagTimeLineReply, error) {
+	timeline, err := s.tc.GetTagTimeLine(ctx, req.UserID, &postentity.PostQuery{PageCond: req.PageCond})
 	if err != nil {
 		s.logger.Errorln(err)
 		return nil, err
 	}
 
-	return &timelineentity.GetHomeTimeLineReply{
+	return &timelineentity.GetTagTimeLineReply{
 		Page: *timeline,
 	}, nil
 }
@@ -19,6 +19,9 @@ var ProviderSetUserService = wire.NewSet(
 	NewUserGRPCService,
 )
 
+// suggestLimit 提及用户时自动补全的候选数
+const suggestLimit = 10
+
 type UserService struct {
 	uc     *user.UserUseCase
 	ac     *user.AuthUseCase
@@ -232,3 +235,15 @@ func (s *UserService) RefreshToken(ctx context.Context, req *userentity.RefreshT
 		RefreshToken: newRefreshToken,
 	}, nil
 }
+
+func (s *UserService) SuggestUsers(ctx context.Context, req *userentity.SuggestUsersRequest) (*userentity.SuggestUsersReply, error) {
+	users, err := s.uc.SuggestByNamePrefix(ctx, req.Prefix, suggestLimit)
+	if err != nil {
+		s.logger.Errorln(err)
+		return nil, err
+	}
+
+	return &userentity.SuggestUsersReply{
+		Users: users,
+	}, nil
+}
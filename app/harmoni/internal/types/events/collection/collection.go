@@ -0,0 +1,6 @@
+package collection
+
+type CollectStoreMessage struct {
+	// key is post id, value is collect count
+	Counts map[int64]int64
+}
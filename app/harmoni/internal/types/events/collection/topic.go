@@ -0,0 +1,5 @@
+package collection
+
+const (
+	TopicCollectStore = "collect_store"
+)
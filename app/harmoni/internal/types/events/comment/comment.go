@@ -0,0 +1,25 @@
+package comment
+
+import "harmoni/internal/types/object"
+
+type BaseMessage struct {
+	CommentID int64 `json:"comment_id,omitempty"`
+	ObjectID  int64 `json:"object_id,omitempty"`
+	AuthorID  int64 `json:"author_id,omitempty"`
+	RootID    int64 `json:"root_id,omitempty"`
+	// ObjectType 评论区所属对象的类型
+	ObjectType object.ObjectType `json:"object_type,omitempty"`
+}
+
+type CommentUpdatedMessage struct {
+	BaseMessage
+}
+
+type CommentDeletedMessage struct {
+	BaseMessage
+}
+
+type CommentStoreMessage struct {
+	// key is post id, value is comment count
+	Counts map[int64]int64
+}
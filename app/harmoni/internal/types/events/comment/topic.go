@@ -0,0 +1,8 @@
+package comment
+
+const (
+	TopicCommentCreated = "comment_created"
+	TopicCommentUpdated = "comment_updated"
+	TopicCommentDeleted = "comment_deleted"
+	TopicCommentStore   = "comment_store"
+)
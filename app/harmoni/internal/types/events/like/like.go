@@ -27,4 +27,6 @@ type LikeStoreMessage struct {
 	BaseMessage
 	// key is liking id, value is like count
 	Counts map[int64]int64
+	// DislikeCounts key is liking id, value is dislike count
+	DislikeCounts map[int64]int64
 }
@@ -0,0 +1,5 @@
+package mention
+
+const (
+	TopicMentionCreated = "mention_created"
+)
@@ -0,0 +1,25 @@
+package post
+
+import "time"
+
+type BaseMessage struct {
+	PostID   int64 `json:"post_id,omitempty"`
+	AuthorID int64 `json:"author_id,omitempty"`
+}
+
+// PostCreatedMessage 帖子发布或从回收站恢复
+type PostCreatedMessage struct {
+	BaseMessage
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+type PostUpdatedMessage struct {
+	BaseMessage
+	Revision int64 `json:"revision,omitempty"`
+}
+
+// PostDeletedMessage 帖子被移入回收站
+type PostDeletedMessage struct {
+	BaseMessage
+	OperatorID int64 `json:"operator_id,omitempty"`
+}
@@ -0,0 +1,7 @@
+package post
+
+const (
+	TopicPostCreated = "post_created"
+	TopicPostUpdated = "post_updated"
+	TopicPostDeleted = "post_deleted"
+)
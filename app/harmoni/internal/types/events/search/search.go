@@ -0,0 +1,7 @@
+package search
+
+// ReindexMessage 索引保存在每个实例的内存中，一个实例重建后通知其他实例各自重建
+type ReindexMessage struct {
+	// InstanceID 发起重建的实例，已经重建过，不需要再处理
+	InstanceID int64 `json:"instance_id,omitempty"`
+}
@@ -0,0 +1,166 @@
+package block
+
+import (
+	"context"
+	blockentity "harmoni/app/harmoni/internal/entity/block"
+	followentity "harmoni/app/harmoni/internal/entity/follow"
+	"harmoni/app/harmoni/internal/entity/paginator"
+	userentity "harmoni/app/harmoni/internal/entity/user"
+	"harmoni/app/harmoni/internal/pkg/reason"
+	"harmoni/app/harmoni/internal/types/iface"
+	followuse "harmoni/app/harmoni/internal/usecase/follow"
+	"harmoni/internal/pkg/errorx"
+	"harmoni/internal/types/events"
+
+	"go.uber.org/zap"
+)
+
+// muteRelayBatchSize 每批发送的静音变化数
+const muteRelayBatchSize = 100
+
+type BlockUseCase struct {
+	blockRepo     blockentity.BlockRepository
+	followRepo    followentity.FollowRepository
+	userRepo      userentity.UserRepository
+	followUseCase *followuse.FollowUseCase
+	publisher     iface.Publisher
+	logger        *zap.SugaredLogger
+}
+
+func NewBlockUseCase(
+	blockRepo blockentity.BlockRepository,
+	followRepo followentity.FollowRepository,
+	userRepo userentity.UserRepository,
+	followUseCase *followuse.FollowUseCase,
+	publisher iface.Publisher,
+	logger *zap.SugaredLogger,
+) *BlockUseCase {
+	return &BlockUseCase{
+		blockRepo:     blockRepo,
+		followRepo:    followRepo,
+		userRepo:      userRepo,
+		followUseCase: followUseCase,
+		publisher:     publisher,
+		logger:        logger.With("module", "usecase/block"),
+	}
+}
+
+func (u *BlockUseCase) create(ctx context.Context, block *blockentity.Block) error {
+	if block.UserID == block.TargetID {
+		return errorx.BadRequest(reason.DisallowBlockYourSelf)
+	}
+	_, exist, err := u.userRepo.GetByUserID(ctx, block.TargetID)
+	if err != nil {
+		return err
+	} else if !exist {
+		return errorx.NotFound(reason.UserNotFound)
+	}
+	return u.blockRepo.Create(ctx, block)
+}
+
+// Block 拉黑用户并双向解除关注，拉黑之后对方无法再关注自己。
+// 重复拉黑时只补做解除关注，之前解除关注失败时可以重试
+func (u *BlockUseCase) Block(ctx context.Context, userID, targetID int64) error {
+	blocked, err := u.blockRepo.Exists(ctx, userID, targetID, blockentity.BlockUser)
+	if err != nil {
+		return err
+	}
+	if !blocked {
+		err = u.create(ctx, &blockentity.Block{
+			UserID:    userID,
+			TargetID:  targetID,
+			BlockType: blockentity.BlockUser,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, follow := range []*followentity.Follow{
+		{FollowerID: userID, FollowingID: targetID, FollowedType: followentity.FollowUser},
+		{FollowerID: targetID, FollowingID: userID, FollowedType: followentity.FollowUser},
+	} {
+		_, err = u.followRepo.DeletePending(ctx, follow.FollowerID, follow.FollowingID)
+		if err != nil {
+			return err
+		}
+		following, err := u.followRepo.IsFollowing(ctx, follow)
+		if err != nil {
+			return err
+		} else if !following {
+			continue
+		}
+		// cancel through the use case so that the timeline inboxes are cleaned up as well
+		err = u.followUseCase.FollowCancel(ctx, follow)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *BlockUseCase) Unblock(ctx context.Context, userID, targetID int64) error {
+	return u.blockRepo.Delete(ctx, &blockentity.Block{
+		UserID:    userID,
+		TargetID:  targetID,
+		BlockType: blockentity.BlockUser,
+	})
+}
+
+// Mute 静音用户，静音变化经发件箱发送给通知服务，之后不再向自己发送对方产生的提醒
+func (u *BlockUseCase) Mute(ctx context.Context, userID, targetID int64) error {
+	return u.create(ctx, &blockentity.Block{
+		UserID:    userID,
+		TargetID:  targetID,
+		BlockType: blockentity.MuteUser,
+	})
+}
+
+func (u *BlockUseCase) Unmute(ctx context.Context, userID, targetID int64) error {
+	return u.blockRepo.Delete(ctx, &blockentity.Block{
+		UserID:    userID,
+		TargetID:  targetID,
+		BlockType: blockentity.MuteUser,
+	})
+}
+
+// RelayMuteEvents 按顺序把发件箱中的静音变化发送给通知服务，发送失败时停止，下次从失败的位置继续
+func (u *BlockUseCase) RelayMuteEvents(ctx context.Context) (int, error) {
+	relayed := 0
+	for {
+		muteEvents, err := u.blockRepo.ListMuteEvents(ctx, muteRelayBatchSize)
+		if err != nil {
+			return relayed, err
+		}
+		if len(muteEvents) == 0 {
+			return relayed, nil
+		}
+
+		ids := make([]uint64, 0, len(muteEvents))
+		for _, e := range muteEvents {
+			err = u.publisher.Publish(ctx, events.TopicUserMuted, &events.UserMutedMessage{
+				UserID:       e.UserID,
+				TargetUserID: e.TargetID,
+				IsCancel:     e.IsCancel,
+			})
+			if err != nil {
+				break
+			}
+			ids = append(ids, e.ID)
+		}
+		if delErr := u.blockRepo.DeleteMuteEvents(ctx, ids); delErr != nil {
+			return relayed, delErr
+		}
+		relayed += len(ids)
+		if err != nil {
+			return relayed, err
+		}
+		if len(muteEvents) < muteRelayBatchSize {
+			return relayed, nil
+		}
+	}
+}
+
+func (u *BlockUseCase) GetPage(ctx context.Context, query *blockentity.BlockQuery) (paginator.Page[int64], error) {
+	return u.blockRepo.GetPage(ctx, query)
+}
@@ -0,0 +1,228 @@
+package collection
+
+import (
+	"context"
+	"harmoni/app/harmoni/internal/entity"
+	collectionentity "harmoni/app/harmoni/internal/entity/collection"
+	"harmoni/app/harmoni/internal/entity/paginator"
+	postentity "harmoni/app/harmoni/internal/entity/post"
+	userentity "harmoni/app/harmoni/internal/entity/user"
+	"harmoni/app/harmoni/internal/pkg/reason"
+	postuse "harmoni/app/harmoni/internal/usecase/post"
+	useruse "harmoni/app/harmoni/internal/usecase/user"
+	"harmoni/internal/pkg/errorx"
+
+	"go.uber.org/zap"
+)
+
+type CollectionUseCase struct {
+	collectionRepo collectionentity.CollectionRepository
+	postUsecase    *postuse.PostUseCase
+	userUsecase    *useruse.UserUseCase
+	logger         *zap.SugaredLogger
+}
+
+func NewCollectionUseCase(
+	collectionRepo collectionentity.CollectionRepository,
+	postUsecase *postuse.PostUseCase,
+	userUsecase *useruse.UserUseCase,
+	logger *zap.SugaredLogger,
+) *CollectionUseCase {
+	return &CollectionUseCase{
+		collectionRepo: collectionRepo,
+		postUsecase:    postUsecase,
+		userUsecase:    userUsecase,
+		logger:         logger.With("module", "usecase/collection"),
+	}
+}
+
+func (u *CollectionUseCase) setUser(ctx context.Context, info *collectionentity.CollectionInfo) error {
+	owner, exist, err := u.userUsecase.GetBasicByUserID(ctx, info.User.UserID)
+	if err != nil {
+		return err
+	} else if !exist {
+		owner = &userentity.UserBasicInfo{
+			UserID: -1,
+			Name:   "deactivated",
+		}
+	}
+	info.User = owner
+	return nil
+}
+
+// getVisible 私有收藏夹只有创建者可以查看
+func (u *CollectionUseCase) getVisible(ctx context.Context, userID int64, collectionID int64) (*collectionentity.Collection, error) {
+	collection, exist, err := u.collectionRepo.GetByCollectionID(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	} else if !exist || (!collection.Public && collection.UserID != userID) {
+		return nil, errorx.NotFound(reason.CollectionNotFound)
+	}
+
+	return collection, nil
+}
+
+func (u *CollectionUseCase) getOwn(ctx context.Context, userID int64, collectionID int64) (*collectionentity.Collection, error) {
+	collection, err := u.getVisible(ctx, userID, collectionID)
+	if err != nil {
+		return nil, err
+	} else if collection.UserID != userID {
+		return nil, errorx.Forbidden(reason.CollectionEditWithoutPermission)
+	}
+
+	return collection, nil
+}
+
+func (u *CollectionUseCase) Create(ctx context.Context, collection *collectionentity.Collection) (*collectionentity.CollectionInfo, error) {
+	err := u.collectionRepo.Create(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	info := collection.ToInfo()
+	err = u.setUser(ctx, &info)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+func (u *CollectionUseCase) Update(ctx context.Context, userID int64, collection *collectionentity.Collection) (*collectionentity.CollectionInfo, error) {
+	origin, err := u.getOwn(ctx, userID, collection.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.collectionRepo.Update(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	origin.Name = collection.Name
+	origin.Description = collection.Description
+	origin.Public = collection.Public
+	info := origin.ToInfo()
+	err = u.setUser(ctx, &info)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+func (u *CollectionUseCase) Delete(ctx context.Context, userID int64, collectionID int64) error {
+	collection, err := u.getOwn(ctx, userID, collectionID)
+	if err != nil {
+		return err
+	}
+
+	return u.collectionRepo.Delete(ctx, collection)
+}
+
+func (u *CollectionUseCase) GetByCollectionID(ctx context.Context, userID int64, collectionID int64) (*collectionentity.CollectionInfo, error) {
+	collection, err := u.getVisible(ctx, userID, collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	info := collection.ToInfo()
+	err = u.setUser(ctx, &info)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// GetPage 用户的收藏夹，其他用户只能看到公开的收藏夹
+func (u *CollectionUseCase) GetPage(ctx context.Context, userID int64, ownerID int64, pageCond *entity.PageCond) (*paginator.Page[collectionentity.CollectionInfo], error) {
+	collections, err := u.collectionRepo.GetPageByUserID(ctx, ownerID, userID != ownerID, pageCond)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]collectionentity.CollectionInfo, len(collections.Data))
+	for i := range collections.Data {
+		infos[i] = collections.Data[i].ToInfo()
+	}
+	if len(infos) != 0 {
+		// all collections in the page belong to the same user
+		err = u.setUser(ctx, &infos[0])
+		if err != nil {
+			return nil, err
+		}
+		for i := range infos {
+			infos[i].User = infos[0].User
+		}
+	}
+
+	return &paginator.Page[collectionentity.CollectionInfo]{
+		CurrentPage: collections.CurrentPage,
+		PageSize:    collections.PageSize,
+		Total:       collections.Total,
+		Pages:       collections.Pages,
+		Data:        infos,
+	}, nil
+}
+
+func (u *CollectionUseCase) AddPost(ctx context.Context, userID int64, collectionID int64, postID int64) error {
+	_, err := u.getOwn(ctx, userID, collectionID)
+	if err != nil {
+		return err
+	}
+
+	post, exist, err := u.postUsecase.GetBasicInfoByPostID(ctx, postID)
+	if err != nil {
+		return err
+	} else if !exist || !post.IsPublished() {
+		return errorx.NotFound(reason.PostNotFound)
+	}
+
+	return u.collectionRepo.AddPost(ctx, &collectionentity.CollectionItem{
+		CollectionID: collectionID,
+		PostID:       postID,
+		UserID:       userID,
+	})
+}
+
+func (u *CollectionUseCase) RemovePost(ctx context.Context, userID int64, collectionID int64, postID int64) error {
+	_, err := u.getOwn(ctx, userID, collectionID)
+	if err != nil {
+		return err
+	}
+
+	return u.collectionRepo.RemovePost(ctx, &collectionentity.CollectionItem{
+		CollectionID: collectionID,
+		PostID:       postID,
+		UserID:       userID,
+	})
+}
+
+// GetPostPage 收藏夹中的帖子，已删除的帖子不再展示
+func (u *CollectionUseCase) GetPostPage(ctx context.Context, userID int64, collectionID int64, pageCond *entity.PageCond) (*paginator.Page[postentity.PostBasicInfo], error) {
+	_, err := u.getVisible(ctx, userID, collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	idPage, err := u.collectionRepo.GetPostIDPage(ctx, collectionID, pageCond)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := u.postUsecase.GetByPostIDs(ctx, userID, idPage.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &paginator.Page[postentity.PostBasicInfo]{
+		CurrentPage: idPage.CurrentPage,
+		PageSize:    idPage.PageSize,
+		Total:       idPage.Total,
+		Pages:       idPage.Pages,
+		Data:        posts,
+	}, nil
+}
+
+// BatchCollectCount 缓存中的帖子收藏数，由定时任务同步到数据库
+func (u *CollectionUseCase) BatchCollectCount(ctx context.Context) (map[int64]int64, error) {
+	return u.collectionRepo.BatchCollectCount(ctx)
+}
@@ -2,15 +2,32 @@ package comment
 
 import (
 	"context"
+	v1 "harmoni/app/harmoni/api/mq/v1/comment"
+	v1mention "harmoni/app/harmoni/api/mq/v1/mention"
+	blockentity "harmoni/app/harmoni/internal/entity/block"
 	commententity "harmoni/app/harmoni/internal/entity/comment"
+	followentity "harmoni/app/harmoni/internal/entity/follow"
 	likeentity "harmoni/app/harmoni/internal/entity/like"
 	"harmoni/app/harmoni/internal/entity/paginator"
+	postentity "harmoni/app/harmoni/internal/entity/post"
+	tagentity "harmoni/app/harmoni/internal/entity/tag"
 	"harmoni/app/harmoni/internal/entity/user"
+	"harmoni/app/harmoni/internal/infrastructure/config"
+	"harmoni/app/harmoni/internal/pkg/mention"
+	"harmoni/app/harmoni/internal/pkg/reason"
+	eventcomment "harmoni/app/harmoni/internal/types/events/comment"
+	eventmention "harmoni/app/harmoni/internal/types/events/mention"
+	"harmoni/app/harmoni/internal/types/iface"
 	"harmoni/app/harmoni/internal/usecase/comment/events"
 	"harmoni/app/harmoni/internal/usecase/file"
+	useruse "harmoni/app/harmoni/internal/usecase/user"
+	"harmoni/internal/pkg/errorx"
+	"harmoni/internal/types/object"
+	"time"
 
 	"github.com/google/wire"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var ProviderSetComment = wire.NewSet(
@@ -19,35 +36,382 @@ var ProviderSetComment = wire.NewSet(
 )
 
 type CommentUseCase struct {
+	conf        *config.Comment
 	commentRepo commententity.CommentRepository
 	likeRepo    likeentity.LikeRepository
+	blockRepo   blockentity.BlockRepository
+	postRepo    postentity.PostRepository
+	tagRepo     tagentity.TagRepository
+	followRepo  followentity.FollowRepository
 	userRepo    user.UserRepository
+	userUsecase *useruse.UserUseCase
 	fileUsecase *file.FileUseCase
+	publisher   iface.Publisher
 	logger      *zap.SugaredLogger
 }
 
 func NewCommentUseCase(
+	conf *config.Comment,
 	commentRepo commententity.CommentRepository,
 	likeRepo likeentity.LikeRepository,
+	blockRepo blockentity.BlockRepository,
+	postRepo postentity.PostRepository,
+	tagRepo tagentity.TagRepository,
+	followRepo followentity.FollowRepository,
 	userRepo user.UserRepository,
+	userUsecase *useruse.UserUseCase,
 	fileUsecase *file.FileUseCase,
+	publisher iface.Publisher,
 	logger *zap.SugaredLogger) *CommentUseCase {
 	return &CommentUseCase{
+		conf:        conf,
 		commentRepo: commentRepo,
 		likeRepo:    likeRepo,
+		blockRepo:   blockRepo,
+		postRepo:    postRepo,
+		tagRepo:     tagRepo,
+		followRepo:  followRepo,
 		userRepo:    userRepo,
+		userUsecase: userUsecase,
 		fileUsecase: fileUsecase,
+		publisher:   publisher,
 		logger:      logger,
 	}
 }
 
+// publish 评论事件用于更新搜索索引等衍生数据，发送失败不影响评论本身的写入
+func (u *CommentUseCase) publish(ctx context.Context, topic string, msg interface{}) {
+	err := u.publisher.Publish(ctx, topic, msg)
+	if err != nil {
+		u.logger.Errorf("send %s msg to mq failed: %s", topic, err)
+	}
+}
+
+// areaOwner 评论区所属对象的作者，话题没有作者。对象不存在时返回 NotFound
+func (u *CommentUseCase) areaOwner(ctx context.Context, objectID int64, objectType object.ObjectType) (int64, error) {
+	switch objectType {
+	case object.ObjectTypePost:
+		post, exist, err := u.postRepo.GetBasicInfoByPostID(ctx, objectID)
+		if err != nil {
+			return 0, err
+		} else if !exist || !post.IsPublished() {
+			return 0, errorx.NotFound(reason.PostNotFound)
+		}
+		return post.AuthorID, nil
+	case object.ObjectTypeTag:
+		_, exist, err := u.tagRepo.GetByTagID(ctx, objectID)
+		if err != nil {
+			return 0, err
+		} else if !exist {
+			return 0, errorx.NotFound(reason.TagNotFound)
+		}
+		return 0, nil
+	case object.ObjectTypeUser:
+		_, exist, err := u.userRepo.GetByUserID(ctx, objectID)
+		if err != nil {
+			return 0, err
+		} else if !exist {
+			return 0, errorx.NotFound(reason.UserNotFound)
+		}
+		return objectID, nil
+	}
+	return 0, errorx.BadRequest(reason.CommentAreaNotSupported)
+}
+
+// checkCommentable 作者总是可以在自己的评论区评论，除非评论区已关闭
+func (u *CommentUseCase) checkCommentable(ctx context.Context, userID int64, ownerID int64, objectID int64, objectType object.ObjectType) error {
+	area, err := u.commentRepo.GetArea(ctx, objectID, objectType)
+	if err != nil {
+		return err
+	}
+
+	switch area.Status {
+	case commententity.AreaClosed:
+		return errorx.Forbidden(reason.CommentAreaClosed)
+	case commententity.AreaFollowersOnly:
+		if userID == ownerID {
+			return nil
+		}
+		following, err := u.followRepo.IsFollowing(ctx, &followentity.Follow{
+			FollowerID:   userID,
+			FollowingID:  ownerID,
+			FollowedType: followentity.FollowUser,
+		})
+		if err != nil {
+			return err
+		} else if !following {
+			return errorx.Forbidden(reason.CommentAreaFollowersOnly)
+		}
+	}
+	return nil
+}
+
+// checkBlocked 被评论区作者或被回复评论的作者拉黑时不能评论
+func (u *CommentUseCase) checkBlocked(ctx context.Context, userID int64, ownerIDs ...int64) error {
+	blockers, err := u.blockRepo.ListBlockers(ctx, ownerIDs, userID, blockentity.BlockUser)
+	if err != nil {
+		return err
+	} else if len(blockers) != 0 {
+		return errorx.Forbidden(reason.BlockedByUser)
+	}
+	return nil
+}
+
+// Create 评论创建事件带上评论区所属对象的作者和被回复评论的作者，用于生成回复提醒
 func (u *CommentUseCase) Create(ctx context.Context, comment *commententity.Comment) error {
+	ownerID, err := u.areaOwner(ctx, comment.ObjectID, comment.ObjectType)
+	if err != nil {
+		return err
+	}
+	err = u.checkCommentable(ctx, comment.Author.UserID, ownerID, comment.ObjectID, comment.ObjectType)
+	if err != nil {
+		return err
+	}
+
+	var parentAuthorID int64
+	if comment.ParentID != 0 {
+		parent, exist, err := u.commentRepo.GetByCommentID(ctx, comment.ParentID)
+		if err != nil {
+			return err
+		} else if !exist || parent.ObjectID != comment.ObjectID || parent.ObjectType != comment.ObjectType {
+			return errorx.NotFound(reason.CommentNotFound)
+		}
+		parentAuthorID = parent.Author.UserID
+
+		comment.DialogID, err = u.dialogID(ctx, comment, parent)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = u.checkBlocked(ctx, comment.Author.UserID, ownerID, parentAuthorID)
+	if err != nil {
+		return err
+	}
+
+	comment.EscapeContent()
+	mentionIDs, err := u.userUsecase.ResolveMentions(ctx, comment.Content)
+	if err != nil {
+		return err
+	}
+	comment.Mentions = user.ToBasicInfos(mentionIDs)
+	err = u.commentRepo.Create(ctx, comment)
+	if err != nil {
+		return err
+	}
+	err = u.likeRepo.AddObject(ctx, likeentity.LikeComment, comment.CommentID, comment.Author.UserID)
+	if err != nil {
+		u.logger.Errorf("add comment %d to object filter failed: %s", comment.CommentID, err)
+	}
+
+	toUserIDs := make([]int64, len(comment.ToMembers))
+	for i, toMember := range comment.ToMembers {
+		toUserIDs[i] = toMember.UserID
+	}
+	u.publish(ctx, eventcomment.TopicCommentCreated, &v1.CommentCreatedMessage{
+		CommentID:      comment.CommentID,
+		ObjectID:       comment.ObjectID,
+		ObjectType:     v1.ObjectType(comment.ObjectType),
+		ObjectAuthorID: ownerID,
+		AuthorID:       comment.Author.UserID,
+		ParentID:       comment.ParentID,
+		ParentAuthorID: parentAuthorID,
+		RootID:         comment.RootID,
+		ToUserIDs:      toUserIDs,
+		CreatedAt:      timestamppb.New(comment.CreatedAt),
+	})
+	u.publishMentioned(ctx, comment, mentionIDs, comment.CreatedAt)
+	return nil
+}
+
+// dialogID 回复根评论不属于任何对话；回复楼中楼时，如果是在回应对方对自己的回复则延续对话，
+// 否则以这条回复开启新对话，同一条评论下不同用户的回复各自成为一个对话
+func (u *CommentUseCase) dialogID(ctx context.Context, comment, parent *commententity.Comment) (int64, error) {
+	if parent.RootID == 0 {
+		return 0, nil
+	}
+	if parent.DialogID == 0 {
+		return commententity.NewDialog, nil
+	}
+
+	grandparent, exist, err := u.commentRepo.GetByCommentID(ctx, parent.ParentID)
+	if err != nil {
+		return 0, err
+	} else if exist && grandparent.Author.UserID == comment.Author.UserID {
+		return parent.DialogID, nil
+	}
+	return commententity.NewDialog, nil
+}
+
+// publishMentioned 拉黑了评论作者的用户不会收到提及提醒
+func (u *CommentUseCase) publishMentioned(ctx context.Context, comment *commententity.Comment, mentionIDs []int64, createdAt time.Time) {
+	if len(mentionIDs) == 0 {
+		return
+	}
+	blockers, err := u.blockRepo.ListBlockers(ctx, mentionIDs, comment.Author.UserID, blockentity.BlockUser)
+	if err != nil {
+		u.logger.Errorf("list blockers of user %d failed: %s", comment.Author.UserID, err)
+		return
+	}
+	mentionIDs = blockentity.ExcludeUsers(mentionIDs, blockers)
+	if len(mentionIDs) == 0 {
+		return
+	}
+	u.publish(ctx, eventmention.TopicMentionCreated, &v1mention.MentionCreatedMessage{
+		UserID:           comment.Author.UserID,
+		MentionedUserIDs: mentionIDs,
+		ObjectType:       v1mention.ObjectType_ObjectComment,
+		ObjectID:         comment.CommentID,
+		CreatedAt:        timestamppb.New(createdAt),
+	})
+}
+
+// Update 作者只能在发布后的一段时间内编辑评论，编辑后新增的提及会通知对应用户
+func (u *CommentUseCase) Update(ctx context.Context, userID int64, commentID int64, content string) (*commententity.Comment, error) {
+	comment, exist, err := u.commentRepo.GetByCommentID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	} else if !exist || comment.Deleted {
+		return nil, errorx.NotFound(reason.CommentNotFound)
+	} else if comment.Author.UserID != userID {
+		return nil, errorx.Forbidden(reason.CommentEditWithoutPermission)
+	} else if time.Since(comment.CreatedAt) > u.conf.EditWindow {
+		return nil, errorx.BadRequest(reason.CommentCannotEditAfterDeadline)
+	}
+
+	visited := make(map[int64]bool, len(comment.Mentions))
+	for _, mentioned := range comment.Mentions {
+		visited[mentioned.UserID] = true
+	}
+
+	comment.Content = content
 	comment.EscapeContent()
-	return u.commentRepo.Create(ctx, comment)
+	mentionIDs, err := u.userUsecase.ResolveMentions(ctx, comment.Content)
+	if err != nil {
+		return nil, err
+	}
+	comment.Mentions = user.ToBasicInfos(mentionIDs)
+	err = u.commentRepo.Update(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	u.publish(ctx, eventcomment.TopicCommentUpdated, &eventcomment.CommentUpdatedMessage{
+		BaseMessage: eventcomment.BaseMessage{
+			CommentID:  comment.CommentID,
+			ObjectID:   comment.ObjectID,
+			AuthorID:   comment.Author.UserID,
+			RootID:     comment.RootID,
+			ObjectType: comment.ObjectType,
+		},
+	})
+	added := []int64{}
+	for _, mentionID := range mentionIDs {
+		if !visited[mentionID] {
+			added = append(added, mentionID)
+		}
+	}
+	u.publishMentioned(ctx, comment, added, time.Now())
+
+	comments := []*commententity.Comment{comment}
+	err = u.fillUsers(ctx, comments)
+	if err != nil {
+		return nil, err
+	}
+	err = u.fillLikeCount(ctx, userID, comments)
+	if err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// Delete 作者或版主删除评论后保留占位，只有版主可以彻底删除
+func (u *CommentUseCase) Delete(ctx context.Context, userID int64, commentID int64, hard bool) error {
+	comment, exist, err := u.commentRepo.GetByCommentID(ctx, commentID)
+	if err != nil {
+		return err
+	} else if !exist {
+		return errorx.NotFound(reason.CommentNotFound)
+	}
+
+	isModerator := false
+	if hard || comment.Author.UserID != userID {
+		isModerator, err = u.userUsecase.IsModerator(ctx, userID)
+		if err != nil {
+			return err
+		}
+	}
+	if hard && !isModerator {
+		return errorx.Forbidden(reason.ModeratorRequired)
+	} else if !isModerator && comment.Author.UserID != userID {
+		return errorx.Forbidden(reason.CommentDeleteWithoutPermission)
+	}
+
+	deleted := []*commententity.Comment{}
+	if hard {
+		comments, err := u.commentRepo.HardDelete(ctx, comment)
+		if err != nil {
+			return err
+		}
+		commentIDs := make([]int64, len(comments))
+		for i, c := range comments {
+			commentIDs[i] = c.CommentID
+		}
+		err = u.likeRepo.RemoveObject(ctx, likeentity.LikeComment, commentIDs...)
+		if err != nil {
+			u.logger.Errorf("remove comments from object cache failed: %s", err)
+		}
+		// tombstones have been removed from derived data already
+		for _, c := range comments {
+			if !c.Deleted {
+				deleted = append(deleted, c)
+			}
+		}
+	} else {
+		ok, err := u.commentRepo.Tombstone(ctx, comment)
+		if err != nil {
+			return err
+		} else if ok {
+			deleted = append(deleted, comment)
+		}
+		// the cached author would keep the tombstone likeable until the cache expires
+		err = u.likeRepo.RemoveObject(ctx, likeentity.LikeComment, comment.CommentID)
+		if err != nil {
+			u.logger.Errorf("remove comments from object cache failed: %s", err)
+		}
+	}
+
+	for _, c := range deleted {
+		u.publish(ctx, eventcomment.TopicCommentDeleted, &eventcomment.CommentDeletedMessage{
+			BaseMessage: eventcomment.BaseMessage{
+				CommentID:  c.CommentID,
+				ObjectID:   c.ObjectID,
+				AuthorID:   c.Author.UserID,
+				RootID:     c.RootID,
+				ObjectType: c.ObjectType,
+			},
+		})
+	}
+	return nil
 }
 
 func (u *CommentUseCase) GetPage(ctx context.Context, commentQuery *commententity.CommentQuery) (*paginator.Page[*commententity.Comment], error) {
-	comments, err := u.commentRepo.List(ctx, commentQuery)
+	err := u.checkAreaVisible(ctx, commentQuery.UserID, commentQuery.ObjectID, commentQuery.ObjectType)
+	if err != nil {
+		return nil, err
+	}
+
+	var comments paginator.Page[*commententity.Comment]
+	switch commentQuery.QueryCond {
+	case commententity.CommentOrderByHot, commententity.CommentOrderByBest:
+		comments, err = u.getRankedPage(ctx, commentQuery)
+	default:
+		comments, err = u.commentRepo.List(ctx, commentQuery)
+	}
+	if err != nil {
+		return nil, err
+	}
+	comments.Data, err = u.hideMuted(ctx, commentQuery.UserID, comments.Data)
 	if err != nil {
 		return nil, err
 	}
@@ -56,13 +420,13 @@ func (u *CommentUseCase) GetPage(ctx context.Context, commentQuery *commententit
 	if err != nil {
 		return nil, err
 	}
-	err = u.fillLikeCount(ctx, comments.Data)
+	err = u.fillLikeCount(ctx, commentQuery.UserID, comments.Data)
 	if err != nil {
 		return nil, err
 	}
 
 	if commentQuery.RootID == 0 && len(comments.Data) > 0 {
-		err = u.fillRootCommentsWithNSubComments(ctx, &comments)
+		err = u.fillRootCommentsWithNSubComments(ctx, commentQuery.UserID, &comments)
 		if err != nil {
 			return nil, err
 		}
@@ -70,7 +434,299 @@ func (u *CommentUseCase) GetPage(ctx context.Context, commentQuery *commententit
 	return &comments, err
 }
 
-func (u *CommentUseCase) fillRootCommentsWithNSubComments(ctx context.Context,
+// GetByCommentIDs 按给定顺序返回评论，不存在的评论和 viewerID 看不到的私密账号帖子下的评论被忽略
+func (u *CommentUseCase) GetByCommentIDs(ctx context.Context, viewerID int64, commentIDs []int64) ([]*commententity.Comment, error) {
+	comments, err := u.commentRepo.GetByCommentIDs(ctx, commentIDs)
+	if err != nil {
+		return nil, err
+	}
+	postIDs := make([]int64, 0, len(comments))
+	for _, comment := range comments {
+		if comment.ObjectType == object.ObjectTypePost {
+			postIDs = append(postIDs, comment.ObjectID)
+		}
+	}
+	hidden, err := u.hiddenPosts(ctx, viewerID, postIDs)
+	if err != nil {
+		return nil, err
+	}
+	comments = hidePrivate(comments, hidden)
+
+	commentMap := make(map[int64]*commententity.Comment, len(comments))
+	for _, comment := range comments {
+		commentMap[comment.CommentID] = comment
+	}
+	comments = make([]*commententity.Comment, 0, len(commentIDs))
+	for _, commentID := range commentIDs {
+		if comment, ok := commentMap[commentID]; ok {
+			comments = append(comments, comment)
+		}
+	}
+
+	err = u.fillUsers(ctx, comments)
+	if err != nil {
+		return nil, err
+	}
+	err = u.fillLikeCount(ctx, viewerID, comments)
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// ListReplies 按时间顺序分页列出根评论下的所有回复
+func (u *CommentUseCase) ListReplies(ctx context.Context, viewerID int64, rootID int64, cursor int64, limit int) (*paginator.CursorPage[*commententity.Comment], error) {
+	root, exist, err := u.commentRepo.GetByCommentID(ctx, rootID)
+	if err != nil {
+		return nil, err
+	} else if !exist || root.RootID != 0 {
+		return nil, errorx.NotFound(reason.CommentNotFound)
+	}
+	err = u.checkAreaVisible(ctx, viewerID, root.ObjectID, root.ObjectType)
+	if err != nil {
+		return nil, err
+	}
+
+	limit = paginator.CursorLimit(limit)
+	comments, err := u.commentRepo.ListByRootID(ctx, rootID, cursor, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return u.cursorPage(ctx, viewerID, comments, limit)
+}
+
+// ListDialog 按时间顺序分页列出评论所在对话中两人之间的往来回复
+func (u *CommentUseCase) ListDialog(ctx context.Context, viewerID int64, commentID int64, cursor int64, limit int) (*paginator.CursorPage[*commententity.Comment], error) {
+	comment, exist, err := u.commentRepo.GetByCommentID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	} else if !exist {
+		return nil, errorx.NotFound(reason.CommentNotFound)
+	}
+	err = u.checkAreaVisible(ctx, viewerID, comment.ObjectID, comment.ObjectType)
+	if err != nil {
+		return nil, err
+	}
+
+	// comments outside any dialog only list themselves, except the replied comments of dialogs keyed the old way
+	dialogID := comment.DialogID
+	if dialogID == 0 {
+		dialogID = comment.CommentID
+	}
+	limit = paginator.CursorLimit(limit)
+	comments, err := u.commentRepo.ListByDialogID(ctx, dialogID, cursor, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return u.cursorPage(ctx, viewerID, comments, limit)
+}
+
+func (u *CommentUseCase) cursorPage(ctx context.Context, viewerID int64, comments []*commententity.Comment, limit int) (*paginator.CursorPage[*commententity.Comment], error) {
+	page := paginator.NewCursorPage(comments, limit, func(c *commententity.Comment) int64 {
+		return c.CommentID
+	})
+	// hide after paging so that the cursor still moves past the hidden comments
+	var err error
+	page.Data, err = u.hideMuted(ctx, viewerID, page.Data)
+	if err != nil {
+		return nil, err
+	}
+	err = u.fillUsers(ctx, page.Data)
+	if err != nil {
+		return nil, err
+	}
+	err = u.fillLikeCount(ctx, viewerID, page.Data)
+	if err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// getRankedPage 从排序中分页读取评论，排序未建立时先从数据库构建
+func (u *CommentUseCase) getRankedPage(ctx context.Context, commentQuery *commententity.CommentQuery) (paginator.Page[*commententity.Comment], error) {
+	objectID, objectType := commentQuery.ObjectID, commentQuery.ObjectType
+	exist, err := u.commentRepo.RankExists(ctx, objectID, objectType, commentQuery.RootID)
+	if err != nil {
+		return paginator.Page[*commententity.Comment]{}, err
+	} else if !exist {
+		err = u.rebuildRanks(ctx, objectID, objectType, commentQuery.RootID)
+		if err != nil {
+			return paginator.Page[*commententity.Comment]{}, err
+		}
+	}
+
+	page := paginator.Page[*commententity.Comment]{CurrentPage: commentQuery.Page, PageSize: commentQuery.PageSize}
+	if page.CurrentPage <= 0 {
+		page.CurrentPage = 1
+	}
+	if page.PageSize <= 0 {
+		page.PageSize = 10
+	}
+	start := (page.CurrentPage - 1) * page.PageSize
+	commentIDs, total, err := u.commentRepo.ListRankedIDs(ctx, commentQuery.QueryCond, objectID, objectType, commentQuery.RootID, start, start+page.PageSize-1)
+	if err != nil {
+		return paginator.Page[*commententity.Comment]{}, err
+	}
+	page.Total = total
+	page.Pages = (total + page.PageSize - 1) / page.PageSize
+
+	comments, err := u.commentRepo.GetByCommentIDs(ctx, commentIDs)
+	if err != nil {
+		return paginator.Page[*commententity.Comment]{}, err
+	}
+	commentMap := make(map[int64]*commententity.Comment, len(comments))
+	for _, comment := range comments {
+		commentMap[comment.CommentID] = comment
+	}
+	page.Data = make([]*commententity.Comment, 0, len(commentIDs))
+	for _, commentID := range commentIDs {
+		if comment, ok := commentMap[commentID]; ok {
+			page.Data = append(page.Data, comment)
+		}
+	}
+	return page, nil
+}
+
+func (u *CommentUseCase) rebuildRanks(ctx context.Context, objectID int64, objectType object.ObjectType, rootID int64) error {
+	comments, err := u.commentRepo.ListByObjectAndRootID(ctx, objectID, objectType, rootID)
+	if err != nil {
+		return err
+	}
+
+	commentIDs := make([]int64, len(comments))
+	for i, comment := range comments {
+		commentIDs[i] = comment.CommentID
+	}
+	likes, err := u.likeRepo.BatchLikeCountByIDs(ctx, commentIDs, likeentity.LikeComment)
+	if err != nil {
+		return err
+	}
+	dislikes, err := u.likeRepo.BatchDislikeCountByIDs(ctx, commentIDs, likeentity.LikeComment)
+	if err != nil {
+		return err
+	}
+	replies, err := u.commentRepo.CountReplies(ctx, commentIDs)
+	if err != nil {
+		return err
+	}
+	ranks := commententity.NewRanks(comments, likes, dislikes, replies, u.conf.HotDecay)
+	return u.commentRepo.ResetRanks(ctx, objectID, objectType, rootID, ranks)
+}
+
+func (u *CommentUseCase) GetArea(ctx context.Context, objectID int64, objectType object.ObjectType) (*commententity.Area, error) {
+	if !commententity.IsAreaObjectType(objectType) {
+		return nil, errorx.BadRequest(reason.CommentAreaNotSupported)
+	}
+	return u.commentRepo.GetArea(ctx, objectID, objectType)
+}
+
+// UpdateArea 对象的作者或版主可以修改评论区设置，话题没有作者，只能由版主修改
+func (u *CommentUseCase) UpdateArea(ctx context.Context, userID int64, area *commententity.Area) error {
+	ownerID, err := u.areaOwner(ctx, area.ObjectID, area.ObjectType)
+	if err != nil {
+		return err
+	}
+	if ownerID == 0 && area.Status == commententity.AreaFollowersOnly {
+		return errorx.BadRequest(reason.CommentAreaNotSupported)
+	}
+	if ownerID != userID {
+		isModerator, err := u.userUsecase.IsModerator(ctx, userID)
+		if err != nil {
+			return err
+		} else if !isModerator {
+			return errorx.Forbidden(reason.CommentAreaWithoutPermission)
+		}
+	}
+
+	return u.commentRepo.SaveArea(ctx, area)
+}
+
+// hideMuted 去掉查看者静音的用户发表的评论
+func (u *CommentUseCase) hideMuted(ctx context.Context, viewerID int64, comments []*commententity.Comment) ([]*commententity.Comment, error) {
+	if viewerID == 0 || len(comments) == 0 {
+		return comments, nil
+	}
+	muted, err := u.blockRepo.ListTargetIDs(ctx, viewerID, blockentity.MuteUser)
+	if err != nil {
+		return nil, err
+	} else if len(muted) == 0 {
+		return comments, nil
+	}
+
+	set := make(map[int64]bool, len(muted))
+	for _, userID := range muted {
+		set[userID] = true
+	}
+	shown := make([]*commententity.Comment, 0, len(comments))
+	for _, comment := range comments {
+		if !set[comment.Author.UserID] {
+			shown = append(shown, comment)
+		}
+	}
+	return shown, nil
+}
+
+// checkAreaVisible 私密账号帖子下的评论和帖子一样，只对作者本人和已批准的关注者可见
+func (u *CommentUseCase) checkAreaVisible(ctx context.Context, viewerID int64, objectID int64, objectType object.ObjectType) error {
+	if objectType != object.ObjectTypePost {
+		return nil
+	}
+	hidden, err := u.hiddenPosts(ctx, viewerID, []int64{objectID})
+	if err != nil {
+		return err
+	} else if hidden[objectID] {
+		return errorx.Forbidden(reason.PrivateAccount)
+	}
+	return nil
+}
+
+// hiddenPosts postIDs 中作者是 viewerID 看不到的私密账号的帖子
+func (u *CommentUseCase) hiddenPosts(ctx context.Context, viewerID int64, postIDs []int64) (map[int64]bool, error) {
+	if len(postIDs) == 0 {
+		return nil, nil
+	}
+	posts, err := u.postRepo.BatchByIDs(ctx, postIDs)
+	if err != nil {
+		return nil, err
+	}
+	authorIDs := make([]int64, len(posts))
+	for i, post := range posts {
+		authorIDs[i] = post.AuthorID
+	}
+	authors, err := u.postRepo.ListHiddenAuthors(ctx, viewerID, authorIDs)
+	if err != nil || len(authors) == 0 {
+		return nil, err
+	}
+
+	hiddenAuthors := make(map[int64]bool, len(authors))
+	for _, authorID := range authors {
+		hiddenAuthors[authorID] = true
+	}
+	hidden := make(map[int64]bool)
+	for _, post := range posts {
+		if hiddenAuthors[post.AuthorID] {
+			hidden[post.PostID] = true
+		}
+	}
+	return hidden, nil
+}
+
+// hidePrivate 去掉 hiddenPosts 中的帖子下的评论
+func hidePrivate(comments []*commententity.Comment, hiddenPosts map[int64]bool) []*commententity.Comment {
+	if len(hiddenPosts) == 0 {
+		return comments
+	}
+	shown := make([]*commententity.Comment, 0, len(comments))
+	for _, comment := range comments {
+		if comment.ObjectType == object.ObjectTypePost && hiddenPosts[comment.ObjectID] {
+			continue
+		}
+		shown = append(shown, comment)
+	}
+	return shown
+}
+
+func (u *CommentUseCase) fillRootCommentsWithNSubComments(ctx context.Context, viewerID int64,
 	commentPage *paginator.Page[*commententity.Comment]) error {
 	commentIDs := make([]int64, len(commentPage.Data))
 	for i, comment := range commentPage.Data {
@@ -80,11 +736,15 @@ func (u *CommentUseCase) fillRootCommentsWithNSubComments(ctx context.Context,
 	if err != nil {
 		return err
 	}
+	subComments, err = u.hideMuted(ctx, viewerID, subComments)
+	if err != nil {
+		return err
+	}
 	err = u.fillUsers(ctx, subComments)
 	if err != nil {
 		return err
 	}
-	err = u.fillLikeCount(ctx, subComments)
+	err = u.fillLikeCount(ctx, viewerID, subComments)
 	if err != nil {
 		return err
 	}
@@ -116,6 +776,13 @@ func (u *CommentUseCase) fillUsers(ctx context.Context, comments []*commententit
 				visitedUserIDs[toUser.UserID] = true
 			}
 		}
+		for _, mentioned := range comment.Mentions {
+			// Add mentioned userIDs
+			if !visitedUserIDs[mentioned.UserID] {
+				userIDs = append(userIDs, mentioned.UserID)
+				visitedUserIDs[mentioned.UserID] = true
+			}
+		}
 	}
 	users, err := u.userRepo.GetByUserIDs(ctx, userIDs)
 	if err != nil {
@@ -139,6 +806,11 @@ func (u *CommentUseCase) fillUsers(ctx context.Context, comments []*commententit
 	}
 
 	for _, comment := range comments {
+		if comment.Deleted {
+			comment.Hide()
+			continue
+		}
+
 		userTmp := userMap[comment.Author.UserID]
 		if userTmp != nil {
 			userBasic := userTmp.ToBasicInfo(files[userTmp.Avatar])
@@ -160,11 +832,28 @@ func (u *CommentUseCase) fillUsers(ctx context.Context, comments []*commententit
 				toUser.Name = ""
 			}
 		}
+
+		// mentioned users who no longer exist are dropped
+		mentions := make([]*user.UserBasicInfo, 0, len(comment.Mentions))
+		names := make(map[string]int64, len(comment.Mentions))
+		for _, mentioned := range comment.Mentions {
+			mentionedTmp := userMap[mentioned.UserID]
+			if mentionedTmp == nil {
+				continue
+			}
+			userBasic := mentionedTmp.ToBasicInfo(files[mentionedTmp.Avatar])
+			mentions = append(mentions, &userBasic)
+			names[userBasic.Name] = userBasic.UserID
+		}
+		comment.Mentions = mentions
+		comment.RenderedContent = mention.Render(comment.Content, names)
 	}
 
 	return nil
 }
-func (u *CommentUseCase) fillLikeCount(ctx context.Context, comments []*commententity.Comment) error {
+
+// fillLikeCount 填充赞数、踩数和得分，viewerID 不为 0 时同时填充该用户的投票
+func (u *CommentUseCase) fillLikeCount(ctx context.Context, viewerID int64, comments []*commententity.Comment) error {
 	commentIDs := make([]int64, len(comments))
 	for i, comment := range comments {
 		commentIDs[i] = comment.CommentID
@@ -174,8 +863,22 @@ func (u *CommentUseCase) fillLikeCount(ctx context.Context, comments []*commente
 	if err != nil {
 		return err
 	}
+	dislikes, err := u.likeRepo.BatchDislikeCountByIDs(ctx, commentIDs, likeentity.LikeComment)
+	if err != nil {
+		return err
+	}
+	votes := map[int64]likeentity.LikeDirection{}
+	if viewerID != 0 {
+		votes, err = u.likeRepo.BatchVotes(ctx, viewerID, likeentity.LikeComment, commentIDs)
+		if err != nil {
+			return err
+		}
+	}
 	for i := range comments {
 		comments[i].LikeCount = likes[comments[i].CommentID]
+		comments[i].DislikeCount = dislikes[comments[i].CommentID]
+		comments[i].Score = comments[i].LikeCount - comments[i].DislikeCount
+		comments[i].Vote = int8(votes[comments[i].CommentID])
 	}
 
 	return nil
@@ -0,0 +1,40 @@
+package comment
+
+import (
+	commententity "harmoni/app/harmoni/internal/entity/comment"
+	"harmoni/internal/types/object"
+	"reflect"
+	"testing"
+)
+
+func TestHidePrivate(t *testing.T) {
+	comment := func(commentID, objectID int64, objectType object.ObjectType) *commententity.Comment {
+		return &commententity.Comment{CommentID: commentID, ObjectID: objectID, ObjectType: objectType}
+	}
+	comments := []*commententity.Comment{
+		comment(1, 10, object.ObjectTypePost),
+		comment(2, 20, object.ObjectTypePost),
+		comment(3, 10, object.ObjectTypeTag),
+		comment(4, 10, object.ObjectTypePost),
+	}
+	tests := []struct {
+		name   string
+		hidden map[int64]bool
+		want   []int64
+	}{
+		{"nothing hidden", nil, []int64{1, 2, 3, 4}},
+		{"private post", map[int64]bool{10: true}, []int64{2, 3}},
+		{"all posts private", map[int64]bool{10: true, 20: true}, []int64{3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []int64{}
+			for _, c := range hidePrivate(comments, tt.hidden) {
+				got = append(got, c.CommentID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("hidePrivate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,17 +2,30 @@ package events
 
 import (
 	"context"
-	userentity "harmoni/app/harmoni/internal/entity/comment"
+	v1comment "harmoni/app/harmoni/api/mq/v1/comment"
+	v1 "harmoni/app/harmoni/api/mq/v1/like"
+	commententity "harmoni/app/harmoni/internal/entity/comment"
+	likeentity "harmoni/app/harmoni/internal/entity/like"
+	"harmoni/app/harmoni/internal/infrastructure/config"
+	eventcomment "harmoni/app/harmoni/internal/types/events/comment"
 	event "harmoni/app/harmoni/internal/types/events/like"
 )
 
 type CommentEventsHandler struct {
-	userRepo userentity.CommentRepository
+	conf        *config.Comment
+	commentRepo commententity.CommentRepository
+	likeRepo    likeentity.LikeRepository
 }
 
-func NewCommentEventsHandler(userRepo userentity.CommentRepository) *CommentEventsHandler {
+func NewCommentEventsHandler(
+	conf *config.Comment,
+	commentRepo commententity.CommentRepository,
+	likeRepo likeentity.LikeRepository,
+) *CommentEventsHandler {
 	return &CommentEventsHandler{
-		userRepo: userRepo,
+		conf:        conf,
+		commentRepo: commentRepo,
+		likeRepo:    likeRepo,
 	}
 }
 
@@ -21,9 +34,73 @@ func (h *CommentEventsHandler) HandleLikeStore(ctx context.Context, msg *event.L
 		return nil
 	}
 	for k, v := range msg.Counts {
-		if err := h.userRepo.UpdateLikeCount(ctx, k, v); err != nil {
+		if err := h.commentRepo.UpdateLikeCount(ctx, k, v); err != nil {
+			return err
+		}
+	}
+	for k, v := range msg.DislikeCounts {
+		if err := h.commentRepo.UpdateDislikeCount(ctx, k, v); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// refreshRanks 重新计算评论的排序分，已经不存在的评论会被忽略
+func (h *CommentEventsHandler) refreshRanks(ctx context.Context, commentIDs ...int64) error {
+	comments, err := h.commentRepo.GetByCommentIDs(ctx, commentIDs)
+	if err != nil {
+		return err
+	}
+	if len(comments) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(comments))
+	for i, comment := range comments {
+		ids[i] = comment.CommentID
+	}
+	likes, err := h.likeRepo.BatchLikeCountByIDs(ctx, ids, likeentity.LikeComment)
+	if err != nil {
+		return err
+	}
+	dislikes, err := h.likeRepo.BatchDislikeCountByIDs(ctx, ids, likeentity.LikeComment)
+	if err != nil {
+		return err
+	}
+	replies, err := h.commentRepo.CountReplies(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	ranks := commententity.NewRanks(comments, likes, dislikes, replies, h.conf.HotDecay)
+	for i, comment := range comments {
+		if err := h.commentRepo.UpdateRank(ctx, comment, ranks[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *CommentEventsHandler) HandleLikeCreated(ctx context.Context, msg *v1.LikeCreatedMessage) error {
+	if msg.BaseMessage.GetLikeType() != v1.LikeType_LikeComment || msg.Reaction != "" {
+		return nil
+	}
+	return h.refreshRanks(ctx, msg.LikingID)
+}
+
+// HandleCommentCreated 新评论进入所在层级的排序，回复同时增加根评论的热度
+func (h *CommentEventsHandler) HandleCommentCreated(ctx context.Context, msg *v1comment.CommentCreatedMessage) error {
+	if msg.RootID == 0 {
+		return h.refreshRanks(ctx, msg.CommentID)
+	}
+	return h.refreshRanks(ctx, msg.CommentID, msg.RootID)
+}
+
+// HandleCommentDeleted 删除的回复不再计入根评论的热度
+func (h *CommentEventsHandler) HandleCommentDeleted(ctx context.Context, msg *eventcomment.CommentDeletedMessage) error {
+	if msg.RootID == 0 {
+		return nil
+	}
+	return h.refreshRanks(ctx, msg.RootID)
+}
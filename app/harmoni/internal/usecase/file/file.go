@@ -153,15 +153,21 @@ func (u *FileUseCase) ListFileLinkMap(ctx context.Context, fileIDs []int64) (map
 	}
 	fileMap := files.ToMap()
 	m := map[int64]string{}
+	defaultAvatar := ""
 	for _, fileID := range fileIDs {
-		if fileID == 0 {
-			m[fileID], err = u.getDefaultAvatar(ctx)
-			if err != nil {
-				return nil, err
+		file, ok := fileMap[fileID]
+		// files that no longer exist fall back to the default avatar
+		if fileID == 0 || !ok {
+			if defaultAvatar == "" {
+				defaultAvatar, err = u.getDefaultAvatar(ctx)
+				if err != nil {
+					return nil, err
+				}
 			}
+			m[fileID] = defaultAvatar
 			continue
 		}
-		m[fileID], err = u.getFilelinkCore(ctx, fileMap[fileID].Path)
+		m[fileID], err = u.getFilelinkCore(ctx, file.Path)
 		if err != nil {
 			return nil, err
 		}
@@ -2,44 +2,184 @@ package follow
 
 import (
 	"context"
+	blockentity "harmoni/app/harmoni/internal/entity/block"
 	followentity "harmoni/app/harmoni/internal/entity/follow"
 	"harmoni/app/harmoni/internal/entity/paginator"
 	"harmoni/app/harmoni/internal/pkg/reason"
+	"harmoni/app/harmoni/internal/types/iface"
 	taguse "harmoni/app/harmoni/internal/usecase/tag"
+	timelineuse "harmoni/app/harmoni/internal/usecase/timeline"
 	useruse "harmoni/app/harmoni/internal/usecase/user"
 	"harmoni/internal/pkg/errorx"
+	"harmoni/internal/types/events"
+	"time"
 
 	"go.uber.org/zap"
 )
 
+// approveBatchSize 取消私密账号时每批批准的关注请求数
+const approveBatchSize = 500
+
 type FollowUseCase struct {
-	followRepo  followentity.FollowRepository
-	userUseCase *useruse.UserUseCase
-	tagUseCase  *taguse.TagUseCase
-	logger      *zap.SugaredLogger
+	followRepo      followentity.FollowRepository
+	blockRepo       blockentity.BlockRepository
+	userUseCase     *useruse.UserUseCase
+	tagUseCase      *taguse.TagUseCase
+	timelineUseCase *timelineuse.TimeLinePullUsecase
+	publisher       iface.Publisher
+	logger          *zap.SugaredLogger
 }
 
 func NewFollowUseCase(followRepo followentity.FollowRepository,
+	blockRepo blockentity.BlockRepository,
 	userUseCase *useruse.UserUseCase,
 	tagUseCase *taguse.TagUseCase,
+	timelineUseCase *timelineuse.TimeLinePullUsecase,
+	publisher iface.Publisher,
 	logger *zap.SugaredLogger) *FollowUseCase {
 	return &FollowUseCase{
-		followRepo:  followRepo,
-		userUseCase: userUseCase,
-		tagUseCase:  tagUseCase,
-		logger:      logger,
+		followRepo:      followRepo,
+		blockRepo:       blockRepo,
+		userUseCase:     userUseCase,
+		tagUseCase:      tagUseCase,
+		timelineUseCase: timelineUseCase,
+		publisher:       publisher,
+		logger:          logger,
 	}
 }
 
-func (u *FollowUseCase) Follow(ctx context.Context, follow *followentity.Follow) error {
-	if follow.FollowerID == follow.FollowingID {
-		return errorx.BadRequest(reason.DisallowFollow)
+// Follow 关注私密账号时只创建关注请求，返回是否等待对方批准
+func (u *FollowUseCase) Follow(ctx context.Context, follow *followentity.Follow) (bool, error) {
+	if follow.FollowedType == followentity.FollowUser {
+		if follow.FollowerID == follow.FollowingID {
+			return false, errorx.BadRequest(reason.DisallowFollow)
+		}
+		blocked, err := u.blockRepo.Exists(ctx, follow.FollowingID, follow.FollowerID, blockentity.BlockUser)
+		if err != nil {
+			return false, err
+		} else if blocked {
+			return false, errorx.Forbidden(reason.BlockedByUser)
+		}
+		private, err := u.userUseCase.IsPrivate(ctx, follow.FollowingID)
+		if err != nil {
+			return false, err
+		} else if private {
+			return true, u.request(ctx, follow.FollowerID, follow.FollowingID)
+		}
+	}
+	err := u.followRepo.Follow(ctx, follow)
+	if err != nil {
+		return false, err
+	}
+
+	if follow.FollowedType == followentity.FollowUser {
+		u.backfill(ctx, follow.FollowerID, follow.FollowingID)
 	}
-	return u.followRepo.Follow(ctx, follow)
+	return false, nil
 }
 
+// request 创建关注请求并提醒私密账号
+func (u *FollowUseCase) request(ctx context.Context, followerID, followingID int64) error {
+	err := u.followRepo.CreatePending(ctx, &followentity.PendingFollow{
+		FollowerID:  followerID,
+		FollowingID: followingID,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = u.publisher.Publish(ctx, events.TopicFollowRequested, &events.FollowRequestedMessage{
+		UserID:       followerID,
+		TargetUserID: followingID,
+		CreatedAt:    time.Now(),
+	})
+	if err != nil {
+		u.logger.Errorf("send %s msg to mq failed: %s", events.TopicFollowRequested, err)
+	}
+	return nil
+}
+
+func (u *FollowUseCase) backfill(ctx context.Context, followerID, followingID int64) {
+	err := u.timelineUseCase.Backfill(ctx, followerID, followingID)
+	if err != nil {
+		u.logger.Errorf("backfill timeline of user %d failed: %s", followerID, err)
+	}
+}
+
+// FollowCancel 还没有被批准时撤回关注请求
 func (u *FollowUseCase) FollowCancel(ctx context.Context, follow *followentity.Follow) error {
-	return u.followRepo.FollowCancel(ctx, follow)
+	if follow.FollowedType == followentity.FollowUser {
+		withdrawn, err := u.followRepo.DeletePending(ctx, follow.FollowerID, follow.FollowingID)
+		if err != nil {
+			return err
+		} else if withdrawn {
+			return nil
+		}
+	}
+	err := u.followRepo.FollowCancel(ctx, follow)
+	if err != nil {
+		return err
+	}
+
+	if follow.FollowedType == followentity.FollowUser {
+		err = u.timelineUseCase.Cleanup(ctx, follow.FollowerID, follow.FollowingID)
+		if err != nil {
+			u.logger.Errorf("clean up timeline of user %d failed: %s", follow.FollowerID, err)
+		}
+	}
+	return nil
+}
+
+// Approve 批准 followerID 关注 userID
+func (u *FollowUseCase) Approve(ctx context.Context, userID, followerID int64) error {
+	err := u.followRepo.ApprovePending(ctx, followerID, userID)
+	if err != nil {
+		return err
+	}
+
+	u.backfill(ctx, followerID, userID)
+	return nil
+}
+
+// Reject 拒绝 followerID 的关注请求，对方不会收到提醒
+func (u *FollowUseCase) Reject(ctx context.Context, userID, followerID int64) error {
+	exist, err := u.followRepo.DeletePending(ctx, followerID, userID)
+	if err != nil {
+		return err
+	} else if !exist {
+		return errorx.NotFound(reason.FollowRequestNotFound)
+	}
+	return nil
+}
+
+func (u *FollowUseCase) GetPending(ctx context.Context, followQuery *followentity.FollowQuery) (paginator.Page[int64], error) {
+	return u.followRepo.GetPendingPage(ctx, followQuery)
+}
+
+// SetPrivate 取消私密账号时批准所有待处理的关注请求
+func (u *FollowUseCase) SetPrivate(ctx context.Context, userID int64, private bool) error {
+	err := u.userUseCase.SetPrivate(ctx, userID, private)
+	if err != nil || private {
+		return err
+	}
+
+	var after int64
+	for {
+		followerIDs, err := u.followRepo.ListPendingAfter(ctx, userID, after, approveBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, followerID := range followerIDs {
+			err = u.Approve(ctx, userID, followerID)
+			if err != nil {
+				return err
+			}
+		}
+		if len(followerIDs) < approveBatchSize {
+			return nil
+		}
+		after = followerIDs[len(followerIDs)-1]
+	}
 }
 
 func (u *FollowUseCase) GetFollowers(ctx context.Context, followQuery *followentity.FollowQuery) (paginator.Page[int64], error) {
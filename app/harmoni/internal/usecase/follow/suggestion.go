@@ -0,0 +1,288 @@
+package follow
+
+import (
+	"context"
+	blockentity "harmoni/app/harmoni/internal/entity/block"
+	followentity "harmoni/app/harmoni/internal/entity/follow"
+	likeentity "harmoni/app/harmoni/internal/entity/like"
+	postentity "harmoni/app/harmoni/internal/entity/post"
+	userentity "harmoni/app/harmoni/internal/entity/user"
+	"harmoni/app/harmoni/internal/infrastructure/config"
+	"math"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// suggestionBatchSize 每批一起查询候选用户的用户数
+	suggestionBatchSize = 100
+	// suggestionCandidates 每种信号最多取的候选用户数
+	suggestionCandidates = 500
+	// likedTagsLimit 用户赞过最多的话题才用于匹配
+	likedTagsLimit = 20
+
+	defaultSuggestionLimit = 10
+
+	// 每个共同关注的用户和每个相同的话题的分数
+	weightMutual = 3.0
+	weightTag    = 2.0
+)
+
+type SuggestionUseCase struct {
+	conf           *config.Follow
+	suggestionRepo followentity.SuggestionRepository
+	followRepo     followentity.FollowRepository
+	blockRepo      blockentity.BlockRepository
+	likeRepo       likeentity.LikeRepository
+	postRepo       postentity.PostRepository
+	userRepo       userentity.UserRepository
+	logger         *zap.SugaredLogger
+}
+
+func NewSuggestionUseCase(conf *config.Follow,
+	suggestionRepo followentity.SuggestionRepository,
+	followRepo followentity.FollowRepository,
+	blockRepo blockentity.BlockRepository,
+	likeRepo likeentity.LikeRepository,
+	postRepo postentity.PostRepository,
+	userRepo userentity.UserRepository,
+	logger *zap.SugaredLogger) *SuggestionUseCase {
+	return &SuggestionUseCase{
+		conf:           conf,
+		suggestionRepo: suggestionRepo,
+		followRepo:     followRepo,
+		blockRepo:      blockRepo,
+		likeRepo:       likeRepo,
+		postRepo:       postRepo,
+		userRepo:       userRepo,
+		logger:         logger.With("module", "usecase/follow/suggestion"),
+	}
+}
+
+// Suggest 推荐给用户关注的用户，预先计算的推荐不够时用粉丝最多的用户补齐。
+// 推荐计算之后新关注、拉黑或静音的用户在这里去掉
+func (u *SuggestionUseCase) Suggest(ctx context.Context, userID int64, limit int) ([]int64, error) {
+	if limit <= 0 {
+		limit = defaultSuggestionLimit
+	}
+
+	suggestions, err := u.suggestionRepo.GetSuggestions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	candidates := suggestions
+	if len(suggestions) < limit {
+		popular, err := u.getPopular(ctx)
+		if err != nil {
+			return nil, err
+		}
+		candidates = appendUnique(suggestions, popular)
+	}
+
+	excluded, err := u.excluded(ctx, userID, candidates)
+	if err != nil {
+		return nil, err
+	}
+	candidates = blockentity.ExcludeUsers(candidates, excluded)
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	return candidates, nil
+}
+
+// getPopular 定时任务还没有执行过时从数据库查询
+func (u *SuggestionUseCase) getPopular(ctx context.Context) ([]int64, error) {
+	popular, err := u.suggestionRepo.GetPopular(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(popular) != 0 {
+		return popular, nil
+	}
+	return u.userRepo.ListPopularIDs(ctx, u.conf.SuggestionSize)
+}
+
+// Rebuild 重新计算全站粉丝最多的用户和最近活跃的用户的推荐，返回计算过的用户数。
+// 不活跃的用户保留之前的推荐，新用户使用粉丝最多的用户；一批用户失败时记录日志并继续
+func (u *SuggestionUseCase) Rebuild(ctx context.Context) (int, error) {
+	popular, err := u.userRepo.ListPopularIDs(ctx, u.conf.SuggestionSize)
+	if err != nil {
+		return 0, err
+	}
+	err = u.suggestionRepo.SetPopular(ctx, popular)
+	if err != nil {
+		return 0, err
+	}
+
+	since := time.Now().Add(-u.conf.ActiveWindow)
+	posts, err := u.postRepo.CountByAuthorsSince(ctx, since)
+	if err != nil {
+		return 0, err
+	}
+
+	rebuilt := 0
+	var after int64
+	for {
+		userIDs, err := u.userRepo.ListActiveIDsAfter(ctx, after, since, suggestionBatchSize)
+		if err != nil {
+			return rebuilt, err
+		}
+		if len(userIDs) == 0 {
+			return rebuilt, nil
+		}
+
+		n, err := u.rebuildBatch(ctx, userIDs, posts)
+		if err != nil {
+			u.logger.Errorf("rebuild follow suggestions of users %d to %d failed: %s", userIDs[0], userIDs[len(userIDs)-1], err)
+		}
+		rebuilt += n
+		if len(userIDs) < suggestionBatchSize {
+			return rebuilt, nil
+		}
+		after = userIDs[len(userIDs)-1]
+	}
+}
+
+// rebuildBatch 一次查询一批用户的候选用户，单个用户保存失败时跳过，返回保存成功的用户数
+func (u *SuggestionUseCase) rebuildBatch(ctx context.Context, userIDs []int64, posts map[int64]int64) (int, error) {
+	mutual, err := u.followRepo.BatchCountFollowingsOfFollowings(ctx, userIDs, suggestionCandidates)
+	if err != nil {
+		return 0, err
+	}
+	likedTags, err := u.likeRepo.BatchListLikedTagIDs(ctx, userIDs, likedTagsLimit)
+	if err != nil {
+		return 0, err
+	}
+	var tagIDs []int64
+	for _, ids := range likedTags {
+		tagIDs = append(tagIDs, ids...)
+	}
+	tagIDs = appendUnique(nil, tagIDs)
+	likers, err := u.likeRepo.ListTopLikersByTags(ctx, tagIDs, suggestionCandidates)
+	if err != nil {
+		return 0, err
+	}
+
+	followings, err := u.followRepo.BatchGetFollowingUsers(ctx, userIDs)
+	if err != nil {
+		return 0, err
+	}
+	blocked, err := u.blockRepo.BatchListTargetIDs(ctx, userIDs, blockentity.BlockUser)
+	if err != nil {
+		return 0, err
+	}
+	muted, err := u.blockRepo.BatchListTargetIDs(ctx, userIDs, blockentity.MuteUser)
+	if err != nil {
+		return 0, err
+	}
+	blockers, err := u.blockRepo.BatchListBlockersOf(ctx, userIDs, blockentity.BlockUser)
+	if err != nil {
+		return 0, err
+	}
+
+	rebuilt := 0
+	for _, userID := range userIDs {
+		tagged := countTagged(likedTags[userID], likers)
+		candidates := make([]int64, 0, len(mutual[userID])+len(tagged))
+		for candidateID := range mutual[userID] {
+			candidates = append(candidates, candidateID)
+		}
+		for candidateID := range tagged {
+			if _, ok := mutual[userID][candidateID]; !ok {
+				candidates = append(candidates, candidateID)
+			}
+		}
+
+		excluded := []int64{userID}
+		for _, ids := range [][]int64{followings[userID], blocked[userID], muted[userID], blockers[userID]} {
+			excluded = append(excluded, ids...)
+		}
+		candidates = blockentity.ExcludeUsers(candidates, excluded)
+
+		err = u.suggestionRepo.SetSuggestions(ctx, userID, rankSuggestions(candidates, mutual[userID], tagged, posts, u.conf.SuggestionSize))
+		if err != nil {
+			u.logger.Errorf("save follow suggestions of user %d failed: %s", userID, err)
+			continue
+		}
+		rebuilt++
+	}
+	return rebuilt, nil
+}
+
+// excluded 用户自己、已关注、拉黑或静音的用户，以及 candidates 中拉黑了用户的人
+func (u *SuggestionUseCase) excluded(ctx context.Context, userID int64, candidates []int64) ([]int64, error) {
+	excluded := []int64{userID}
+	if len(candidates) == 0 {
+		return excluded, nil
+	}
+
+	followings, err := u.followRepo.GetFollowingUsersAll(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	excluded = append(excluded, followings...)
+	for _, blockType := range []blockentity.BlockType{blockentity.BlockUser, blockentity.MuteUser} {
+		targetIDs, err := u.blockRepo.ListTargetIDs(ctx, userID, blockType)
+		if err != nil {
+			return nil, err
+		}
+		excluded = append(excluded, targetIDs...)
+	}
+	blockers, err := u.blockRepo.ListBlockers(ctx, candidates, userID, blockentity.BlockUser)
+	if err != nil {
+		return nil, err
+	}
+	return append(excluded, blockers...), nil
+}
+
+// rankSuggestions 按共同关注数和相同话题数的加权和排序，取前 size 个。
+// 最近发过帖子的用户额外加分，取对数避免发帖多的用户排在共同关注多的用户前面
+func rankSuggestions(candidates []int64, mutual, tagged, posts map[int64]int64, size int) []followentity.Suggestion {
+	suggestions := make([]followentity.Suggestion, len(candidates))
+	for i, userID := range candidates {
+		suggestions[i] = followentity.Suggestion{
+			UserID: userID,
+			Score: weightMutual*float64(mutual[userID]) +
+				weightTag*float64(tagged[userID]) +
+				math.Log1p(float64(posts[userID])),
+		}
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].UserID < suggestions[j].UserID
+	})
+	if len(suggestions) > size {
+		suggestions = suggestions[:size]
+	}
+	return suggestions
+}
+
+// countTagged 用户赞过的话题中每个候选用户出现在多少个话题的点赞最多的用户里
+func countTagged(tagIDs []int64, likers map[int64][]int64) map[int64]int64 {
+	tagged := make(map[int64]int64)
+	for _, tagID := range tagIDs {
+		for _, userID := range likers[tagID] {
+			tagged[userID]++
+		}
+	}
+	return tagged
+}
+
+// appendUnique 把 more 中不在 userIDs 里的用户依次追加到后面
+func appendUnique(userIDs []int64, more []int64) []int64 {
+	seen := make(map[int64]bool, len(userIDs)+len(more))
+	merged := make([]int64, 0, len(userIDs)+len(more))
+	for _, ids := range [][]int64{userIDs, more} {
+		for _, userID := range ids {
+			if !seen[userID] {
+				seen[userID] = true
+				merged = append(merged, userID)
+			}
+		}
+	}
+	return merged
+}
@@ -0,0 +1,44 @@
+package follow
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRankSuggestions(t *testing.T) {
+	mutual := map[int64]int64{1: 2, 2: 1}
+	tagged := map[int64]int64{2: 1, 3: 2}
+	posts := map[int64]int64{1: 100, 3: 1}
+
+	got := rankSuggestions([]int64{3, 2, 1, 4}, mutual, tagged, posts, 3)
+	var ids []int64
+	for _, s := range got {
+		ids = append(ids, s.UserID)
+	}
+	// 1: 6+log(101), 2: 3+2, 3: 4+log(2), 4: 0
+	want := []int64{1, 2, 3}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("rankSuggestions() = %v, want %v", ids, want)
+	}
+}
+
+func TestAppendUnique(t *testing.T) {
+	got := appendUnique([]int64{3, 1}, []int64{1, 2, 3, 4})
+	want := []int64{3, 1, 2, 4}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("appendUnique() = %v, want %v", got, want)
+	}
+}
+
+func TestCountTagged(t *testing.T) {
+	likers := map[int64][]int64{
+		10: {1, 2},
+		20: {2, 3},
+		30: {4},
+	}
+	got := countTagged([]int64{10, 20, 40}, likers)
+	want := map[int64]int64{1: 1, 2: 2, 3: 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("countTagged() = %v, want %v", got, want)
+	}
+}
@@ -3,8 +3,8 @@ package events
 import (
 	"context"
 	v1 "harmoni/app/harmoni/api/mq/v1/like"
-	"harmoni/app/harmoni/internal/entity"
 	likeentity "harmoni/app/harmoni/internal/entity/like"
+	"strings"
 )
 
 type LikeEventsHandler struct {
@@ -30,14 +30,39 @@ func FromEventLikeType(likeType v1.LikeType) likeentity.LikeType {
 }
 
 func (h *LikeEventsHandler) HandleLikeCreated(ctx context.Context, msg *v1.LikeCreatedMessage) error {
+	direction := likeentity.LikeUp
+	if msg.IsDown {
+		direction = likeentity.LikeDown
+	}
+	// 被新回应替换的表情回应记为取消，多个时以逗号分隔
+	for _, replaced := range strings.Split(msg.Replaced, ",") {
+		if replaced == "" {
+			continue
+		}
+		err := h.likeRepo.Save(ctx, &likeentity.Like{
+			UserID:       msg.UserID,
+			TargetUserID: msg.TargetUserID,
+			LikingID:     msg.LikingID,
+			LikeType:     FromEventLikeType(msg.BaseMessage.LikeType),
+			Canceled:     true,
+			Direction:    direction,
+			Reaction:     replaced,
+			CreatedAt:    msg.CreatedAt.AsTime(),
+			UpdatedAt:    msg.CreatedAt.AsTime(),
+		})
+		if err != nil {
+			return err
+		}
+	}
 	return h.likeRepo.Save(ctx, &likeentity.Like{
 		UserID:       msg.UserID,
 		TargetUserID: msg.TargetUserID,
 		LikingID:     msg.LikingID,
 		LikeType:     FromEventLikeType(msg.BaseMessage.LikeType),
 		Canceled:     msg.IsCancel,
-		TimeMixin: entity.TimeMixin{
-			CreatedAt: msg.CreatedAt.AsTime(),
-		},
+		Direction:    direction,
+		Reaction:     msg.Reaction,
+		CreatedAt:    msg.CreatedAt.AsTime(),
+		UpdatedAt:    msg.CreatedAt.AsTime(),
 	})
 }
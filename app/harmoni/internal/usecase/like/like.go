@@ -3,6 +3,7 @@ package like
 import (
 	"context"
 	v1 "harmoni/app/harmoni/api/mq/v1/like"
+	blockentity "harmoni/app/harmoni/internal/entity/block"
 	commententity "harmoni/app/harmoni/internal/entity/comment"
 	likeentity "harmoni/app/harmoni/internal/entity/like"
 	"harmoni/app/harmoni/internal/entity/paginator"
@@ -12,9 +13,15 @@ import (
 	"harmoni/app/harmoni/internal/pkg/reason"
 	event "harmoni/app/harmoni/internal/types/events/like"
 	"harmoni/app/harmoni/internal/types/iface"
+	commentuse "harmoni/app/harmoni/internal/usecase/comment"
 	"harmoni/app/harmoni/internal/usecase/like/events"
 	postuse "harmoni/app/harmoni/internal/usecase/post"
+	useruse "harmoni/app/harmoni/internal/usecase/user"
 	"harmoni/internal/pkg/errorx"
+	"harmoni/internal/types/object"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/google/wire"
 	"go.uber.org/zap"
@@ -27,31 +34,51 @@ var ProviderSetLikeUsecase = wire.NewSet(
 )
 
 type LikeUsecase struct {
+	conf        *config.Like
 	likeRepo    likeentity.LikeRepository
+	blockRepo   blockentity.BlockRepository
 	userRepo    userentity.UserRepository
 	commentRepo commententity.CommentRepository
+	postRepo    postentity.PostRepository
 	postUseCase *postuse.PostUseCase
-	logger      *zap.SugaredLogger
+	// commentUseCase 和 userUseCase 用于填充点赞列表中的评论和用户
+	commentUseCase *commentuse.CommentUseCase
+	userUseCase    *useruse.UserUseCase
+	logger         *zap.SugaredLogger
 
 	publisher iface.Publisher
+
+	// reconciling 同一进程内同时只进行一次对账，手动触发和定时任务共用
+	mu          sync.Mutex
+	reconciling bool
 }
 
 func NewLikeUsecase(
 	conf *config.MessageQueue,
+	likeConf *config.Like,
 	likeRepo likeentity.LikeRepository,
+	blockRepo blockentity.BlockRepository,
 	postUseCase *postuse.PostUseCase,
+	commentUseCase *commentuse.CommentUseCase,
+	userUseCase *useruse.UserUseCase,
 	commentRepo commententity.CommentRepository,
+	postRepo postentity.PostRepository,
 	userRepo userentity.UserRepository,
 	logger *zap.SugaredLogger,
 	publisher iface.Publisher,
 ) (*LikeUsecase, func(), error) {
 	lc := &LikeUsecase{
-		likeRepo:    likeRepo,
-		commentRepo: commentRepo,
-		userRepo:    userRepo,
-		postUseCase: postUseCase,
-		logger:      logger.With("module", "usecase/like"),
-		publisher:   publisher,
+		conf:           likeConf,
+		likeRepo:       likeRepo,
+		blockRepo:      blockRepo,
+		commentRepo:    commentRepo,
+		postRepo:       postRepo,
+		userRepo:       userRepo,
+		postUseCase:    postUseCase,
+		commentUseCase: commentUseCase,
+		userUseCase:    userUseCase,
+		logger:         logger.With("module", "usecase/like"),
+		publisher:      publisher,
 	}
 
 	return lc, func() {
@@ -59,33 +86,79 @@ func NewLikeUsecase(
 	}, nil
 }
 
-func (u *LikeUsecase) Like(ctx context.Context, like *likeentity.Like, isCancel bool) error {
-	var (
-		exist        bool
-		err          error
-		post         *postentity.PostInfo
-		comment      *commententity.Comment
-		targetUserID int64
-	)
-	// TODO: cache post and comment existence
+// targetUserID 被点赞对象的作者
+func (u *LikeUsecase) targetUserID(ctx context.Context, like *likeentity.Like) (int64, error) {
+	var load func(ctx context.Context) (*likeentity.ObjectMeta, error)
 	switch like.LikeType {
 	case likeentity.LikePost:
-		post, exist, err = u.postUseCase.GetByPostID(ctx, 0, like.LikingID)
-		targetUserID = post.User.UserID
+		load = func(ctx context.Context) (*likeentity.ObjectMeta, error) {
+			post, exist, err := u.postRepo.GetBasicInfoByPostID(ctx, like.LikingID)
+			if err != nil || !exist || !post.IsPublished() {
+				return &likeentity.ObjectMeta{}, err
+			}
+			return &likeentity.ObjectMeta{AuthorID: post.AuthorID, Exist: true}, nil
+		}
 	case likeentity.LikeComment:
-		comment, exist, err = u.commentRepo.GetByCommentID(ctx, like.LikingID)
-		targetUserID = comment.Author.UserID
+		load = func(ctx context.Context) (*likeentity.ObjectMeta, error) {
+			// tombstoned comments are kept to hold their replies, but can no longer be liked
+			comment, exist, err := u.commentRepo.GetByCommentID(ctx, like.LikingID)
+			if err != nil || !exist || comment.Deleted {
+				return &likeentity.ObjectMeta{}, err
+			}
+			return &likeentity.ObjectMeta{AuthorID: comment.Author.UserID, Exist: true}, nil
+		}
+	case likeentity.LikeUser:
+		if like.LikingID == like.UserID {
+			return 0, errorx.BadRequest(reason.DisallowLikeYourSelf)
+		}
+		load = func(ctx context.Context) (*likeentity.ObjectMeta, error) {
+			_, exist, err := u.userRepo.GetByUserID(ctx, like.LikingID)
+			return &likeentity.ObjectMeta{AuthorID: like.LikingID, Exist: exist}, err
+		}
 	default:
-		return errorx.BadRequest(reason.LikeUnknownType)
+		return 0, errorx.BadRequest(reason.LikeUnknownType)
 	}
+
+	meta, err := u.likeRepo.GetObjectMeta(ctx, like.LikeType, like.LikingID, load)
+	if err != nil {
+		return 0, err
+	} else if !meta.Exist {
+		return 0, errorx.NotFound(reason.ObjectNotFound)
+	}
+	return meta.AuthorID, nil
+}
+
+// checkBlocked 被作者拉黑的用户不能再点赞，但可以取消之前的点赞
+func (u *LikeUsecase) checkBlocked(ctx context.Context, like *likeentity.Like, targetUserID int64, isCancel bool) error {
+	if isCancel || targetUserID == 0 {
+		return nil
+	}
+	blocked, err := u.blockRepo.Exists(ctx, targetUserID, like.UserID, blockentity.BlockUser)
+	if err != nil {
+		return err
+	} else if blocked {
+		return errorx.Forbidden(reason.BlockedByUser)
+	}
+	return nil
+}
+
+func (u *LikeUsecase) Like(ctx context.Context, like *likeentity.Like, isCancel bool) error {
+	targetUserID, err := u.targetUserID(ctx, like)
+	if err != nil {
+		return err
+	}
+	err = u.checkBlocked(ctx, like, targetUserID, isCancel)
 	if err != nil {
 		return err
-	} else if !exist {
-		return errorx.NotFound(reason.ObjectNotFound)
 	}
 
+	if like.Direction == 0 {
+		like.Direction = likeentity.LikeUp
+	} else if like.Direction == likeentity.LikeDown && like.LikeType == likeentity.LikeUser {
+		return errorx.BadRequest(reason.TypeNotSupport)
+	}
 	like.TargetUserID = targetUserID
-	err = u.likeRepo.Like(ctx, like, like.TargetUserID, isCancel)
+	switched, err := u.likeRepo.Like(ctx, like, like.TargetUserID, isCancel)
 	if err != nil {
 		return err
 	}
@@ -98,6 +171,8 @@ func (u *LikeUsecase) Like(ctx context.Context, like *likeentity.Like, isCancel
 		UserID:       like.UserID,
 		IsCancel:     isCancel,
 		LikingID:     like.LikingID,
+		IsDown:       like.Direction == likeentity.LikeDown,
+		Switched:     switched,
 		CreatedAt:    timestamppb.Now(),
 	}
 
@@ -109,10 +184,134 @@ func (u *LikeUsecase) Like(ctx context.Context, like *likeentity.Like, isCancel
 	return nil
 }
 
-func (u *LikeUsecase) ListLikingIDs(ctx context.Context, query *likeentity.LikeQuery) (paginator.Page[int64], error) {
-	if query.Type == likeentity.LikeComment {
-		return paginator.Page[int64]{}, errorx.BadRequest(reason.TypeNotSupport)
+// React 添加或取消表情回应，表情必须在配置的范围内
+func (u *LikeUsecase) React(ctx context.Context, like *likeentity.Like, isCancel bool) error {
+	if !slices.Contains(u.conf.Reactions, like.Reaction) {
+		return errorx.BadRequest(reason.LikeReactionNotSupported)
+	}
+	targetUserID, err := u.targetUserID(ctx, like)
+	if err != nil {
+		return err
 	}
+	err = u.checkBlocked(ctx, like, targetUserID, isCancel)
+	if err != nil {
+		return err
+	}
+
+	like.Direction = likeentity.LikeUp
+	like.TargetUserID = targetUserID
+	replaced, err := u.likeRepo.React(ctx, like, isCancel)
+	if err != nil {
+		return err
+	}
+
+	msg := v1.LikeCreatedMessage{
+		BaseMessage: &v1.BaseMessage{
+			LikeType: like.LikeType.ToEventLikeType(),
+		},
+		TargetUserID: like.TargetUserID,
+		UserID:       like.UserID,
+		IsCancel:     isCancel,
+		LikingID:     like.LikingID,
+		Reaction:     like.Reaction,
+		Replaced:     replaced,
+		CreatedAt:    timestamppb.Now(),
+	}
+
+	err = u.publisher.Publish(ctx, event.TopicLikeCreated, &msg)
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err)
+	}
+
+	return nil
+}
+
+// GetReactions 对象收到的表情回应数，userID 不为 0 时同时返回该用户的回应
+func (u *LikeUsecase) GetReactions(ctx context.Context, userID int64, likeType likeentity.LikeType, likingID int64) (*likeentity.GetReactionsReply, error) {
+	counts, err := u.likeRepo.BatchReactionCountByIDs(ctx, []int64{likingID}, likeType)
+	if err != nil {
+		return nil, err
+	}
+	reply := &likeentity.GetReactionsReply{
+		Available: u.conf.Reactions,
+		Counts:    counts[likingID],
+		Reacted:   []string{},
+	}
+	if userID != 0 {
+		reactions, err := u.likeRepo.BatchUserReactions(ctx, userID, likeType, []int64{likingID})
+		if err != nil {
+			return nil, err
+		}
+		if len(reactions[likingID]) > 0 {
+			reply.Reacted = reactions[likingID]
+		}
+	}
+	return reply, nil
+}
+
+// ListReceived 按时间倒序列出用户收到的赞并填充点赞的用户
+func (u *LikeUsecase) ListReceived(ctx context.Context, query *likeentity.ReceivedLikeQuery) (*paginator.CursorPage[*likeentity.ReceivedLike], error) {
+	limit := paginator.CursorLimit(query.Limit)
+	query.Limit = limit + 1
+	likes, err := u.likeRepo.ListReceived(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]int64, 0, len(likes))
+	for _, like := range likes {
+		userIDs = append(userIDs, like.UserID)
+	}
+	users, err := u.userUseCase.ListBasicByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	userMap := make(map[int64]*userentity.UserBasicInfo, len(users))
+	for _, user := range users {
+		userMap[user.UserID] = user
+	}
+
+	received := make([]*likeentity.ReceivedLike, len(likes))
+	for i, like := range likes {
+		user, ok := userMap[like.UserID]
+		if !ok {
+			user = &userentity.UserBasicInfo{UserID: like.UserID}
+		}
+		received[i] = &likeentity.ReceivedLike{
+			ID:       like.ID,
+			User:     user,
+			Type:     like.LikeType,
+			LikingID: like.LikingID,
+			Reaction: like.Reaction,
+			LikedAt:  like.UpdatedAt,
+		}
+	}
+
+	page := paginator.NewCursorPage(received, limit, func(l *likeentity.ReceivedLike) int64 {
+		return l.LikedAt.UnixMilli()
+	})
+	return &page, nil
+}
+
+// ReceivedStats 统计用户在 day 当天收到的赞，用于作者的数据卡片
+func (u *LikeUsecase) ReceivedStats(ctx context.Context, userID int64, day time.Time) (*likeentity.GetReceivedLikeStatsReply, error) {
+	since := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	counts, err := u.likeRepo.CountReceivedByObject(ctx, userID, since, since.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &likeentity.GetReceivedLikeStatsReply{
+		Date:    since.Format(time.DateOnly),
+		Objects: counts,
+	}
+	for _, count := range counts {
+		reply.Total += count.Count
+	}
+	return reply, nil
+}
+
+func (u *LikeUsecase) ListLikingIDs(ctx context.Context, query *likeentity.LikeQuery) (paginator.Page[int64], error) {
 	return u.likeRepo.ListLikingIDs(ctx, query)
 }
 
@@ -130,9 +329,9 @@ func (u *LikeUsecase) LikeCount(ctx context.Context, like *likeentity.Like) (int
 
 	switch like.LikeType {
 	case likeentity.LikePost:
-		count, exist, err = u.commentRepo.GetLikeCount(ctx, like.LikingID)
-	case likeentity.LikeComment:
 		count, exist, err = u.postUseCase.GetLikeCount(ctx, like.LikingID)
+	case likeentity.LikeComment:
+		count, exist, err = u.commentRepo.GetLikeCount(ctx, like.LikingID)
 	case likeentity.LikeUser:
 		count, exist, err = u.userRepo.GetLikeCount(ctx, like.LikingID)
 	}
@@ -155,6 +354,10 @@ func (u *LikeUsecase) BatchLikeCount(ctx context.Context, likeType likeentity.Li
 	return u.likeRepo.BatchLikeCount(ctx, likeType)
 }
 
+func (u *LikeUsecase) BatchDislikeCount(ctx context.Context, likeType likeentity.LikeType) (map[int64]int64, error) {
+	return u.likeRepo.BatchDislikeCount(ctx, likeType)
+}
+
 func (u *LikeUsecase) BatchLikeCountByIDs(ctx context.Context, likingIDs []int64, likeType likeentity.LikeType) (map[int64]int64, error) {
 	return u.likeRepo.BatchLikeCountByIDs(ctx, likingIDs, likeType)
 }
@@ -162,18 +365,63 @@ func (u *LikeUsecase) BatchLikeCountByIDs(ctx context.Context, likingIDs []int64
 func (u *LikeUsecase) GetLikingObjects(ctx context.Context, userID int64, objectIDs []int64, likeType likeentity.LikeType) (any, error) {
 	switch likeType {
 	case likeentity.LikePost:
-		postInfos, err := u.postUseCase.BatchByIDs(ctx, objectIDs)
+		// liked posts of private accounts the user no longer follows are left out
+		return u.postUseCase.GetByPostIDs(ctx, userID, objectIDs)
+	case likeentity.LikeComment:
+		return u.getLikedComments(ctx, userID, objectIDs)
+	case likeentity.LikeUser:
+		users, err := u.userUseCase.ListBasicByUserIDs(ctx, objectIDs)
 		if err != nil {
 			return nil, err
 		}
 
-		postInfos, err = u.postUseCase.MergeList(ctx, userID, postInfos)
-		if err != nil {
-			return nil, err
+		userMap := make(map[int64]*userentity.UserBasicInfo, len(users))
+		for _, user := range users {
+			userMap[user.UserID] = user
+		}
+		userInfos := make([]*userentity.UserBasicInfo, 0, len(objectIDs))
+		for _, id := range objectIDs {
+			if user, ok := userMap[id]; ok {
+				userInfos = append(userInfos, user)
+			}
 		}
 
-		return postInfos, nil
+		return userInfos, nil
 	default:
 		return nil, errorx.BadRequest(reason.TypeNotSupport)
 	}
 }
+
+// getLikedComments 按点赞顺序返回评论，帖子下的评论附带所在帖子
+func (u *LikeUsecase) getLikedComments(ctx context.Context, userID int64, commentIDs []int64) ([]likeentity.LikedComment, error) {
+	comments, err := u.commentUseCase.GetByCommentIDs(ctx, userID, commentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	postIDs := make([]int64, 0, len(comments))
+	for _, comment := range comments {
+		if comment.ObjectType == object.ObjectTypePost {
+			postIDs = append(postIDs, comment.ObjectID)
+		}
+	}
+	postMap := make(map[int64]*postentity.PostBasicInfo, len(postIDs))
+	if len(postIDs) > 0 {
+		postInfos, err := u.postUseCase.GetByPostIDs(ctx, userID, postIDs)
+		if err != nil {
+			return nil, err
+		}
+		for i := range postInfos {
+			postMap[postInfos[i].PostID] = &postInfos[i]
+		}
+	}
+
+	liked := make([]likeentity.LikedComment, len(comments))
+	for i, comment := range comments {
+		liked[i].Comment = comment
+		if comment.ObjectType == object.ObjectTypePost {
+			liked[i].Post = postMap[comment.ObjectID]
+		}
+	}
+	return liked, nil
+}
@@ -0,0 +1,42 @@
+package like
+
+import (
+	"context"
+	likeentity "harmoni/app/harmoni/internal/entity/like"
+)
+
+// RebuildObjectFilter 从数据库重建帖子、评论和用户的布隆过滤器
+func (u *LikeUsecase) RebuildObjectFilter(ctx context.Context) error {
+	err := u.likeRepo.RebuildObjectFilter(ctx, likeentity.LikePost, func(after int64) ([]int64, error) {
+		posts, err := u.postRepo.ListPublishedAfter(ctx, after, likeReconcileBatchSize)
+		ids := make([]int64, len(posts))
+		for i := range posts {
+			ids[i] = posts[i].PostID
+		}
+		return ids, err
+	})
+	if err != nil {
+		return err
+	}
+
+	err = u.likeRepo.RebuildObjectFilter(ctx, likeentity.LikeComment, func(after int64) ([]int64, error) {
+		comments, err := u.commentRepo.ListAfter(ctx, after, likeReconcileBatchSize)
+		ids := make([]int64, len(comments))
+		for i := range comments {
+			ids[i] = comments[i].CommentID
+		}
+		return ids, err
+	})
+	if err != nil {
+		return err
+	}
+
+	return u.likeRepo.RebuildObjectFilter(ctx, likeentity.LikeUser, func(after int64) ([]int64, error) {
+		users, err := u.userRepo.ListAfter(ctx, after, likeReconcileBatchSize)
+		ids := make([]int64, len(users))
+		for i := range users {
+			ids[i] = users[i].UserID
+		}
+		return ids, err
+	})
+}
@@ -0,0 +1,240 @@
+package like
+
+import (
+	"context"
+	likeentity "harmoni/app/harmoni/internal/entity/like"
+	"harmoni/app/harmoni/internal/pkg/counter"
+	"harmoni/app/harmoni/internal/pkg/reason"
+	"harmoni/internal/pkg/errorx"
+	"time"
+)
+
+const likeReconcileBatchSize = 500
+
+// likeCounter 数据库中保存的点赞数
+type likeCounter struct {
+	id       int64
+	likes    int64
+	dislikes int64
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Reconcile 从点赞表重新统计帖子、评论和用户的点赞数，修复缓存和数据库中的偏差。
+// 已经在对账时返回 LikeReconcileInProgress
+func (u *LikeUsecase) Reconcile(ctx context.Context) ([]likeentity.LikeDrift, error) {
+	if !u.startReconcile() {
+		return nil, errorx.BadRequest(reason.LikeReconcileInProgress)
+	}
+	defer u.finishReconcile()
+
+	return u.reconcileAll(ctx)
+}
+
+// ReconcileInBackground 在后台对账，完成后调用 done。已经在对账时不会开始，返回 LikeReconcileInProgress
+func (u *LikeUsecase) ReconcileInBackground(done func([]likeentity.LikeDrift, error)) error {
+	if !u.startReconcile() {
+		return errorx.BadRequest(reason.LikeReconcileInProgress)
+	}
+
+	go func() {
+		defer u.finishReconcile()
+		done(u.reconcileAll(context.Background()))
+	}()
+	return nil
+}
+
+func (u *LikeUsecase) startReconcile() bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.reconciling {
+		return false
+	}
+	u.reconciling = true
+	return true
+}
+
+func (u *LikeUsecase) finishReconcile() {
+	u.mu.Lock()
+	u.reconciling = false
+	u.mu.Unlock()
+}
+
+func (u *LikeUsecase) reconcileAll(ctx context.Context) ([]likeentity.LikeDrift, error) {
+	drifts := make([]likeentity.LikeDrift, 0, len(likeentity.LikeTypeList))
+
+	drift, err := u.reconcile(ctx, likeentity.LikePost, func(after int64) ([]likeCounter, error) {
+		posts, err := u.postRepo.ListPublishedAfter(ctx, after, likeReconcileBatchSize)
+		counters := make([]likeCounter, len(posts))
+		for i, post := range posts {
+			counters[i] = likeCounter{id: post.PostID, likes: post.LikeCount, dislikes: post.DislikeCount}
+		}
+		return counters, err
+	}, func(c likeCounter) error {
+		if err := u.postRepo.UpdateLikeCount(ctx, c.id, c.likes); err != nil {
+			return err
+		}
+		return u.postRepo.UpdateDislikeCount(ctx, c.id, c.dislikes)
+	})
+	if err != nil {
+		return drifts, err
+	}
+	drifts = append(drifts, drift)
+
+	drift, err = u.reconcile(ctx, likeentity.LikeComment, func(after int64) ([]likeCounter, error) {
+		comments, err := u.commentRepo.ListAfter(ctx, after, likeReconcileBatchSize)
+		counters := make([]likeCounter, len(comments))
+		for i, comment := range comments {
+			counters[i] = likeCounter{id: comment.CommentID, likes: comment.LikeCount, dislikes: comment.DislikeCount}
+		}
+		return counters, err
+	}, func(c likeCounter) error {
+		if err := u.commentRepo.UpdateLikeCount(ctx, c.id, c.likes); err != nil {
+			return err
+		}
+		return u.commentRepo.UpdateDislikeCount(ctx, c.id, c.dislikes)
+	})
+	if err != nil {
+		return drifts, err
+	}
+	drifts = append(drifts, drift)
+
+	drift, err = u.reconcile(ctx, likeentity.LikeUser, func(after int64) ([]likeCounter, error) {
+		users, err := u.userRepo.ListAfter(ctx, after, likeReconcileBatchSize)
+		counters := make([]likeCounter, len(users))
+		for i, user := range users {
+			counters[i] = likeCounter{id: user.UserID, likes: user.LikeCount}
+		}
+		return counters, err
+	}, func(c likeCounter) error {
+		return u.userRepo.UpdateLikeCount(ctx, c.id, c.likes)
+	})
+	if err != nil {
+		return drifts, err
+	}
+	return append(drifts, drift), nil
+}
+
+// countLikes 从点赞表统计对象的赞数和踩数，用户的点赞数是用户收到的所有赞
+func (u *LikeUsecase) countLikes(ctx context.Context, likeType likeentity.LikeType, ids []int64) (map[int64]int64, map[int64]int64, error) {
+	if likeType == likeentity.LikeUser {
+		likes, err := u.likeRepo.CountByTargetUserIDs(ctx, ids)
+		return likes, map[int64]int64{}, err
+	}
+	return u.likeRepo.CountByLikingIDs(ctx, likeType, ids)
+}
+
+// reconcile 分批遍历一种对象找出有偏差的对象。点赞记录异步写入点赞表，
+// 偏差等待 ReconcileSettle 后重新统计确认，缓存只在这段时间内没有新的投票时覆盖
+func (u *LikeUsecase) reconcile(ctx context.Context, likeType likeentity.LikeType,
+	list func(after int64) ([]likeCounter, error), update func(c likeCounter) error) (likeentity.LikeDrift, error) {
+	drift := likeentity.LikeDrift{Type: likeType}
+	drifted := []likeCounter{}
+	cachedLikes, cachedDislikes := map[int64]int64{}, map[int64]int64{}
+	var after int64
+	for {
+		counters, err := list(after)
+		if err != nil {
+			return drift, err
+		}
+		if len(counters) == 0 {
+			break
+		}
+
+		ids := make([]int64, len(counters))
+		for i := range counters {
+			ids[i] = counters[i].id
+		}
+		likes, dislikes, err := u.countLikes(ctx, likeType, ids)
+		if err != nil {
+			return drift, err
+		}
+		cachedL, cachedD, err := u.likeRepo.CachedCounts(ctx, likeType, ids)
+		if err != nil {
+			return drift, err
+		}
+
+		for _, c := range counters {
+			drift.Scanned++
+			// objects not in the cache are loaded from the like table on demand
+			likeCached, likeOK := cachedL[c.id]
+			dislikeCached, dislikeOK := cachedD[c.id]
+			if (!likeOK || likeCached == likes[c.id]) && (!dislikeOK || dislikeCached == dislikes[c.id]) &&
+				c.likes == likes[c.id] && c.dislikes == dislikes[c.id] {
+				continue
+			}
+			drifted = append(drifted, c)
+			if likeOK {
+				cachedLikes[c.id] = likeCached
+			}
+			if dislikeOK {
+				cachedDislikes[c.id] = dislikeCached
+			}
+		}
+
+		after = counters[len(counters)-1].id
+		if len(counters) < likeReconcileBatchSize {
+			break
+		}
+	}
+	if len(drifted) == 0 {
+		return drift, nil
+	}
+
+	timer := time.NewTimer(u.conf.ReconcileSettle)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return drift, ctx.Err()
+	case <-timer.C:
+	}
+
+	for start := 0; start < len(drifted); start += likeReconcileBatchSize {
+		counters := drifted[start:min(start+likeReconcileBatchSize, len(drifted))]
+		ids := make([]int64, len(counters))
+		for i := range counters {
+			ids[i] = counters[i].id
+		}
+		likes, dislikes, err := u.countLikes(ctx, likeType, ids)
+		if err != nil {
+			return drift, err
+		}
+
+		resetLikes, resetDislikes := []counter.Swap{}, []counter.Swap{}
+		for _, c := range counters {
+			cacheDrift := int64(0)
+			if cached, ok := cachedLikes[c.id]; ok && cached != likes[c.id] {
+				cacheDrift += abs(cached - likes[c.id])
+				resetLikes = append(resetLikes, counter.Swap{ID: c.id, Old: cached, New: likes[c.id]})
+			}
+			if cached, ok := cachedDislikes[c.id]; ok && cached != dislikes[c.id] {
+				cacheDrift += abs(cached - dislikes[c.id])
+				resetDislikes = append(resetDislikes, counter.Swap{ID: c.id, Old: cached, New: dislikes[c.id]})
+			}
+			if cacheDrift != 0 {
+				drift.CacheDrifted++
+				drift.Drift += cacheDrift
+			}
+
+			if c.likes == likes[c.id] && c.dislikes == dislikes[c.id] {
+				continue
+			}
+			drift.DatabaseDrifted++
+			drift.Drift += abs(c.likes-likes[c.id]) + abs(c.dislikes-dislikes[c.id])
+			err = update(likeCounter{id: c.id, likes: likes[c.id], dislikes: dislikes[c.id]})
+			if err != nil {
+				return drift, err
+			}
+		}
+		err = u.likeRepo.ResetCounts(ctx, likeType, resetLikes, resetDislikes)
+		if err != nil {
+			return drift, err
+		}
+	}
+	return drift, nil
+}
@@ -0,0 +1,59 @@
+package post
+
+import (
+	"context"
+)
+
+const commentReconcileBatchSize = 500
+
+// BatchCommentCount 缓存中的帖子评论数，由定时任务同步到数据库
+func (u *PostUseCase) BatchCommentCount(ctx context.Context) (map[int64]int64, error) {
+	return u.commentRepo.BatchCommentCount(ctx)
+}
+
+// ReconcileCommentCount 从评论表重新统计所有已发布帖子的评论数，覆盖缓存和数据库中的值
+func (u *PostUseCase) ReconcileCommentCount(ctx context.Context) (int, error) {
+	reconciled := 0
+	var lastPostID int64
+	for {
+		posts, err := u.postRepo.ListPublishedAfter(ctx, lastPostID, commentReconcileBatchSize)
+		if err != nil {
+			return reconciled, err
+		}
+		if len(posts) == 0 {
+			return reconciled, nil
+		}
+
+		postIDs := make([]int64, len(posts))
+		for i := range posts {
+			postIDs[i] = posts[i].PostID
+		}
+		counts, err := u.commentRepo.CountByObjectIDs(ctx, postIDs)
+		if err != nil {
+			return reconciled, err
+		}
+
+		for _, post := range posts {
+			// posts without comments are reset to zero as well
+			count := counts[post.PostID]
+			counts[post.PostID] = count
+			if post.CommentCount == count {
+				continue
+			}
+			err = u.postRepo.UpdateCommentCount(ctx, post.PostID, count)
+			if err != nil {
+				return reconciled, err
+			}
+			reconciled++
+		}
+		err = u.commentRepo.ResetCommentCounts(ctx, counts)
+		if err != nil {
+			return reconciled, err
+		}
+
+		lastPostID = posts[len(posts)-1].PostID
+		if len(posts) < commentReconcileBatchSize {
+			return reconciled, nil
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package post
+
+import (
+	"context"
+	"harmoni/app/harmoni/internal/entity"
+	"harmoni/app/harmoni/internal/entity/paginator"
+	postentity "harmoni/app/harmoni/internal/entity/post"
+	"harmoni/app/harmoni/internal/pkg/reason"
+	"harmoni/internal/pkg/errorx"
+	"time"
+)
+
+const publishBatchSize = 100
+
+func (u *PostUseCase) getOwnDraft(ctx context.Context, userID int64, postID int64) (*postentity.Post, error) {
+	post, exist, err := u.postRepo.GetBasicInfoByPostID(ctx, postID)
+	if err != nil {
+		return nil, err
+	} else if !exist {
+		return nil, errorx.NotFound(reason.PostNotFound)
+	} else if post.AuthorID != userID {
+		return nil, errorx.Forbidden(reason.PostEditWithoutPermission)
+	} else if post.IsPublished() {
+		return nil, errorx.BadRequest(reason.PostAlreadyPublished)
+	}
+
+	return post, nil
+}
+
+func (u *PostUseCase) CreateDraft(ctx context.Context, post *postentity.Post) (*postentity.PostInfo, error) {
+	post.Status = postentity.PostStatusDraft
+	return u.Create(ctx, post)
+}
+
+// SaveDraft 自动保存草稿，定时发布的帖子保存后仍按原定时间发布
+func (u *PostUseCase) SaveDraft(ctx context.Context, userID int64, post *postentity.Post) (*postentity.PostInfo, error) {
+	origin, err := u.getOwnDraft(ctx, userID, post.PostID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := u.checkTags(ctx, post.TagIDs)
+	if err != nil {
+		return nil, err
+	}
+	post.MentionIDs, err = u.userUsecase.ResolveMentions(ctx, post.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.postRepo.SaveDraft(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+
+	origin.Title = post.Title
+	origin.Content = post.Content
+	origin.MentionIDs = post.MentionIDs
+	postInfo := origin.ToInfo()
+	err = u.setTagInfos(ctx, &postInfo.PostBasicInfo, tags)
+	if err != nil {
+		return nil, err
+	}
+	err = u.Merge(ctx, userID, &postInfo, tags)
+	if err != nil {
+		return nil, err
+	}
+	return &postInfo, nil
+}
+
+// Publish 立即发布草稿，publishAt 晚于当前时间时改为定时发布
+func (u *PostUseCase) Publish(ctx context.Context, userID int64, postID int64, publishAt *time.Time) (*postentity.PostInfo, error) {
+	post, err := u.getOwnDraft(ctx, userID, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.Title == "" || post.Content == "" {
+		return nil, errorx.BadRequest(reason.PostDraftIncomplete)
+	}
+
+	if publishAt != nil && publishAt.After(time.Now()) {
+		err = u.postRepo.Schedule(ctx, postID, *publishAt)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		now := time.Now()
+		published, err := u.postRepo.Publish(ctx, postID, now)
+		if err != nil {
+			return nil, err
+		} else if published {
+			u.publishCreated(ctx, post, now)
+			u.publishMentioned(ctx, post, post.MentionIDs, now)
+		}
+	}
+
+	postInfo, exist, err := u.GetByPostID(ctx, userID, postID)
+	if err != nil {
+		return nil, err
+	} else if !exist {
+		return nil, errorx.NotFound(reason.PostNotFound)
+	}
+	err = u.setTagInfos(ctx, &postInfo.PostBasicInfo, nil)
+	if err != nil {
+		return nil, err
+	}
+	return postInfo, nil
+}
+
+// GetDraftPage 用户的草稿和定时发布的帖子
+func (u *PostUseCase) GetDraftPage(ctx context.Context, userID int64, pageCond *entity.PageCond) (*paginator.Page[postentity.PostBasicInfo], error) {
+	posts, err := u.postRepo.GetDraftPage(ctx, userID, pageCond)
+	if err != nil {
+		return nil, err
+	}
+
+	postInfos := make([]postentity.PostBasicInfo, len(posts.Data))
+	for i, v := range posts.Data {
+		postInfos[i] = v.ToBasic()
+	}
+
+	postInfos, err = u.MergeList(ctx, userID, postInfos)
+	if err != nil {
+		return nil, err
+	}
+
+	return &paginator.Page[postentity.PostBasicInfo]{
+		CurrentPage: posts.CurrentPage,
+		PageSize:    posts.PageSize,
+		Pages:       posts.Pages,
+		Total:       posts.Total,
+		Data:        postInfos,
+	}, nil
+}
+
+// PublishScheduled 发布所有已到发布时间的定时帖子
+func (u *PostUseCase) PublishScheduled(ctx context.Context) (int, error) {
+	published := 0
+	for {
+		posts, err := u.postRepo.ListScheduledBefore(ctx, time.Now(), publishBatchSize)
+		if err != nil {
+			return published, err
+		}
+
+		for i := range posts {
+			ok, err := u.postRepo.Publish(ctx, posts[i].PostID, *posts[i].ScheduledAt)
+			if err != nil {
+				return published, err
+			} else if ok {
+				u.publishCreated(ctx, &posts[i], *posts[i].ScheduledAt)
+				u.publishMentioned(ctx, &posts[i], posts[i].MentionIDs, *posts[i].ScheduledAt)
+				published++
+			}
+		}
+
+		if len(posts) < publishBatchSize {
+			return published, nil
+		}
+	}
+}
@@ -2,16 +2,25 @@ package events
 
 import (
 	"context"
+	v1comment "harmoni/app/harmoni/api/mq/v1/comment"
+	v1 "harmoni/app/harmoni/api/mq/v1/like"
 	postentity "harmoni/app/harmoni/internal/entity/post"
+	"harmoni/app/harmoni/internal/infrastructure/config"
+	eventcollection "harmoni/app/harmoni/internal/types/events/collection"
+	eventcomment "harmoni/app/harmoni/internal/types/events/comment"
 	event "harmoni/app/harmoni/internal/types/events/like"
+	eventpost "harmoni/app/harmoni/internal/types/events/post"
+	"harmoni/internal/types/object"
 )
 
 type PostEventsHandler struct {
+	conf     *config.Post
 	postRepo postentity.PostRepository
 }
 
-func NewPostEventsHandler(postRepo postentity.PostRepository) *PostEventsHandler {
+func NewPostEventsHandler(conf *config.Post, postRepo postentity.PostRepository) *PostEventsHandler {
 	return &PostEventsHandler{
+		conf:     conf,
 		postRepo: postRepo,
 	}
 }
@@ -25,5 +34,96 @@ func (h *PostEventsHandler) HandleLikeStore(ctx context.Context, msg *event.Like
 			return err
 		}
 	}
+	for k, v := range msg.DislikeCounts {
+		if err := h.postRepo.UpdateDislikeCount(ctx, k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *PostEventsHandler) HandleCollectStore(ctx context.Context, msg *eventcollection.CollectStoreMessage) error {
+	for k, v := range msg.Counts {
+		if err := h.postRepo.UpdateCollectCount(ctx, k, v); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+func (h *PostEventsHandler) HandleCommentStore(ctx context.Context, msg *eventcomment.CommentStoreMessage) error {
+	for k, v := range msg.Counts {
+		if err := h.postRepo.UpdateCommentCount(ctx, k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// refreshHot 累加帖子的互动分值并更新热榜，未发布或已删除的帖子不进入热榜
+func (h *PostEventsHandler) refreshHot(ctx context.Context, postID int64, delta float64) error {
+	post, exist, err := h.postRepo.GetBasicInfoByPostID(ctx, postID)
+	if err != nil {
+		return err
+	} else if !exist || !post.IsPublished() {
+		return nil
+	}
+
+	points, err := h.postRepo.IncrHotPoints(ctx, postID, delta)
+	if err != nil {
+		return err
+	}
+	score := postentity.HotScore(points, post.CreatedAt, h.conf.HotDecay)
+	return h.postRepo.UpdateHotRank(ctx, postID, score, h.conf.HotRankSize)
+}
+
+func (h *PostEventsHandler) HandleLikeCreated(ctx context.Context, msg *v1.LikeCreatedMessage) error {
+	if msg.BaseMessage.GetLikeType() != v1.LikeType_LikePost || msg.Reaction != "" {
+		return nil
+	}
+	// 踩不增加热度，从赞改为踩时收回点赞的热度
+	delta := postentity.HotLikePoints
+	switch {
+	case msg.IsDown && msg.Switched:
+		delta = -delta
+	case msg.IsDown:
+		return nil
+	case msg.IsCancel:
+		delta = -delta
+	}
+	return h.refreshHot(ctx, msg.LikingID, delta)
+}
+
+// HandleCommentCreated 新评论把帖子顶到回复时间排序的前面，同时增加热度
+func (h *PostEventsHandler) HandleCommentCreated(ctx context.Context, msg *v1comment.CommentCreatedMessage) error {
+	if msg.ObjectType != v1comment.ObjectType_ObjectPost {
+		return nil
+	}
+	if msg.CreatedAt != nil {
+		err := h.postRepo.UpdateRepliedAt(ctx, msg.ObjectID, msg.CreatedAt.AsTime())
+		if err != nil {
+			return err
+		}
+	}
+	return h.refreshHot(ctx, msg.ObjectID, postentity.HotCommentPoints)
+}
+
+func (h *PostEventsHandler) HandleCommentDeleted(ctx context.Context, msg *eventcomment.CommentDeletedMessage) error {
+	if msg.ObjectType != object.ObjectTypePost {
+		return nil
+	}
+	return h.refreshHot(ctx, msg.ObjectID, -postentity.HotCommentPoints)
+}
+
+func (h *PostEventsHandler) HandlePostCreated(ctx context.Context, msg *eventpost.PostCreatedMessage) error {
+	return h.refreshHot(ctx, msg.PostID, 0)
+}
+
+// HandlePostUpdated 话题可能发生变化，重新放入对应话题的热榜
+func (h *PostEventsHandler) HandlePostUpdated(ctx context.Context, msg *eventpost.PostUpdatedMessage) error {
+	return h.refreshHot(ctx, msg.PostID, 0)
+}
+
+func (h *PostEventsHandler) HandlePostDeleted(ctx context.Context, msg *eventpost.PostDeletedMessage) error {
+	return h.postRepo.RemoveHotRank(ctx, msg.PostID)
+}
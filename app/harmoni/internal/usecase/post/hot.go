@@ -0,0 +1,61 @@
+package post
+
+import (
+	"context"
+	likeentity "harmoni/app/harmoni/internal/entity/like"
+	postentity "harmoni/app/harmoni/internal/entity/post"
+)
+
+const hotRebuildBatchSize = 500
+
+// RebuildHotRank 按点赞数和评论数重新计算所有已发布帖子的互动分值和热榜
+func (u *PostUseCase) RebuildHotRank(ctx context.Context) (int, error) {
+	rebuilt := 0
+	var lastPostID int64
+	for {
+		posts, err := u.postRepo.ListPublishedAfter(ctx, lastPostID, hotRebuildBatchSize)
+		if err != nil {
+			return rebuilt, err
+		}
+		if len(posts) == 0 {
+			return rebuilt, nil
+		}
+
+		postIDs := make([]int64, len(posts))
+		for i := range posts {
+			postIDs[i] = posts[i].PostID
+		}
+		// like counts in cache are newer than those stored in post
+		likes, err := u.likeRepo.BatchLikeCountByIDs(ctx, postIDs, likeentity.LikePost)
+		if err != nil {
+			return rebuilt, err
+		}
+		comments, err := u.commentRepo.CountByObjectIDs(ctx, postIDs)
+		if err != nil {
+			return rebuilt, err
+		}
+
+		for _, post := range posts {
+			likeCount, ok := likes[post.PostID]
+			if !ok {
+				likeCount = post.LikeCount
+			}
+			points := float64(likeCount)*postentity.HotLikePoints + float64(comments[post.PostID])*postentity.HotCommentPoints
+			err = u.postRepo.SetHotPoints(ctx, post.PostID, points)
+			if err != nil {
+				return rebuilt, err
+			}
+			score := postentity.HotScore(points, post.CreatedAt, u.conf.HotDecay)
+			err = u.postRepo.UpdateHotRank(ctx, post.PostID, score, u.conf.HotRankSize)
+			if err != nil {
+				return rebuilt, err
+			}
+		}
+
+		rebuilt += len(posts)
+		lastPostID = posts[len(posts)-1].PostID
+		if len(posts) < hotRebuildBatchSize {
+			return rebuilt, nil
+		}
+	}
+}
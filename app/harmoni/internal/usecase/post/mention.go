@@ -0,0 +1,86 @@
+package post
+
+import (
+	"context"
+	v1 "harmoni/app/harmoni/api/mq/v1/mention"
+	blockentity "harmoni/app/harmoni/internal/entity/block"
+	postentity "harmoni/app/harmoni/internal/entity/post"
+	userentity "harmoni/app/harmoni/internal/entity/user"
+	"harmoni/app/harmoni/internal/pkg/mention"
+	eventmention "harmoni/app/harmoni/internal/types/events/mention"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// publishMentioned 通知被提及的用户，草稿中的提及在发布时才通知，拉黑了作者的用户不会收到通知
+func (u *PostUseCase) publishMentioned(ctx context.Context, post *postentity.Post, mentionIDs []int64, createdAt time.Time) {
+	if len(mentionIDs) == 0 {
+		return
+	}
+	blockers, err := u.blockRepo.ListBlockers(ctx, mentionIDs, post.AuthorID, blockentity.BlockUser)
+	if err != nil {
+		u.logger.Errorf("list blockers of user %d failed: %s", post.AuthorID, err)
+		return
+	}
+	mentionIDs = blockentity.ExcludeUsers(mentionIDs, blockers)
+	if len(mentionIDs) == 0 {
+		return
+	}
+	u.publish(ctx, eventmention.TopicMentionCreated, &v1.MentionCreatedMessage{
+		UserID:           post.AuthorID,
+		MentionedUserIDs: mentionIDs,
+		ObjectType:       v1.ObjectType_ObjectPost,
+		ObjectID:         post.PostID,
+		CreatedAt:        timestamppb.New(createdAt),
+	})
+}
+
+// newMentions 编辑后新增的提及
+func newMentions(origin []int64, current []int64) []int64 {
+	visited := make(map[int64]bool, len(origin))
+	for _, userID := range origin {
+		visited[userID] = true
+	}
+	added := []int64{}
+	for _, userID := range current {
+		if !visited[userID] {
+			added = append(added, userID)
+		}
+	}
+	return added
+}
+
+// setMentions 填充被提及用户的信息，并把内容中的提及渲染为用户主页链接
+func (u *PostUseCase) setMentions(ctx context.Context, postInfos []*postentity.PostBasicInfo) error {
+	userIDs := []int64{}
+	for _, postInfo := range postInfos {
+		for _, mentioned := range postInfo.Mentions {
+			userIDs = append(userIDs, mentioned.UserID)
+		}
+	}
+	users, err := u.userUsecase.ListBasicByUserIDs(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+	userMap := make(map[int64]*userentity.UserBasicInfo, len(users))
+	for _, user := range users {
+		userMap[user.UserID] = user
+	}
+
+	for _, postInfo := range postInfos {
+		names := make(map[string]int64, len(postInfo.Mentions))
+		mentions := make([]*userentity.UserBasicInfo, 0, len(postInfo.Mentions))
+		for _, mentioned := range postInfo.Mentions {
+			user, ok := userMap[mentioned.UserID]
+			if !ok {
+				continue
+			}
+			mentions = append(mentions, user)
+			names[user.Name] = user.UserID
+		}
+		postInfo.Mentions = mentions
+		postInfo.RenderedContent = mention.Render(postInfo.Content, names)
+	}
+	return nil
+}
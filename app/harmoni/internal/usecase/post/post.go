@@ -2,16 +2,26 @@ package post
 
 import (
 	"context"
+	"harmoni/app/harmoni/internal/entity"
+	blockentity "harmoni/app/harmoni/internal/entity/block"
+	collectionentity "harmoni/app/harmoni/internal/entity/collection"
+	commententity "harmoni/app/harmoni/internal/entity/comment"
 	likeentity "harmoni/app/harmoni/internal/entity/like"
 	"harmoni/app/harmoni/internal/entity/paginator"
 	postentity "harmoni/app/harmoni/internal/entity/post"
 	tagentity "harmoni/app/harmoni/internal/entity/tag"
 	userentity "harmoni/app/harmoni/internal/entity/user"
+	"harmoni/app/harmoni/internal/infrastructure/config"
+	"harmoni/app/harmoni/internal/pkg/diff"
 	"harmoni/app/harmoni/internal/pkg/reason"
+	eventpost "harmoni/app/harmoni/internal/types/events/post"
+	"harmoni/app/harmoni/internal/types/iface"
 	"harmoni/app/harmoni/internal/usecase/post/events"
 	taguse "harmoni/app/harmoni/internal/usecase/tag"
 	useruse "harmoni/app/harmoni/internal/usecase/user"
 	"harmoni/internal/pkg/errorx"
+	"html"
+	"time"
 
 	"github.com/google/wire"
 	"go.uber.org/zap"
@@ -23,29 +33,67 @@ var ProviderSetPost = wire.NewSet(
 )
 
 type PostUseCase struct {
-	postRepo    postentity.PostRepository
-	likeRepo    likeentity.LikeRepository
-	userUsecase *useruse.UserUseCase
-	tagUsecase  *taguse.TagUseCase
-	logger      *zap.SugaredLogger
+	conf           *config.Post
+	postRepo       postentity.PostRepository
+	likeRepo       likeentity.LikeRepository
+	blockRepo      blockentity.BlockRepository
+	commentRepo    commententity.CommentRepository
+	collectionRepo collectionentity.CollectionRepository
+	userUsecase    *useruse.UserUseCase
+	tagUsecase     *taguse.TagUseCase
+	publisher      iface.Publisher
+	logger         *zap.SugaredLogger
 }
 
 func NewPostUseCase(
+	conf *config.Post,
 	postRepo postentity.PostRepository,
 	likeRepo likeentity.LikeRepository,
+	blockRepo blockentity.BlockRepository,
+	commentRepo commententity.CommentRepository,
+	collectionRepo collectionentity.CollectionRepository,
 	userUsecase *useruse.UserUseCase,
 	tagUsecase *taguse.TagUseCase,
+	publisher iface.Publisher,
 	logger *zap.SugaredLogger,
 ) *PostUseCase {
 	return &PostUseCase{
-		postRepo:    postRepo,
-		tagUsecase:  tagUsecase,
-		likeRepo:    likeRepo,
-		userUsecase: userUsecase,
-		logger:      logger,
+		conf:           conf,
+		postRepo:       postRepo,
+		commentRepo:    commentRepo,
+		collectionRepo: collectionRepo,
+		tagUsecase:     tagUsecase,
+		likeRepo:       likeRepo,
+		blockRepo:      blockRepo,
+		userUsecase:    userUsecase,
+		publisher:      publisher,
+		logger:         logger,
 	}
 }
 
+// publish 帖子事件用于更新搜索索引等衍生数据，发送失败不影响帖子本身的写入
+func (u *PostUseCase) publish(ctx context.Context, topic string, msg interface{}) {
+	err := u.publisher.Publish(ctx, topic, msg)
+	if err != nil {
+		u.logger.Errorf("send %s msg to mq failed: %s", topic, err)
+	}
+}
+
+func (u *PostUseCase) publishCreated(ctx context.Context, post *postentity.Post, createdAt time.Time) {
+	// the post can be liked as soon as it is published
+	err := u.likeRepo.AddObject(ctx, likeentity.LikePost, post.PostID, post.AuthorID)
+	if err != nil {
+		u.logger.Errorf("add post %d to object filter failed: %s", post.PostID, err)
+	}
+	u.publish(ctx, eventpost.TopicPostCreated, &eventpost.PostCreatedMessage{
+		BaseMessage: eventpost.BaseMessage{
+			PostID:   post.PostID,
+			AuthorID: post.AuthorID,
+		},
+		CreatedAt: &createdAt,
+	})
+}
+
 func (u *PostUseCase) setTagInfos(ctx context.Context, postInfo *postentity.PostBasicInfo, tags []tagentity.Tag) error {
 	var err error
 
@@ -64,20 +112,72 @@ func (u *PostUseCase) setTagInfos(ctx context.Context, postInfo *postentity.Post
 	return nil
 }
 
+// setTagInfoList 一次查询填充所有帖子的标签
+func (u *PostUseCase) setTagInfoList(ctx context.Context, postInfos []*postentity.PostBasicInfo) error {
+	postIDs := make([]int64, len(postInfos))
+	for i, postInfo := range postInfos {
+		postIDs[i] = postInfo.PostID
+	}
+	tagMap, err := u.tagUsecase.GetTagsByPostIDs(ctx, postIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, postInfo := range postInfos {
+		tags := tagMap[postInfo.PostID]
+		postInfo.Tags = make([]tagentity.TagInfo, len(tags))
+		for i, tag := range tags {
+			postInfo.Tags[i] = tag.ToBasicInfo()
+		}
+	}
+	return nil
+}
+
+func deactivatedUser() *userentity.UserBasicInfo {
+	return &userentity.UserBasicInfo{
+		UserID: -1,
+		Name:   "deactivated",
+	}
+}
+
 func (u *PostUseCase) setUser(ctx context.Context, postInfo *postentity.PostBasicInfo, userID int64) error {
 	author, exist, err := u.userUsecase.GetBasicByUserID(ctx, userID)
 	if err != nil {
 		return err
 	} else if !exist {
-		author = &userentity.UserBasicInfo{
-			UserID: -1,
-			Name:   "deactivated",
-		}
+		author = deactivatedUser()
 	}
 	postInfo.User = author
 	return nil
 }
 
+// setUsers 批量填充帖子的作者，头像链接也是批量获取的
+func (u *PostUseCase) setUsers(ctx context.Context, postInfos []*postentity.PostBasicInfo) error {
+	userIDs := make([]int64, 0, len(postInfos))
+	for _, postInfo := range postInfos {
+		if postInfo.User != nil {
+			userIDs = append(userIDs, postInfo.User.UserID)
+		}
+	}
+	users, err := u.userUsecase.ListBasicByUserIDs(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+	userMap := make(map[int64]*userentity.UserBasicInfo, len(users))
+	for _, user := range users {
+		userMap[user.UserID] = user
+	}
+
+	for _, postInfo := range postInfos {
+		if postInfo.User == nil || userMap[postInfo.User.UserID] == nil {
+			postInfo.User = deactivatedUser()
+			continue
+		}
+		postInfo.User = userMap[postInfo.User.UserID]
+	}
+	return nil
+}
+
 func (u *PostUseCase) MergeList(ctx context.Context, userID int64, postInfos []postentity.PostBasicInfo) ([]postentity.PostBasicInfo, error) {
 	postIDs := make([]int64, len(postInfos))
 	for i, post := range postInfos {
@@ -87,27 +187,59 @@ func (u *PostUseCase) MergeList(ctx context.Context, userID int64, postInfos []p
 	if err != nil {
 		return nil, err
 	}
-	for i, postInfo := range postInfos {
-		if userID != 0 {
-			isLiked, err := u.likeRepo.IsLiking(ctx, &likeentity.Like{LikeType: likeentity.LikePost, UserID: userID, LikingID: postInfo.PostID})
-			if err != nil {
-				return nil, err
-			}
-			postInfos[i].Liked = isLiked
-		}
-
-		err = u.setTagInfos(ctx, &postInfos[i], nil)
+	dislikes, err := u.likeRepo.BatchDislikeCountByIDs(ctx, postIDs, likeentity.LikePost)
+	if err != nil {
+		return nil, err
+	}
+	collects, err := u.collectionRepo.BatchCollectCountByIDs(ctx, postIDs)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := u.commentRepo.BatchCommentCountByObjectIDs(ctx, postIDs)
+	if err != nil {
+		return nil, err
+	}
+	collected := map[int64]bool{}
+	votes := map[int64]likeentity.LikeDirection{}
+	if userID != 0 {
+		collected, err = u.collectionRepo.BatchIsCollected(ctx, userID, postIDs)
 		if err != nil {
 			return nil, err
 		}
-
-		// TODO: batch users by userIDs
-		err = u.setUser(ctx, &postInfos[i], postInfo.User.UserID)
+		votes, err = u.likeRepo.BatchVotes(ctx, userID, likeentity.LikePost, postIDs)
 		if err != nil {
 			return nil, err
 		}
+	}
+	for i, postInfo := range postInfos {
+		if userID != 0 {
+			postInfos[i].Vote = int8(votes[postInfo.PostID])
+			postInfos[i].Liked = votes[postInfo.PostID] == likeentity.LikeUp
+			postInfos[i].Collected = collected[postInfo.PostID]
+		}
 
 		postInfos[i].LikeCount = likes[postInfo.PostID]
+		postInfos[i].DislikeCount = dislikes[postInfo.PostID]
+		postInfos[i].Score = postInfos[i].LikeCount - postInfos[i].DislikeCount
+		postInfos[i].CollectCount = collects[postInfo.PostID]
+		postInfos[i].CommentCount = comments[postInfo.PostID]
+	}
+
+	infos := make([]*postentity.PostBasicInfo, len(postInfos))
+	for i := range postInfos {
+		infos[i] = &postInfos[i]
+	}
+	err = u.setTagInfoList(ctx, infos)
+	if err != nil {
+		return nil, err
+	}
+	err = u.setUsers(ctx, infos)
+	if err != nil {
+		return nil, err
+	}
+	err = u.setMentions(ctx, infos)
+	if err != nil {
+		return nil, err
 	}
 
 	return postInfos, nil
@@ -123,44 +255,90 @@ func (u *PostUseCase) Merge(ctx context.Context, userID int64, postInfo *postent
 		postInfo.LikeCount = likecount
 	}
 
+	dislikes, err := u.likeRepo.BatchDislikeCountByIDs(ctx, []int64{postInfo.PostID}, likeentity.LikePost)
+	if err != nil {
+		return err
+	}
+	postInfo.DislikeCount = dislikes[postInfo.PostID]
+	postInfo.Score = postInfo.LikeCount - postInfo.DislikeCount
+
+	collects, err := u.collectionRepo.BatchCollectCountByIDs(ctx, []int64{postInfo.PostID})
+	if err != nil {
+		return err
+	}
+	postInfo.CollectCount = collects[postInfo.PostID]
+
+	comments, err := u.commentRepo.BatchCommentCountByObjectIDs(ctx, []int64{postInfo.PostID})
+	if err != nil {
+		return err
+	}
+	postInfo.CommentCount = comments[postInfo.PostID]
+
 	if userID != 0 {
-		isLiked, err := u.likeRepo.IsLiking(ctx, &likeentity.Like{LikeType: likeentity.LikePost, UserID: userID, LikingID: postInfo.PostID})
+		votes, err := u.likeRepo.BatchVotes(ctx, userID, likeentity.LikePost, []int64{postInfo.PostID})
 		if err != nil {
 			return err
 		}
-		postInfo.Liked = isLiked
+		postInfo.Vote = int8(votes[postInfo.PostID])
+		postInfo.Liked = votes[postInfo.PostID] == likeentity.LikeUp
+
+		collected, err := u.collectionRepo.BatchIsCollected(ctx, userID, []int64{postInfo.PostID})
+		if err != nil {
+			return err
+		}
+		postInfo.Collected = collected[postInfo.PostID]
+	}
+
+	err = u.setMentions(ctx, []*postentity.PostBasicInfo{&postInfo.PostBasicInfo})
+	if err != nil {
+		return err
 	}
 
 	return u.setUser(ctx, &postInfo.PostBasicInfo, postInfo.User.UserID)
 }
 
-func (u *PostUseCase) Create(ctx context.Context, post *postentity.Post) (*postentity.PostInfo, error) {
-	var (
-		tags []tagentity.Tag
-		err  error
-	)
-	if len(post.TagIDs) != 0 {
-		tags, err = u.tagUsecase.GetByTagIDs(ctx, post.TagIDs)
-		if err != nil {
-			return nil, err
-		}
+func (u *PostUseCase) checkTags(ctx context.Context, tagIDs []int64) ([]tagentity.Tag, error) {
+	if len(tagIDs) == 0 {
+		return nil, nil
+	}
 
-		tagIDMap := map[int64]bool{}
-		for _, tag := range tags {
-			tagIDMap[tag.TagID] = true
-		}
+	tags, err := u.tagUsecase.GetByTagIDs(ctx, tagIDs)
+	if err != nil {
+		return nil, err
+	}
 
-		for _, tagID := range post.TagIDs {
-			if !tagIDMap[tagID] {
-				return nil, errorx.BadRequest(reason.TagNotFound)
-			}
+	tagIDMap := map[int64]bool{}
+	for _, tag := range tags {
+		tagIDMap[tag.TagID] = true
+	}
+
+	for _, tagID := range tagIDs {
+		if !tagIDMap[tagID] {
+			return nil, errorx.BadRequest(reason.TagNotFound)
 		}
 	}
 
+	return tags, nil
+}
+
+func (u *PostUseCase) Create(ctx context.Context, post *postentity.Post) (*postentity.PostInfo, error) {
+	tags, err := u.checkTags(ctx, post.TagIDs)
+	if err != nil {
+		return nil, err
+	}
+	post.MentionIDs, err = u.userUsecase.ResolveMentions(ctx, post.Content)
+	if err != nil {
+		return nil, err
+	}
+
 	err = u.postRepo.Create(ctx, post)
 	if err != nil {
 		return nil, err
 	}
+	if post.IsPublished() {
+		u.publishCreated(ctx, post, post.CreatedAt)
+		u.publishMentioned(ctx, post, post.MentionIDs, post.CreatedAt)
+	}
 
 	postInfo := post.ToInfo()
 	err = u.Merge(ctx, 0, &postInfo, tags)
@@ -170,14 +348,205 @@ func (u *PostUseCase) Create(ctx context.Context, post *postentity.Post) (*poste
 	return &postInfo, err
 }
 
+// Update edit title, content and tags of post, only author can edit it
+func (u *PostUseCase) Update(ctx context.Context, userID int64, post *postentity.Post, revision *postentity.PostRevision) (*postentity.PostInfo, error) {
+	origin, exist, err := u.postRepo.GetBasicInfoByPostID(ctx, post.PostID)
+	if err != nil {
+		return nil, err
+	} else if !exist {
+		return nil, errorx.NotFound(reason.PostNotFound)
+	} else if origin.AuthorID != userID {
+		return nil, errorx.Forbidden(reason.PostEditWithoutPermission)
+	} else if !origin.IsPublished() {
+		return nil, errorx.BadRequest(reason.PostNotPublished)
+	}
+
+	tags, err := u.checkTags(ctx, post.TagIDs)
+	if err != nil {
+		return nil, err
+	}
+	post.MentionIDs, err = u.userUsecase.ResolveMentions(ctx, post.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	revision.EditorID = userID
+	err = u.postRepo.Update(ctx, post, revision)
+	if err != nil {
+		return nil, err
+	}
+	u.publishMentioned(ctx, origin, newMentions(origin.MentionIDs, post.MentionIDs), time.Now())
+	u.publish(ctx, eventpost.TopicPostUpdated, &eventpost.PostUpdatedMessage{
+		BaseMessage: eventpost.BaseMessage{
+			PostID:   origin.PostID,
+			AuthorID: origin.AuthorID,
+		},
+		Revision: post.Revision,
+	})
+
+	origin.Title = post.Title
+	origin.Content = post.Content
+	origin.MentionIDs = post.MentionIDs
+	origin.Revision = post.Revision
+	postInfo := origin.ToInfo()
+	err = u.setTagInfos(ctx, &postInfo.PostBasicInfo, tags)
+	if err != nil {
+		return nil, err
+	}
+	err = u.Merge(ctx, userID, &postInfo, tags)
+	if err != nil {
+		return nil, err
+	}
+	return &postInfo, nil
+}
+
+// Rollback restore post to an earlier revision, it is recorded as a new revision
+func (u *PostUseCase) Rollback(ctx context.Context, userID int64, postID int64, revision int64) (*postentity.PostInfo, error) {
+	postRevision, exist, err := u.postRepo.GetRevision(ctx, postID, revision)
+	if err != nil {
+		return nil, err
+	} else if !exist {
+		return nil, errorx.NotFound(reason.PostRevisionNotFound)
+	}
+
+	// content in revision has been escaped
+	return u.Update(ctx, userID, &postentity.Post{
+		PostID:  postID,
+		Title:   html.UnescapeString(postRevision.Title),
+		Content: html.UnescapeString(postRevision.Content),
+		TagIDs:  postRevision.TagIDs,
+	}, &postentity.PostRevision{
+		RollbackFrom: postRevision.Revision,
+	})
+}
+
+func (u *PostUseCase) setRevisionEditors(ctx context.Context, revisions []postentity.PostRevisionInfo) error {
+	userIDs := make([]int64, 0, len(revisions))
+	visited := map[int64]bool{}
+	for _, revision := range revisions {
+		if !visited[revision.Editor.UserID] {
+			visited[revision.Editor.UserID] = true
+			userIDs = append(userIDs, revision.Editor.UserID)
+		}
+	}
+
+	users, err := u.userUsecase.ListBasicByUserIDs(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+
+	editors := make(map[int64]*userentity.UserBasicInfo, len(users))
+	for _, user := range users {
+		editors[user.UserID] = user
+	}
+
+	for i := range revisions {
+		if editor, ok := editors[revisions[i].Editor.UserID]; ok {
+			revisions[i].Editor = editor
+		}
+	}
+	return nil
+}
+
+func (u *PostUseCase) GetRevisionPage(ctx context.Context, postID int64, pageCond *entity.PageCond) (*paginator.Page[postentity.PostRevisionInfo], error) {
+	_, exist, err := u.postRepo.GetBasicInfoByPostID(ctx, postID)
+	if err != nil {
+		return nil, err
+	} else if !exist {
+		return nil, errorx.NotFound(reason.PostNotFound)
+	}
+
+	revisions, err := u.postRepo.GetRevisionPage(ctx, postID, pageCond)
+	if err != nil {
+		return nil, err
+	}
+
+	revisionInfos := make([]postentity.PostRevisionInfo, len(revisions.Data))
+	for i := range revisions.Data {
+		revisionInfos[i] = revisions.Data[i].ToInfo()
+	}
+	err = u.setRevisionEditors(ctx, revisionInfos)
+	if err != nil {
+		return nil, err
+	}
+
+	return &paginator.Page[postentity.PostRevisionInfo]{
+		CurrentPage: revisions.CurrentPage,
+		PageSize:    revisions.PageSize,
+		Pages:       revisions.Pages,
+		Total:       revisions.Total,
+		Data:        revisionInfos,
+	}, nil
+}
+
+// DiffRevisions compare two revisions of post line by line
+func (u *PostUseCase) DiffRevisions(ctx context.Context, postID int64, from int64, to int64) (*postentity.PostRevisionDiff, error) {
+	fromRevision, exist, err := u.postRepo.GetRevision(ctx, postID, from)
+	if err != nil {
+		return nil, err
+	} else if !exist {
+		return nil, errorx.NotFound(reason.PostRevisionNotFound)
+	}
+	toRevision, exist, err := u.postRepo.GetRevision(ctx, postID, to)
+	if err != nil {
+		return nil, err
+	} else if !exist {
+		return nil, errorx.NotFound(reason.PostRevisionNotFound)
+	}
+
+	revisionInfos := []postentity.PostRevisionInfo{fromRevision.ToInfo(), toRevision.ToInfo()}
+	err = u.setRevisionEditors(ctx, revisionInfos)
+	if err != nil {
+		return nil, err
+	}
+
+	revisionDiff := &postentity.PostRevisionDiff{
+		From:        revisionInfos[0],
+		To:          revisionInfos[1],
+		Title:       diff.Lines(fromRevision.Title, toRevision.Title),
+		Content:     diff.Lines(fromRevision.Content, toRevision.Content),
+		AddedTags:   []tagentity.TagInfo{},
+		RemovedTags: []tagentity.TagInfo{},
+	}
+
+	added, removed := postentity.DiffTagIDs(fromRevision.TagIDs, toRevision.TagIDs)
+	if len(added)+len(removed) == 0 {
+		return revisionDiff, nil
+	}
+	tags, err := u.tagUsecase.GetByTagIDs(ctx, append(added, removed...))
+	if err != nil {
+		return nil, err
+	}
+	addedSet := make(map[int64]bool, len(added))
+	for _, tagID := range added {
+		addedSet[tagID] = true
+	}
+	for i := range tags {
+		if addedSet[tags[i].TagID] {
+			revisionDiff.AddedTags = append(revisionDiff.AddedTags, tags[i].ToBasicInfo())
+		} else {
+			revisionDiff.RemovedTags = append(revisionDiff.RemovedTags, tags[i].ToBasicInfo())
+		}
+	}
+
+	return revisionDiff, nil
+}
+
 func (u *PostUseCase) GetByPostID(ctx context.Context, userID int64, postID int64) (*postentity.PostInfo, bool, error) {
 	post, exist, err := u.postRepo.GetByPostID(ctx, postID)
 	if err != nil {
 		return nil, false, err
 	}
-	if !exist {
+	// unpublished posts are only visible to their author
+	if !exist || (!post.IsPublished() && post.AuthorID != userID) {
 		return nil, false, nil
 	}
+	hidden, err := u.hiddenAuthors(ctx, userID, []int64{post.AuthorID})
+	if err != nil {
+		return nil, false, err
+	} else if hidden[post.AuthorID] {
+		return nil, false, errorx.Forbidden(reason.PrivateAccount)
+	}
 
 	postInfo := post.ToInfo()
 	err = u.Merge(ctx, userID, &postInfo, nil)
@@ -217,23 +586,54 @@ func (u *PostUseCase) GetPage(ctx context.Context, queryCond *postentity.PostQue
 	}, err
 }
 
-func (u *PostUseCase) GetLikeCount(ctx context.Context, postID int64) (int64, bool, error) {
-	return u.postRepo.GetLikeCount(ctx, postID)
-}
+// GetByPostIDs 按给定顺序返回已发布的帖子，去掉 userID 看不到的私密账号的帖子
+func (u *PostUseCase) GetByPostIDs(ctx context.Context, userID int64, postIDs []int64) ([]postentity.PostBasicInfo, error) {
+	posts, err := u.postRepo.GetByPostIDs(ctx, postIDs)
+	if err != nil {
+		return nil, err
+	}
+	authorIDs := make([]int64, len(posts))
+	for i, post := range posts {
+		authorIDs[i] = post.AuthorID
+	}
+	hidden, err := u.hiddenAuthors(ctx, userID, authorIDs)
+	if err != nil {
+		return nil, err
+	}
 
-func (u *PostUseCase) UpdateLikeCount(ctx context.Context, postID int64, count int64) error {
-	return u.postRepo.UpdateLikeCount(ctx, postID, count)
+	postInfos := make([]postentity.PostBasicInfo, 0, len(posts))
+	for _, post := range posts {
+		if !hidden[post.AuthorID] {
+			postInfos = append(postInfos, post.ToBasic())
+		}
+	}
+	return u.MergeList(ctx, userID, postInfos)
 }
 
-func (u *PostUseCase) BatchByIDs(ctx context.Context, postIDs []int64) ([]postentity.PostBasicInfo, error) {
-	posts, err := u.postRepo.BatchByIDs(ctx, postIDs)
+// hiddenAuthors authorIDs 中 userID 看不到的私密账号
+func (u *PostUseCase) hiddenAuthors(ctx context.Context, userID int64, authorIDs []int64) (map[int64]bool, error) {
+	others := make([]int64, 0, len(authorIDs))
+	for _, authorID := range authorIDs {
+		if authorID != userID {
+			others = append(others, authorID)
+		}
+	}
+	hidden, err := u.postRepo.ListHiddenAuthors(ctx, userID, others)
 	if err != nil {
 		return nil, err
 	}
-	postInfos := make([]postentity.PostBasicInfo, len(posts))
-	for i, post := range posts {
-		postInfos[i] = post.ToBasic()
+
+	set := make(map[int64]bool, len(hidden))
+	for _, authorID := range hidden {
+		set[authorID] = true
 	}
+	return set, nil
+}
 
-	return postInfos, nil
+func (u *PostUseCase) GetLikeCount(ctx context.Context, postID int64) (int64, bool, error) {
+	return u.postRepo.GetLikeCount(ctx, postID)
+}
+
+func (u *PostUseCase) UpdateLikeCount(ctx context.Context, postID int64, count int64) error {
+	return u.postRepo.UpdateLikeCount(ctx, postID, count)
 }
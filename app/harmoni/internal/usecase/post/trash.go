@@ -0,0 +1,184 @@
+package post
+
+import (
+	"context"
+	"harmoni/app/harmoni/internal/entity"
+	likeentity "harmoni/app/harmoni/internal/entity/like"
+	"harmoni/app/harmoni/internal/entity/paginator"
+	postentity "harmoni/app/harmoni/internal/entity/post"
+	"harmoni/app/harmoni/internal/pkg/reason"
+	eventpost "harmoni/app/harmoni/internal/types/events/post"
+	"harmoni/internal/pkg/errorx"
+	"time"
+)
+
+const purgeBatchSize = 100
+
+func (u *PostUseCase) canManage(ctx context.Context, userID int64, post *postentity.Post) (bool, error) {
+	if post.AuthorID == userID {
+		return true, nil
+	}
+	return u.userUsecase.IsModerator(ctx, userID)
+}
+
+// Delete 作者或版主删除帖子，帖子在回收站中保留一段时间后被清除
+func (u *PostUseCase) Delete(ctx context.Context, userID int64, postID int64) error {
+	post, exist, err := u.postRepo.GetBasicInfoByPostID(ctx, postID)
+	if err != nil {
+		return err
+	} else if !exist {
+		return errorx.NotFound(reason.PostNotFound)
+	}
+
+	ok, err := u.canManage(ctx, userID, post)
+	if err != nil {
+		return err
+	} else if !ok {
+		return errorx.Forbidden(reason.PostDeleteWithoutPermission)
+	}
+
+	err = u.postRepo.Delete(ctx, postID, userID)
+	if err != nil {
+		return err
+	}
+	err = u.likeRepo.RemoveObject(ctx, likeentity.LikePost, postID)
+	if err != nil {
+		u.logger.Errorf("remove post %d from object cache failed: %s", postID, err)
+	}
+
+	u.publish(ctx, eventpost.TopicPostDeleted, &eventpost.PostDeletedMessage{
+		BaseMessage: eventpost.BaseMessage{
+			PostID:   post.PostID,
+			AuthorID: post.AuthorID,
+		},
+		OperatorID: userID,
+	})
+	return nil
+}
+
+// Restore 从回收站恢复帖子，被版主删除的帖子只能由版主恢复
+func (u *PostUseCase) Restore(ctx context.Context, userID int64, postID int64) (*postentity.PostInfo, error) {
+	post, exist, err := u.postRepo.GetTrashedByPostID(ctx, postID)
+	if err != nil {
+		return nil, err
+	} else if !exist || post.DeletedAt.Time.Add(u.conf.TrashRetention).Before(time.Now()) {
+		return nil, errorx.NotFound(reason.PostNotFound)
+	}
+
+	isModerator, err := u.userUsecase.IsModerator(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isModerator && (post.AuthorID != userID || post.DeletedBy != userID) {
+		return nil, errorx.Forbidden(reason.PostRestoreWithoutPermission)
+	}
+
+	err = u.postRepo.Restore(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.IsPublished() {
+		u.publishCreated(ctx, post, post.CreatedAt)
+	}
+
+	post.DeletedBy = 0
+	postInfo := post.ToInfo()
+	err = u.setTagInfos(ctx, &postInfo.PostBasicInfo, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = u.Merge(ctx, userID, &postInfo, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &postInfo, nil
+}
+
+// GetTrashPage 用户回收站中的帖子
+func (u *PostUseCase) GetTrashPage(ctx context.Context, userID int64, pageCond *entity.PageCond) (*paginator.Page[postentity.TrashedPostInfo], error) {
+	posts, err := u.postRepo.GetTrashPage(ctx, userID, pageCond)
+	if err != nil {
+		return nil, err
+	}
+
+	postInfos := make([]postentity.TrashedPostInfo, len(posts.Data))
+	for i := range posts.Data {
+		postInfos[i] = posts.Data[i].ToTrashed(u.conf.TrashRetention)
+	}
+	infos := make([]*postentity.PostBasicInfo, len(postInfos))
+	for i := range postInfos {
+		infos[i] = &postInfos[i].PostBasicInfo
+	}
+	err = u.setTagInfoList(ctx, infos)
+	if err != nil {
+		return nil, err
+	}
+
+	user, exist, err := u.userUsecase.GetBasicByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	} else if exist {
+		for i := range postInfos {
+			postInfos[i].User = user
+		}
+	}
+
+	return &paginator.Page[postentity.TrashedPostInfo]{
+		CurrentPage: posts.CurrentPage,
+		PageSize:    posts.PageSize,
+		Pages:       posts.Pages,
+		Total:       posts.Total,
+		Data:        postInfos,
+	}, nil
+}
+
+// PurgeTrash 清除超过保留时间的帖子，同时删除其评论、点赞、话题关联以及缓存中的点赞数
+func (u *PostUseCase) PurgeTrash(ctx context.Context) (int, error) {
+	before := time.Now().Add(-u.conf.TrashRetention)
+	purged := 0
+	for {
+		posts, err := u.postRepo.ListTrashedBefore(ctx, before, purgeBatchSize)
+		if err != nil {
+			return purged, err
+		}
+		if len(posts) == 0 {
+			return purged, nil
+		}
+
+		postIDs := make([]int64, len(posts))
+		for i := range posts {
+			postIDs[i] = posts[i].PostID
+		}
+
+		commentIDs, err := u.commentRepo.DeleteByObjectIDs(ctx, postIDs)
+		if err != nil {
+			return purged, err
+		}
+		err = u.likeRepo.DeleteByLikingIDs(ctx, likeentity.LikeComment, commentIDs)
+		if err != nil {
+			return purged, err
+		}
+		err = u.likeRepo.RemoveObject(ctx, likeentity.LikeComment, commentIDs...)
+		if err != nil {
+			u.logger.Errorf("remove comments from object cache failed: %s", err)
+		}
+		err = u.likeRepo.DeleteByLikingIDs(ctx, likeentity.LikePost, postIDs)
+		if err != nil {
+			return purged, err
+		}
+		err = u.collectionRepo.DeleteByPostIDs(ctx, postIDs)
+		if err != nil {
+			return purged, err
+		}
+		// 帖子最后删除，中途失败时下次任务可以重试
+		err = u.postRepo.Purge(ctx, postIDs)
+		if err != nil {
+			return purged, err
+		}
+
+		purged += len(posts)
+		if len(posts) < purgeBatchSize {
+			return purged, nil
+		}
+	}
+}
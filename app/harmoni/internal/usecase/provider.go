@@ -2,12 +2,15 @@ package usecase
 
 import (
 	"harmoni/app/harmoni/internal/pkg/filesystem"
+	"harmoni/app/harmoni/internal/usecase/block"
+	"harmoni/app/harmoni/internal/usecase/collection"
 	"harmoni/app/harmoni/internal/usecase/comment"
 	"harmoni/app/harmoni/internal/usecase/email"
 	"harmoni/app/harmoni/internal/usecase/file"
 	"harmoni/app/harmoni/internal/usecase/follow"
 	"harmoni/app/harmoni/internal/usecase/like"
 	"harmoni/app/harmoni/internal/usecase/post"
+	"harmoni/app/harmoni/internal/usecase/search"
 	"harmoni/app/harmoni/internal/usecase/tag"
 	"harmoni/app/harmoni/internal/usecase/timeline"
 	"harmoni/app/harmoni/internal/usecase/user"
@@ -21,11 +24,15 @@ var ProviderSetUsecase = wire.NewSet(
 	post.ProviderSetPost,
 	like.ProviderSetLikeUsecase,
 	comment.ProviderSetComment,
+	search.ProviderSetSearch,
+	timeline.ProviderSetTimeline,
 
 	email.NewEmailUsecase,
 	follow.NewFollowUseCase,
+	follow.NewSuggestionUseCase,
 	tag.NewTagUseCase,
-	timeline.NewTimeLineUsecase,
+	collection.NewCollectionUseCase,
+	block.NewBlockUseCase,
 	file.NewPolicy,
 	filesystem.NewFileSystem,
 	file.NewFileUseCase,
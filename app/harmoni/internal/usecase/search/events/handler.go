@@ -0,0 +1,60 @@
+package events
+
+import (
+	"context"
+	v1 "harmoni/app/harmoni/api/mq/v1/comment"
+	eventcomment "harmoni/app/harmoni/internal/types/events/comment"
+	eventpost "harmoni/app/harmoni/internal/types/events/post"
+	eventsearch "harmoni/app/harmoni/internal/types/events/search"
+)
+
+// Indexer 搜索索引的增量更新操作
+type Indexer interface {
+	IndexPost(ctx context.Context, postID int64, withComments bool) error
+	RemovePost(ctx context.Context, postID int64)
+	IndexComment(ctx context.Context, commentID int64) error
+	RemoveComment(ctx context.Context, commentID int64)
+	ReindexRequested(ctx context.Context, instanceID int64) error
+}
+
+type SearchEventsHandler struct {
+	indexer Indexer
+}
+
+func NewSearchEventsHandler(indexer Indexer) *SearchEventsHandler {
+	return &SearchEventsHandler{
+		indexer: indexer,
+	}
+}
+
+// HandlePostCreated 恢复的帖子可能已经有评论，需要一起索引
+func (h *SearchEventsHandler) HandlePostCreated(ctx context.Context, msg *eventpost.PostCreatedMessage) error {
+	return h.indexer.IndexPost(ctx, msg.PostID, true)
+}
+
+func (h *SearchEventsHandler) HandlePostUpdated(ctx context.Context, msg *eventpost.PostUpdatedMessage) error {
+	return h.indexer.IndexPost(ctx, msg.PostID, false)
+}
+
+func (h *SearchEventsHandler) HandlePostDeleted(ctx context.Context, msg *eventpost.PostDeletedMessage) error {
+	h.indexer.RemovePost(ctx, msg.PostID)
+	return nil
+}
+
+func (h *SearchEventsHandler) HandleCommentCreated(ctx context.Context, msg *v1.CommentCreatedMessage) error {
+	return h.indexer.IndexComment(ctx, msg.CommentID)
+}
+
+func (h *SearchEventsHandler) HandleCommentUpdated(ctx context.Context, msg *eventcomment.CommentUpdatedMessage) error {
+	return h.indexer.IndexComment(ctx, msg.CommentID)
+}
+
+func (h *SearchEventsHandler) HandleCommentDeleted(ctx context.Context, msg *eventcomment.CommentDeletedMessage) error {
+	h.indexer.RemoveComment(ctx, msg.CommentID)
+	return nil
+}
+
+// HandleReindex 其他实例重建索引后，本实例也从数据库重建
+func (h *SearchEventsHandler) HandleReindex(ctx context.Context, msg *eventsearch.ReindexMessage) error {
+	return h.indexer.ReindexRequested(ctx, msg.InstanceID)
+}
@@ -0,0 +1,451 @@
+package search
+
+import (
+	"context"
+	commententity "harmoni/app/harmoni/internal/entity/comment"
+	"harmoni/app/harmoni/internal/entity/paginator"
+	postentity "harmoni/app/harmoni/internal/entity/post"
+	searchentity "harmoni/app/harmoni/internal/entity/search"
+	userentity "harmoni/app/harmoni/internal/entity/user"
+	"harmoni/app/harmoni/internal/infrastructure/config"
+	"harmoni/app/harmoni/internal/pkg/reason"
+	"harmoni/app/harmoni/internal/pkg/search"
+	eventsearch "harmoni/app/harmoni/internal/types/events/search"
+	"harmoni/app/harmoni/internal/types/iface"
+	"harmoni/app/harmoni/internal/usecase/search/events"
+	useruse "harmoni/app/harmoni/internal/usecase/user"
+	"harmoni/internal/pkg/errorx"
+	"harmoni/internal/types/object"
+	"html"
+	"sync"
+
+	"github.com/google/wire"
+	"go.uber.org/zap"
+)
+
+var ProviderSetSearch = wire.NewSet(
+	NewSearchUseCase,
+	events.NewSearchEventsHandler,
+	wire.Bind(new(events.Indexer), new(*SearchUseCase)),
+)
+
+const (
+	reindexBatchSize = 500
+	maxPageSize      = 50
+)
+
+var _ events.Indexer = (*SearchUseCase)(nil)
+
+// SearchUseCase 基于内存倒排索引的帖子和评论搜索，索引由 MQ 事件增量更新，
+// 也可以从数据库全量重建
+type SearchUseCase struct {
+	conf        *config.Search
+	appConf     *config.App
+	postRepo    postentity.PostRepository
+	commentRepo commententity.CommentRepository
+	userUsecase *useruse.UserUseCase
+	publisher   iface.Publisher
+	logger      *zap.SugaredLogger
+
+	mu    sync.RWMutex
+	index *search.Index
+	// 重建期间的新索引，增量更新同时写入新旧两个索引
+	rebuilding *search.Index
+}
+
+func NewSearchUseCase(
+	conf *config.Search,
+	appConf *config.App,
+	postRepo postentity.PostRepository,
+	commentRepo commententity.CommentRepository,
+	userUsecase *useruse.UserUseCase,
+	publisher iface.Publisher,
+	logger *zap.SugaredLogger,
+) *SearchUseCase {
+	return &SearchUseCase{
+		conf:        conf,
+		appConf:     appConf,
+		postRepo:    postRepo,
+		commentRepo: commentRepo,
+		userUsecase: userUsecase,
+		publisher:   publisher,
+		logger:      logger.With("module", "usecase/search"),
+		index:       search.NewIndex(),
+	}
+}
+
+func (u *SearchUseCase) indexes() []*search.Index {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	if u.rebuilding != nil {
+		return []*search.Index{u.index, u.rebuilding}
+	}
+	return []*search.Index{u.index}
+}
+
+// content in database has been escaped, index the plain text
+func postDocument(post *postentity.Post) search.Document {
+	return search.Document{
+		Type:         search.DocPost,
+		ID:           post.PostID,
+		PostID:       post.PostID,
+		AuthorID:     post.AuthorID,
+		PostAuthorID: post.AuthorID,
+		Title:        html.UnescapeString(post.Title),
+		Content:      html.UnescapeString(post.Content),
+		CreatedAt:    post.CreatedAt,
+	}
+}
+
+func commentDocument(comment *commententity.Comment, postAuthorID int64) search.Document {
+	return search.Document{
+		Type:         search.DocComment,
+		ID:           comment.CommentID,
+		PostID:       comment.ObjectID,
+		AuthorID:     comment.Author.UserID,
+		PostAuthorID: postAuthorID,
+		Content:      html.UnescapeString(comment.Content),
+		CreatedAt:    comment.CreatedAt,
+	}
+}
+
+// IndexPost 重新索引帖子，withComments 为 true 时同时索引帖子下的评论
+func (u *SearchUseCase) IndexPost(ctx context.Context, postID int64, withComments bool) error {
+	post, exist, err := u.postRepo.GetBasicInfoByPostID(ctx, postID)
+	if err != nil {
+		return err
+	} else if !exist || !post.IsPublished() {
+		u.RemovePost(ctx, postID)
+		return nil
+	}
+
+	docs := []search.Document{postDocument(post)}
+	if withComments {
+		comments, err := u.commentRepo.ListByObjectID(ctx, postID)
+		if err != nil {
+			return err
+		}
+		for _, comment := range comments {
+			if comment.Deleted {
+				continue
+			}
+			docs = append(docs, commentDocument(comment, post.AuthorID))
+		}
+	}
+
+	for _, index := range u.indexes() {
+		for _, doc := range docs {
+			index.Add(doc)
+		}
+	}
+	return nil
+}
+
+func (u *SearchUseCase) RemovePost(ctx context.Context, postID int64) {
+	for _, index := range u.indexes() {
+		index.RemovePost(postID)
+	}
+}
+
+func (u *SearchUseCase) IndexComment(ctx context.Context, commentID int64) error {
+	comment, exist, err := u.commentRepo.GetByCommentID(ctx, commentID)
+	if err != nil {
+		return err
+	} else if !exist || comment.Deleted || comment.ObjectType != object.ObjectTypePost {
+		u.RemoveComment(ctx, commentID)
+		return nil
+	}
+
+	// comments under unpublished or deleted posts are not searchable
+	post, exist, err := u.postRepo.GetBasicInfoByPostID(ctx, comment.ObjectID)
+	if err != nil {
+		return err
+	} else if !exist || !post.IsPublished() {
+		return nil
+	}
+
+	for _, index := range u.indexes() {
+		index.Add(commentDocument(comment, post.AuthorID))
+	}
+	return nil
+}
+
+func (u *SearchUseCase) RemoveComment(ctx context.Context, commentID int64) {
+	for _, index := range u.indexes() {
+		index.Remove(search.DocComment, commentID)
+	}
+}
+
+// ReindexAll 重建本实例的索引，并通知其他实例各自从数据库重建
+func (u *SearchUseCase) ReindexAll(ctx context.Context) (*searchentity.ReindexReply, error) {
+	reply, err := u.Reindex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.publisher.Publish(ctx, eventsearch.TopicSearchReindex, &eventsearch.ReindexMessage{
+		InstanceID: u.appConf.AppID,
+	})
+	if err != nil {
+		return nil, errorx.InternalServer(reason.ServerError).WithError(err).WithStack()
+	}
+	reply.InstanceID = u.appConf.AppID
+	return reply, nil
+}
+
+// ReindexRequested 其他实例发起的重建，本实例正在重建时跳过
+func (u *SearchUseCase) ReindexRequested(ctx context.Context, instanceID int64) error {
+	if instanceID == u.appConf.AppID {
+		return nil
+	}
+
+	reply, started, err := u.reindex(ctx)
+	if err != nil {
+		return err
+	} else if !started {
+		u.logger.Infof("skip reindex requested by instance %d, already in progress", instanceID)
+		return nil
+	}
+	u.logger.Infof("search index rebuilt on request of instance %d with %d posts and %d comments",
+		instanceID, reply.Posts, reply.Comments)
+	return nil
+}
+
+// Reindex 从数据库重建本实例的索引，重建完成前继续使用旧索引提供搜索
+func (u *SearchUseCase) Reindex(ctx context.Context) (*searchentity.ReindexReply, error) {
+	reply, started, err := u.reindex(ctx)
+	if err != nil {
+		return nil, err
+	} else if !started {
+		return nil, errorx.BadRequest(reason.SearchReindexInProgress)
+	}
+	return reply, nil
+}
+
+// reindex 已经在重建时不会开始，返回 false
+func (u *SearchUseCase) reindex(ctx context.Context) (*searchentity.ReindexReply, bool, error) {
+	index := search.NewIndex()
+	u.mu.Lock()
+	if u.rebuilding != nil {
+		u.mu.Unlock()
+		return nil, false, nil
+	}
+	u.rebuilding = index
+	u.mu.Unlock()
+
+	reply, err := u.rebuild(ctx, index)
+
+	u.mu.Lock()
+	if err == nil {
+		u.index = index
+	}
+	u.rebuilding = nil
+	u.mu.Unlock()
+
+	return reply, true, err
+}
+
+func (u *SearchUseCase) rebuild(ctx context.Context, index *search.Index) (*searchentity.ReindexReply, error) {
+	reply := &searchentity.ReindexReply{}
+	// 已发布的帖子和作者
+	published := map[int64]int64{}
+	var lastPostID int64
+	for {
+		posts, err := u.postRepo.ListPublishedAfter(ctx, lastPostID, reindexBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for i := range posts {
+			index.Add(postDocument(&posts[i]))
+			published[posts[i].PostID] = posts[i].AuthorID
+			lastPostID = posts[i].PostID
+		}
+		reply.Posts += len(posts)
+		if len(posts) < reindexBatchSize {
+			break
+		}
+	}
+
+	var lastCommentID int64
+	for {
+		comments, err := u.commentRepo.ListAfter(ctx, lastCommentID, reindexBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, comment := range comments {
+			lastCommentID = comment.CommentID
+			postAuthorID, ok := published[comment.ObjectID]
+			if comment.ObjectType != object.ObjectTypePost || !ok || comment.Deleted {
+				continue
+			}
+			index.Add(commentDocument(comment, postAuthorID))
+			reply.Comments++
+		}
+		if len(comments) < reindexBatchSize {
+			break
+		}
+	}
+
+	return reply, nil
+}
+
+func (u *SearchUseCase) authors(ctx context.Context, hits []search.Hit) (map[int64]*userentity.UserBasicInfo, error) {
+	userIDs := make([]int64, 0, len(hits))
+	visited := map[int64]bool{}
+	for _, hit := range hits {
+		if !visited[hit.AuthorID] {
+			visited[hit.AuthorID] = true
+			userIDs = append(userIDs, hit.AuthorID)
+		}
+	}
+
+	users, err := u.userUsecase.ListBasicByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	authors := make(map[int64]*userentity.UserBasicInfo, len(users))
+	for _, user := range users {
+		authors[user.UserID] = user
+	}
+	return authors, nil
+}
+
+// postTitles 评论结果展示所属帖子的标题
+func (u *SearchUseCase) postTitles(ctx context.Context, hits []search.Hit) (map[int64]string, error) {
+	postIDs := []int64{}
+	for _, hit := range hits {
+		if hit.Type == search.DocComment {
+			postIDs = append(postIDs, hit.PostID)
+		}
+	}
+	if len(postIDs) == 0 {
+		return map[int64]string{}, nil
+	}
+
+	posts, err := u.postRepo.BatchByIDs(ctx, postIDs)
+	if err != nil {
+		return nil, err
+	}
+	titles := make(map[int64]string, len(posts))
+	for _, post := range posts {
+		titles[post.PostID] = html.UnescapeString(post.Title)
+	}
+	return titles, nil
+}
+
+// hidePrivate 去掉 userID 看不到的私密账号的帖子以及这些帖子下的评论
+func (u *SearchUseCase) hidePrivate(ctx context.Context, userID int64, hits []search.Hit) ([]search.Hit, error) {
+	authorIDs := []int64{}
+	visited := map[int64]bool{userID: true}
+	for _, hit := range hits {
+		if !visited[hit.PostAuthorID] {
+			visited[hit.PostAuthorID] = true
+			authorIDs = append(authorIDs, hit.PostAuthorID)
+		}
+	}
+	hidden, err := u.postRepo.ListHiddenAuthors(ctx, userID, authorIDs)
+	if err != nil || len(hidden) == 0 {
+		return hits, err
+	}
+
+	set := make(map[int64]bool, len(hidden))
+	for _, authorID := range hidden {
+		set[authorID] = true
+	}
+	shown := make([]search.Hit, 0, len(hits))
+	for _, hit := range hits {
+		if !set[hit.PostAuthorID] {
+			shown = append(shown, hit)
+		}
+	}
+	return shown, nil
+}
+
+func pageHits(hits []search.Hit, offset, limit int) []search.Hit {
+	if offset >= len(hits) {
+		return []search.Hit{}
+	}
+	end := len(hits)
+	if offset+limit < end {
+		end = offset + limit
+	}
+	return hits[offset:end]
+}
+
+func (u *SearchUseCase) Search(ctx context.Context, query *searchentity.SearchQuery) (*paginator.Page[searchentity.SearchResult], error) {
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.PageSize <= 0 {
+		query.PageSize = 10
+	} else if query.PageSize > maxPageSize {
+		query.PageSize = maxPageSize
+	}
+
+	// hits are paged after the posts of hidden private accounts are removed
+	q := search.Query{
+		Text: query.Query,
+	}
+	switch query.Type {
+	case searchentity.SearchTypePost:
+		q.Type = search.DocPost
+	case searchentity.SearchTypeComment:
+		q.Type = search.DocComment
+	}
+
+	u.mu.RLock()
+	index := u.index
+	u.mu.RUnlock()
+	hits, _ := index.Search(q)
+	hits, err := u.hidePrivate(ctx, query.UserID, hits)
+	if err != nil {
+		return nil, err
+	}
+	total := len(hits)
+	hits = pageHits(hits, int((query.Page-1)*query.PageSize), int(query.PageSize))
+
+	authors, err := u.authors(ctx, hits)
+	if err != nil {
+		return nil, err
+	}
+	titles, err := u.postTitles(ctx, hits)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := search.QueryTerms(query.Query)
+	results := make([]searchentity.SearchResult, len(hits))
+	for i, hit := range hits {
+		result := searchentity.SearchResult{
+			Type:      searchentity.SearchTypePost,
+			PostID:    hit.PostID,
+			Title:     search.Highlight(hit.Title, terms, 0),
+			Snippet:   search.Highlight(hit.Content, terms, u.conf.SnippetLength),
+			Score:     hit.Score,
+			Author:    authors[hit.AuthorID],
+			CreatedAt: hit.CreatedAt,
+		}
+		if hit.Type == search.DocComment {
+			result.Type = searchentity.SearchTypeComment
+			result.CommentID = hit.ID
+			result.Title = search.Highlight(titles[hit.PostID], terms, 0)
+		}
+		if result.Author == nil {
+			result.Author = &userentity.UserBasicInfo{UserID: -1, Name: "deactivated"}
+		}
+		results[i] = result
+	}
+
+	pages := int64(total) / query.PageSize
+	if int64(total)%query.PageSize != 0 {
+		pages++
+	}
+	return &paginator.Page[searchentity.SearchResult]{
+		CurrentPage: query.Page,
+		PageSize:    query.PageSize,
+		Total:       int64(total),
+		Pages:       pages,
+		Data:        results,
+	}, nil
+}
@@ -48,3 +48,7 @@ func (u *TagUseCase) GetPage(ctx context.Context, pageSize int64, pageNum int64)
 func (u *TagUseCase) GetTagsByPostID(ctx context.Context, postID int64) ([]tagentity.Tag, error) {
 	return u.tagRepo.GetTagsByPostID(ctx, postID)
 }
+
+func (u *TagUseCase) GetTagsByPostIDs(ctx context.Context, postIDs []int64) (map[int64][]tagentity.Tag, error) {
+	return u.tagRepo.GetTagsByPostIDs(ctx, postIDs)
+}
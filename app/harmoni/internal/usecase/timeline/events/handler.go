@@ -0,0 +1,37 @@
+package events
+
+import (
+	"context"
+	postentity "harmoni/app/harmoni/internal/entity/post"
+	eventpost "harmoni/app/harmoni/internal/types/events/post"
+	"time"
+)
+
+// Distributor 把帖子分发到粉丝的收件箱
+type Distributor interface {
+	FanOut(ctx context.Context, post postentity.PostRef, authorID int64) error
+	Retract(ctx context.Context, postID, authorID int64) error
+}
+
+type TimelineEventsHandler struct {
+	distributor Distributor
+}
+
+func NewTimelineEventsHandler(distributor Distributor) *TimelineEventsHandler {
+	return &TimelineEventsHandler{
+		distributor: distributor,
+	}
+}
+
+// HandlePostCreated 发布和从回收站恢复的帖子都会推送
+func (h *TimelineEventsHandler) HandlePostCreated(ctx context.Context, msg *eventpost.PostCreatedMessage) error {
+	post := postentity.PostRef{PostID: msg.PostID, CreatedAt: time.Now()}
+	if msg.CreatedAt != nil {
+		post.CreatedAt = *msg.CreatedAt
+	}
+	return h.distributor.FanOut(ctx, post, msg.AuthorID)
+}
+
+func (h *TimelineEventsHandler) HandlePostDeleted(ctx context.Context, msg *eventpost.PostDeletedMessage) error {
+	return h.distributor.Retract(ctx, msg.PostID, msg.AuthorID)
+}
@@ -2,34 +2,75 @@ package timeline
 
 import (
 	"context"
+	blockentity "harmoni/app/harmoni/internal/entity/block"
 	followentity "harmoni/app/harmoni/internal/entity/follow"
 	"harmoni/app/harmoni/internal/entity/paginator"
 	postentity "harmoni/app/harmoni/internal/entity/post"
+	timelineentity "harmoni/app/harmoni/internal/entity/timeline"
+	userentity "harmoni/app/harmoni/internal/entity/user"
+	"harmoni/app/harmoni/internal/infrastructure/config"
+	"harmoni/app/harmoni/internal/pkg/reason"
 	"harmoni/app/harmoni/internal/usecase/post"
+	"harmoni/app/harmoni/internal/usecase/timeline/events"
+	"harmoni/internal/pkg/errorx"
 
+	"github.com/google/wire"
 	"go.uber.org/zap"
 )
 
+var ProviderSetTimeline = wire.NewSet(
+	NewTimeLineUsecase,
+	events.NewTimelineEventsHandler,
+	wire.Bind(new(events.Distributor), new(*TimeLinePullUsecase)),
+)
+
+// fanOutBatchSize 推送时每批处理的粉丝数
+const fanOutBatchSize = 500
+
+// TimeLinePullUsecase 首页时间线推拉结合：普通作者的帖子发布时推送到粉丝的收件箱，
+// 粉丝数达到 PullThreshold 的作者和关注的标签在读取时拉取
 type TimeLinePullUsecase struct {
-	followRepo  followentity.FollowRepository
-	postUseCase *post.PostUseCase
-	logger      *zap.SugaredLogger
+	conf         *config.Timeline
+	followRepo   followentity.FollowRepository
+	blockRepo    blockentity.BlockRepository
+	postRepo     postentity.PostRepository
+	userRepo     userentity.UserRepository
+	timelineRepo timelineentity.TimelineRepository
+	postUseCase  *post.PostUseCase
+	logger       *zap.SugaredLogger
 }
 
 func NewTimeLineUsecase(
+	conf *config.Timeline,
 	followRepo followentity.FollowRepository,
+	blockRepo blockentity.BlockRepository,
+	postRepo postentity.PostRepository,
+	userRepo userentity.UserRepository,
+	timelineRepo timelineentity.TimelineRepository,
 	postUseCase *post.PostUseCase,
 	loggger *zap.SugaredLogger,
 ) *TimeLinePullUsecase {
 	return &TimeLinePullUsecase{
-		followRepo:  followRepo,
-		postUseCase: postUseCase,
-		logger:      loggger,
+		conf:         conf,
+		followRepo:   followRepo,
+		blockRepo:    blockRepo,
+		postRepo:     postRepo,
+		userRepo:     userRepo,
+		timelineRepo: timelineRepo,
+		postUseCase:  postUseCase,
+		logger:       loggger,
 	}
 }
 
 // GetTimeLineByUserID Get user's timeline
 func (u *TimeLinePullUsecase) GetTimeLineByUserID(ctx context.Context, authorID int64, userID int64, queryCond *postentity.PostQuery) (*paginator.Page[postentity.PostBasicInfo], error) {
+	visible, err := u.isVisible(ctx, authorID, userID)
+	if err != nil {
+		return nil, err
+	} else if !visible {
+		return nil, errorx.Forbidden(reason.PrivateAccount)
+	}
+
 	return u.postUseCase.GetPage(ctx, &postentity.PostQuery{
 		PageCond:  queryCond.PageCond,
 		AuthorIDs: []int64{authorID},
@@ -37,16 +78,285 @@ func (u *TimeLinePullUsecase) GetTimeLineByUserID(ctx context.Context, authorID
 	})
 }
 
-// GetTimeLine get user's followings timeline
-func (u *TimeLinePullUsecase) GetTimeLine(ctx context.Context, userID int64, queryCond *postentity.PostQuery) (*paginator.Page[postentity.PostBasicInfo], error) {
-	followings, err := u.followRepo.GetFollowingUsersAll(ctx, userID)
+// isVisible 私密账号的帖子只对自己和已批准的关注者可见
+func (u *TimeLinePullUsecase) isVisible(ctx context.Context, authorID, userID int64) (bool, error) {
+	if authorID == userID {
+		return true, nil
+	}
+	author, exist, err := u.userRepo.GetByUserID(ctx, authorID)
+	if err != nil {
+		return false, err
+	} else if !exist {
+		return true, nil
+	}
+
+	return author.VisibleTo(userID, func() (bool, error) {
+		return u.followRepo.IsFollowing(ctx, &followentity.Follow{
+			FollowerID:   userID,
+			FollowingID:  authorID,
+			FollowedType: followentity.FollowUser,
+		})
+	})
+}
+
+// GetTimeLine 首页时间线，合并收件箱和拉取的帖子，按发布时间和帖子ID倒序，每个帖子只出现一次。静音的用户的帖子不出现
+func (u *TimeLinePullUsecase) GetTimeLine(ctx context.Context, userID int64, cursor postentity.PostRef, limit int) (*timelineentity.GetHomeTimeLineReply, error) {
+	limit = paginator.CursorLimit(limit)
+	// only the few followings above the threshold are pulled, the rest are already in the inbox
+	pulled, err := u.followRepo.ListPopularFollowings(ctx, userID, u.conf.PullThreshold)
+	if err != nil {
+		return nil, err
+	}
+	muted, err := u.blockRepo.ListTargetIDs(ctx, userID, blockentity.MuteUser)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs, err := u.followRepo.GetFollowingTagsAll(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	inbox, err := u.listInbox(ctx, userID, muted, cursor, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	// posts under followed tags may come from private accounts the user does not follow
+	pulledRefs, err := u.postRepo.ListRefsBefore(ctx, &postentity.PostQuery{
+		AuthorIDs:        pulled,
+		TagIDs:           tagIDs,
+		ExcludeAuthorIDs: muted,
+		UserID:           userID,
+		HidePrivate:      true,
+	}, cursor, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	// the cursor comes from the merged posts, so posts deleted since they were pushed do not break paging
+	page := paginator.NewCursorPage(mergeDesc(inbox, pulledRefs, limit+1), limit, func(ref postentity.PostRef) int64 {
+		return ref.PostID
+	})
+	postIDs := make([]int64, len(page.Data))
+	for i := range page.Data {
+		postIDs[i] = page.Data[i].PostID
+	}
+	posts, err := u.postUseCase.GetByPostIDs(ctx, userID, postIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &timelineentity.GetHomeTimeLineReply{
+		CursorPage: paginator.CursorPage[postentity.PostBasicInfo]{
+			Data:       hideMuted(posts, muted),
+			NextCursor: page.NextCursor,
+			HasMore:    page.HasMore,
+		},
+	}
+	if len(page.Data) != 0 {
+		reply.NextCursorTime = page.Data[len(page.Data)-1].CreatedAt.UnixMilli()
+	}
+	return reply, nil
+}
+
+// listInbox 读取收件箱，收件箱过期时加载所有关注的用户，用推送作者最近的帖子重建
+func (u *TimeLinePullUsecase) listInbox(ctx context.Context, userID int64, muted []int64, cursor postentity.PostRef, limit int) ([]postentity.PostRef, error) {
+	refs, exist, err := u.timelineRepo.List(ctx, userID, cursor, limit)
+	if err != nil {
+		return nil, err
+	} else if exist {
+		return refs, nil
+	}
+
+	followers, err := u.followRepo.GetFollowingUsersFollowers(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, mutedID := range muted {
+		delete(followers, mutedID)
+	}
+	pushed := make([]int64, 0, len(followers))
+	for followingID, count := range followers {
+		if !u.isPulled(count) {
+			pushed = append(pushed, followingID)
+		}
+	}
+
+	refs, err = u.postRepo.ListRefsBefore(ctx, &postentity.PostQuery{AuthorIDs: pushed}, postentity.PostRef{}, u.conf.InboxSize)
+	if err != nil {
+		return nil, err
+	}
+	err = u.timelineRepo.Rebuild(ctx, userID, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	inbox := make([]postentity.PostRef, 0, limit)
+	for _, ref := range refs {
+		if !cursor.IsZero() && !cursor.After(ref) {
+			continue
+		}
+		if len(inbox) == limit {
+			break
+		}
+		inbox = append(inbox, ref)
+	}
+	return inbox, nil
+}
+
+// GetTagTimeLine 带有关注的标签的帖子
+func (u *TimeLinePullUsecase) GetTagTimeLine(ctx context.Context, userID int64, queryCond *postentity.PostQuery) (*paginator.Page[postentity.PostBasicInfo], error) {
+	tagIDs, err := u.followRepo.GetFollowingTagsAll(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(tagIDs) == 0 {
+		return emptyPage(queryCond), nil
+	}
+	muted, err := u.blockRepo.ListTargetIDs(ctx, userID, blockentity.MuteUser)
 	if err != nil {
 		return nil, err
 	}
 
 	return u.postUseCase.GetPage(ctx, &postentity.PostQuery{
-		PageCond:  queryCond.PageCond,
-		AuthorIDs: followings,
-		UserID:    userID,
+		PageCond:         queryCond.PageCond,
+		TagIDs:           tagIDs,
+		ExcludeAuthorIDs: muted,
+		UserID:           userID,
+		HidePrivate:      true,
 	})
 }
+
+// FanOut 把普通作者新发布的帖子分批推送到粉丝的收件箱
+func (u *TimeLinePullUsecase) FanOut(ctx context.Context, post postentity.PostRef, authorID int64) error {
+	pushed, err := u.isPushedAuthor(ctx, authorID)
+	if err != nil || !pushed {
+		return err
+	}
+
+	return u.eachFollowers(ctx, authorID, func(followers []int64) error {
+		return u.timelineRepo.Push(ctx, followers, post)
+	})
+}
+
+// Retract 从粉丝的收件箱中移除删除的帖子，拉取的作者不需要处理，读取时会过滤已删除的帖子
+func (u *TimeLinePullUsecase) Retract(ctx context.Context, postID, authorID int64) error {
+	pushed, err := u.isPushedAuthor(ctx, authorID)
+	if err != nil || !pushed {
+		return err
+	}
+
+	return u.eachFollowers(ctx, authorID, func(followers []int64) error {
+		return u.timelineRepo.Remove(ctx, followers, postID)
+	})
+}
+
+// Backfill 关注普通作者后把他最近的帖子补进收件箱
+func (u *TimeLinePullUsecase) Backfill(ctx context.Context, followerID, followingID int64) error {
+	pushed, err := u.isPushedAuthor(ctx, followingID)
+	if err != nil || !pushed {
+		return err
+	}
+
+	refs, err := u.postRepo.ListRefsBefore(ctx, &postentity.PostQuery{AuthorIDs: []int64{followingID}}, postentity.PostRef{}, u.conf.InboxSize)
+	if err != nil {
+		return err
+	}
+	return u.timelineRepo.Push(ctx, []int64{followerID}, refs...)
+}
+
+// Cleanup 取消关注后从收件箱中移除该作者的帖子，作者的粉丝数可能已经变化，不区分推拉
+func (u *TimeLinePullUsecase) Cleanup(ctx context.Context, followerID, followingID int64) error {
+	refs, err := u.postRepo.ListRefsBefore(ctx, &postentity.PostQuery{AuthorIDs: []int64{followingID}}, postentity.PostRef{}, u.conf.InboxSize)
+	if err != nil {
+		return err
+	}
+	postIDs := make([]int64, len(refs))
+	for i := range refs {
+		postIDs[i] = refs[i].PostID
+	}
+	return u.timelineRepo.Remove(ctx, []int64{followerID}, postIDs...)
+}
+
+func (u *TimeLinePullUsecase) isPulled(followers int64) bool {
+	return followers >= u.conf.PullThreshold
+}
+
+func (u *TimeLinePullUsecase) isPushedAuthor(ctx context.Context, authorID int64) (bool, error) {
+	author, exist, err := u.userRepo.GetByUserID(ctx, authorID)
+	if err != nil || !exist {
+		return false, err
+	}
+	return !u.isPulled(author.FollowCount), nil
+}
+
+func (u *TimeLinePullUsecase) eachFollowers(ctx context.Context, authorID int64, fn func(followers []int64) error) error {
+	var after int64
+	for {
+		followers, err := u.followRepo.ListFollowersAfter(ctx, authorID, after, fanOutBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(followers) == 0 {
+			return nil
+		}
+		err = fn(followers)
+		if err != nil {
+			return err
+		}
+		if len(followers) < fanOutBatchSize {
+			return nil
+		}
+		after = followers[len(followers)-1]
+	}
+}
+
+// hideMuted 收件箱中可能还有静音之前推送的帖子
+func hideMuted(posts []postentity.PostBasicInfo, muted []int64) []postentity.PostBasicInfo {
+	if len(muted) == 0 {
+		return posts
+	}
+	set := make(map[int64]bool, len(muted))
+	for _, userID := range muted {
+		set[userID] = true
+	}
+	shown := make([]postentity.PostBasicInfo, 0, len(posts))
+	for _, post := range posts {
+		if post.User != nil && set[post.User.UserID] {
+			continue
+		}
+		shown = append(shown, post)
+	}
+	return shown
+}
+
+// mergeDesc 合并两个按发布时间和帖子ID倒序的列表并去重，最多返回 limit 个
+func mergeDesc(a, b []postentity.PostRef, limit int) []postentity.PostRef {
+	merged := make([]postentity.PostRef, 0, limit)
+	i, j := 0, 0
+	for len(merged) < limit && (i < len(a) || j < len(b)) {
+		var next postentity.PostRef
+		switch {
+		case j == len(b) || (i < len(a) && a[i].PostID != b[j].PostID && a[i].After(b[j])):
+			next = a[i]
+			i++
+		case i == len(a) || a[i].PostID != b[j].PostID:
+			next = b[j]
+			j++
+		default:
+			next = a[i]
+			i++
+			j++
+		}
+		merged = append(merged, next)
+	}
+	return merged
+}
+
+// emptyPage 没有关注任何对象时返回空页，而不是查询所有帖子
+func emptyPage(queryCond *postentity.PostQuery) *paginator.Page[postentity.PostBasicInfo] {
+	return &paginator.Page[postentity.PostBasicInfo]{
+		CurrentPage: queryCond.Page,
+		PageSize:    queryCond.PageSize,
+		Data:        []postentity.PostBasicInfo{},
+	}
+}
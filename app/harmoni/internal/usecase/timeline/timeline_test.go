@@ -0,0 +1,74 @@
+package timeline
+
+import (
+	postentity "harmoni/app/harmoni/internal/entity/post"
+	userentity "harmoni/app/harmoni/internal/entity/user"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMergeDesc(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// ref 的发布时间默认随ID递增，draft 是ID较小但发布较晚的草稿
+	ref := func(postID int64) postentity.PostRef {
+		return postentity.PostRef{PostID: postID, CreatedAt: base.Add(time.Duration(postID) * time.Minute)}
+	}
+	draft := postentity.PostRef{PostID: 2, CreatedAt: base.Add(time.Hour)}
+	sameTime := func(postID int64) postentity.PostRef {
+		return postentity.PostRef{PostID: postID, CreatedAt: base}
+	}
+	tests := []struct {
+		name  string
+		a, b  []postentity.PostRef
+		limit int
+		want  []int64
+	}{
+		{"empty", nil, nil, 3, []int64{}},
+		{"one side", []postentity.PostRef{ref(5), ref(3), ref(1)}, nil, 5, []int64{5, 3, 1}},
+		{"interleaved", []postentity.PostRef{ref(9), ref(5), ref(1)}, []postentity.PostRef{ref(8), ref(4)}, 10, []int64{9, 8, 5, 4, 1}},
+		{"duplicated", []postentity.PostRef{ref(9), ref(5), ref(1)}, []postentity.PostRef{ref(9), ref(5), ref(2)}, 10, []int64{9, 5, 2, 1}},
+		{"limited", []postentity.PostRef{ref(9), ref(5), ref(1)}, []postentity.PostRef{ref(8), ref(4)}, 3, []int64{9, 8, 5}},
+		{"published draft first", []postentity.PostRef{ref(9), ref(5)}, []postentity.PostRef{draft, ref(8)}, 10, []int64{2, 9, 8, 5}},
+		{"same time by id", []postentity.PostRef{sameTime(7), sameTime(3)}, []postentity.PostRef{sameTime(5)}, 10, []int64{7, 5, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []int64{}
+			for _, ref := range mergeDesc(tt.a, tt.b, tt.limit) {
+				got = append(got, ref.PostID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeDesc() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHideMuted(t *testing.T) {
+	post := func(postID, userID int64) postentity.PostBasicInfo {
+		return postentity.PostBasicInfo{PostID: postID, User: &userentity.UserBasicInfo{UserID: userID}}
+	}
+	tests := []struct {
+		name  string
+		posts []postentity.PostBasicInfo
+		muted []int64
+		want  []int64
+	}{
+		{"nothing muted", []postentity.PostBasicInfo{post(1, 10), post(2, 20)}, nil, []int64{1, 2}},
+		{"author muted", []postentity.PostBasicInfo{post(1, 10), post(2, 20), post(3, 10)}, []int64{10}, []int64{2}},
+		{"all muted", []postentity.PostBasicInfo{post(1, 10), post(2, 20)}, []int64{10, 20}, []int64{}},
+		{"missing author kept", []postentity.PostBasicInfo{{PostID: 1}, post(2, 20)}, []int64{20}, []int64{1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []int64{}
+			for _, post := range hideMuted(tt.posts, tt.muted) {
+				got = append(got, post.PostID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("hideMuted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
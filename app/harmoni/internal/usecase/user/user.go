@@ -5,10 +5,12 @@ import (
 	likeentity "harmoni/app/harmoni/internal/entity/like"
 	"harmoni/app/harmoni/internal/entity/paginator"
 	userentity "harmoni/app/harmoni/internal/entity/user"
+	"harmoni/app/harmoni/internal/pkg/mention"
 	"harmoni/app/harmoni/internal/pkg/reason"
 	fileusecase "harmoni/app/harmoni/internal/usecase/file"
 	"harmoni/app/harmoni/internal/usecase/user/events"
 	"harmoni/internal/pkg/errorx"
+	"harmoni/internal/pkg/set"
 	"regexp"
 	"unicode/utf8"
 
@@ -75,7 +77,11 @@ func (u *UserUseCase) Create(ctx context.Context, user *userentity.User) error {
 		return err
 	}
 
-	return err
+	err = u.likeRepo.AddObject(ctx, likeentity.LikeUser, user.UserID, user.UserID)
+	if err != nil {
+		u.logger.Errorf("add user %d to object filter failed: %s", user.UserID, err)
+	}
+	return nil
 }
 
 func (u *UserUseCase) VerifyPassword(ctx context.Context, password, hashedPwd string) error {
@@ -118,10 +124,100 @@ func (u *UserUseCase) GetByUserID(ctx context.Context, userID int64) (*userentit
 	return user, exist, nil
 }
 
+// IsModerator 用户是否有管理他人内容的权限
+func (u *UserUseCase) IsModerator(ctx context.Context, userID int64) (bool, error) {
+	user, exist, err := u.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	} else if !exist {
+		return false, nil
+	}
+
+	return user.Role == userentity.RoleModerator, nil
+}
+
+// IsPrivate 用户是否开启了私密账号，用户不存在时为 false
+func (u *UserUseCase) IsPrivate(ctx context.Context, userID int64) (bool, error) {
+	user, exist, err := u.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	} else if !exist {
+		return false, nil
+	}
+
+	return user.Private, nil
+}
+
+func (u *UserUseCase) SetPrivate(ctx context.Context, userID int64, private bool) error {
+	return u.userRepo.SetPrivate(ctx, userID, private)
+}
+
 func (u *UserUseCase) GetByUserIDs(ctx context.Context, userIDs []int64) (userentity.UserList, error) {
 	return u.userRepo.GetByUserIDs(ctx, userIDs)
 }
 
+func (u *UserUseCase) toBasics(ctx context.Context, users userentity.UserList) ([]*userentity.UserBasicInfo, error) {
+	avatarSet := set.New[int64]()
+	for i := range users {
+		avatarSet.Add(users[i].Avatar)
+	}
+	avatarlinkMap, err := u.fileUsecase.ListFileLinkMap(ctx, avatarSet.ToArray())
+	if err != nil {
+		return nil, err
+	}
+
+	return users.ToUserBasics(avatarlinkMap), nil
+}
+
+// ListBasicByUserIDs 批量获取用户的基本信息，不存在的用户被忽略
+func (u *UserUseCase) ListBasicByUserIDs(ctx context.Context, userIDs []int64) ([]*userentity.UserBasicInfo, error) {
+	if len(userIDs) == 0 {
+		return []*userentity.UserBasicInfo{}, nil
+	}
+
+	users, err := u.userRepo.GetByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	return u.toBasics(ctx, users)
+}
+
+// ResolveMentions 解析内容中提及的用户，同名用户取最早注册的一个，找不到的用户名被忽略
+func (u *UserUseCase) ResolveMentions(ctx context.Context, content string) ([]int64, error) {
+	names := mention.Parse(content)
+	if len(names) == 0 {
+		return []int64{}, nil
+	}
+
+	users, err := u.userRepo.GetByNames(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+	nameMap := make(map[string]int64, len(users))
+	for _, user := range users {
+		if _, ok := nameMap[user.Name]; !ok {
+			nameMap[user.Name] = user.UserID
+		}
+	}
+
+	userIDs := make([]int64, 0, len(nameMap))
+	for _, name := range names {
+		if userID, ok := nameMap[name]; ok {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	return userIDs, nil
+}
+
+// SuggestByNamePrefix 提及用户时的自动补全
+func (u *UserUseCase) SuggestByNamePrefix(ctx context.Context, prefix string, limit int) ([]*userentity.UserBasicInfo, error) {
+	users, err := u.userRepo.ListByNamePrefix(ctx, prefix, limit)
+	if err != nil {
+		return nil, err
+	}
+	return u.toBasics(ctx, users)
+}
+
 func (u *UserUseCase) GetUserByEmail(ctx context.Context, email string) (*userentity.User, bool, error) {
 	return u.userRepo.GetByEmail(ctx, email)
 }
@@ -13,6 +13,7 @@ import (
 	"harmoni/app/notification/internal/infrastructure/data"
 	"harmoni/app/notification/internal/infrastructure/rpc"
 	"harmoni/app/notification/internal/pkg/middleware"
+	"harmoni/app/notification/internal/repository/mute"
 	"harmoni/app/notification/internal/repository/notifyconfig"
 	"harmoni/app/notification/internal/repository/remind"
 	"harmoni/app/notification/internal/server/http"
@@ -64,7 +65,8 @@ func initApplication(conf3 *conf.Conf, appConf *conf2.App, dataConf *conf2.DB, e
 		cleanup()
 		return nil, nil, err
 	}
-	remindUsecase := remind2.NewRemindUsecase(notifyConfigRepo, remindRepo, dataDB, loggerLogger)
+	muteRepo := mute.NewMuteRepo(dataDB, loggerLogger)
+	remindUsecase := remind2.NewRemindUsecase(notifyConfigRepo, remindRepo, muteRepo, dataDB, loggerLogger)
 	remindEventsHandler := events.NewLikeEventsHandler(remindUsecase)
 	router, err := mq.NewMQRouter(mqConf, remindEventsHandler, loggerLogger)
 	if err != nil {
@@ -0,0 +1,12 @@
+package mute
+
+import "context"
+
+// MuteRepository 用户之间的静音关系，由 harmoni 的静音事件同步而来
+type MuteRepository interface {
+	// Mute 重复的静音事件被忽略
+	Mute(ctx context.Context, userID, targetUserID int64) error
+	Unmute(ctx context.Context, userID, targetUserID int64) error
+	// ListMuters userIDs 中静音了 targetUserID 的用户
+	ListMuters(ctx context.Context, userIDs []int64, targetUserID int64) ([]int64, error)
+}
@@ -85,6 +85,34 @@ func (h *RemindHandler) ListReplyRemind(c *gin.Context) {
 	response.HandleResponse(c, err, resp)
 }
 
+func (h *RemindHandler) ListFollowRequestRemind(c *gin.Context) {
+	req := v1.ListRemindRequest{}
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.HandleResponse(c,
+			errorx.BadRequest(reason.RequestFormatError).WithError(err), nil)
+		return
+	}
+	req.Action = action.ActionFollowRequest
+	req.UserID = middleware.GetUserInfoFromContext(c).GetId()
+	resp, err := h.rs.ListRemind(c, &req)
+	response.HandleResponse(c, err, resp)
+}
+
+func (h *RemindHandler) ListReactRemind(c *gin.Context) {
+	req := v1.ListRemindRequest{}
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.HandleResponse(c,
+			errorx.BadRequest(reason.RequestFormatError).WithError(err), nil)
+		return
+	}
+	req.Action = action.ActionReact
+	req.UserID = middleware.GetUserInfoFromContext(c).GetId()
+	resp, err := h.rs.ListRemind(c, &req)
+	response.HandleResponse(c, err, resp)
+}
+
 func (h *RemindHandler) ListLikeRemindDetail(c *gin.Context) {
 	req := v1.LikeRemindDetailRequest{}
 	err := c.ShouldBindQuery(&req)
@@ -0,0 +1,16 @@
+package notification
+
+import (
+	"time"
+)
+
+type RemindMute struct {
+	ID           int64     `gorm:"column:id;type:bigint(20);primary_key;AUTO_INCREMENT"`
+	CreatedAt    time.Time `gorm:"column:created_at;type:datetime;NOT NULL"`
+	UserID       int64     `gorm:"column:user_id;type:bigint(20);NOT NULL;uniqueIndex:idx_remind_mute,priority:1"`        // 静音者
+	TargetUserID int64     `gorm:"column:target_user_id;type:bigint(20);NOT NULL;uniqueIndex:idx_remind_mute,priority:2"` // 被静音的用户
+}
+
+func (RemindMute) TableName() string {
+	return "remind_mute"
+}
@@ -0,0 +1,65 @@
+package mute
+
+import (
+	"context"
+	"harmoni/app/notification/internal/entity/mute"
+	"harmoni/app/notification/internal/infrastructure/po/notification"
+	"harmoni/internal/pkg/data"
+	"harmoni/internal/pkg/errorx"
+	"harmoni/internal/pkg/reason"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm/clause"
+)
+
+var _ mute.MuteRepository = (*MuteRepo)(nil)
+
+type MuteRepo struct {
+	data   *data.DB
+	logger *log.Helper
+}
+
+func NewMuteRepo(data *data.DB, logger log.Logger) *MuteRepo {
+	return &MuteRepo{
+		data:   data,
+		logger: log.NewHelper(log.With(logger, "module", "repository/mute")),
+	}
+}
+
+func (r *MuteRepo) Mute(ctx context.Context, userID, targetUserID int64) error {
+	err := r.data.DB(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&notification.RemindMute{
+			UserID:       userID,
+			TargetUserID: targetUserID,
+		}).Error
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func (r *MuteRepo) Unmute(ctx context.Context, userID, targetUserID int64) error {
+	err := r.data.DB(ctx).
+		Where("user_id = ? AND target_user_id = ?", userID, targetUserID).
+		Delete(&notification.RemindMute{}).Error
+	if err != nil {
+		return errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func (r *MuteRepo) ListMuters(ctx context.Context, userIDs []int64, targetUserID int64) ([]int64, error) {
+	muters := []int64{}
+	if len(userIDs) == 0 {
+		return muters, nil
+	}
+
+	err := r.data.DB(ctx).Model(&notification.RemindMute{}).
+		Where("target_user_id = ? AND user_id IN ?", targetUserID, userIDs).
+		Pluck("user_id", &muters).Error
+	if err != nil {
+		return nil, errorx.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return muters, nil
+}
@@ -1,8 +1,10 @@
 package repository
 
 import (
+	"harmoni/app/notification/internal/entity/mute"
 	"harmoni/app/notification/internal/entity/notifyconfig"
 	"harmoni/app/notification/internal/entity/remind"
+	repomute "harmoni/app/notification/internal/repository/mute"
 	repoconfig "harmoni/app/notification/internal/repository/notifyconfig"
 	reporemind "harmoni/app/notification/internal/repository/remind"
 
@@ -14,4 +16,6 @@ var ProviderSet = wire.NewSet(
 	wire.Bind(new(remind.RemindRepository), new(*reporemind.RemindRepo)),
 	repoconfig.NewNotifyConfigRepo,
 	wire.Bind(new(notifyconfig.NotifyConfigRepository), new(*repoconfig.NotifyConfigRepo)),
+	repomute.NewMuteRepo,
+	wire.Bind(new(mute.MuteRepository), new(*repomute.MuteRepo)),
 )
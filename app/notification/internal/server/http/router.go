@@ -29,9 +29,11 @@ func (r *NotificationAPIRouter) RegisterNotificationAPIRouter(g *gin.RouterGroup
 
 		mg.GET("/like", r.rh.ListLikeRemind)
 		mg.GET("/like/detail", r.rh.ListLikeRemindDetail)
+		mg.GET("/reaction", r.rh.ListReactRemind)
 
 		mg.GET("/reply", r.rh.ListReplyRemind)
 		mg.GET("/at", r.rh.ListAtRemind)
+		mg.GET("/follow_request", r.rh.ListFollowRequestRemind)
 	}
 }
 
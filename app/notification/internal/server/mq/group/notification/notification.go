@@ -2,7 +2,9 @@ package notification
 
 import (
 	"encoding/json"
+	eventcomment "harmoni/app/harmoni/api/mq/v1/comment"
 	eventlike "harmoni/app/harmoni/api/mq/v1/like"
+	eventmention "harmoni/app/harmoni/api/mq/v1/mention"
 	eventremind "harmoni/app/notification/internal/usecase/remind/events"
 	"harmoni/internal/conf"
 	"harmoni/internal/pkg/mq"
@@ -41,5 +43,33 @@ func NewNotificationGroup(
 		}
 		return remindEventsHandler.HandleLikeCreated(msg.Context(), &m)
 	})
+	g.Handle(events.TopicCommentCreated, func(msg *message.Message) error {
+		var m eventcomment.CommentCreatedMessage
+		if err := json.Unmarshal(msg.Payload, &m); err != nil {
+			return err
+		}
+		return remindEventsHandler.HandleCommentCreated(msg.Context(), &m)
+	})
+	g.Handle(events.TopicMentionCreated, func(msg *message.Message) error {
+		var m eventmention.MentionCreatedMessage
+		if err := json.Unmarshal(msg.Payload, &m); err != nil {
+			return err
+		}
+		return remindEventsHandler.HandleMentionCreated(msg.Context(), &m)
+	})
+	g.Handle(events.TopicUserMuted, func(msg *message.Message) error {
+		var m events.UserMutedMessage
+		if err := json.Unmarshal(msg.Payload, &m); err != nil {
+			return err
+		}
+		return remindEventsHandler.HandleUserMuted(msg.Context(), &m)
+	})
+	g.Handle(events.TopicFollowRequested, func(msg *message.Message) error {
+		var m events.FollowRequestedMessage
+		if err := json.Unmarshal(msg.Payload, &m); err != nil {
+			return err
+		}
+		return remindEventsHandler.HandleFollowRequested(msg.Context(), &m)
+	})
 	return nil
 }
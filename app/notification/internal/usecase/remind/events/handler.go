@@ -2,10 +2,13 @@ package events
 
 import (
 	"context"
+	eventcomment "harmoni/app/harmoni/api/mq/v1/comment"
 	eventlike "harmoni/app/harmoni/api/mq/v1/like"
+	eventmention "harmoni/app/harmoni/api/mq/v1/mention"
 	"harmoni/app/notification/internal/entity/remind"
 	usercaseremind "harmoni/app/notification/internal/usecase/remind"
 	"harmoni/internal/types/action"
+	"harmoni/internal/types/events"
 	"harmoni/internal/types/object"
 )
 
@@ -32,19 +35,149 @@ func fromLikeType(t eventlike.LikeType) object.ObjectType {
 	}
 }
 
+func fromMentionObjectType(t eventmention.ObjectType) object.ObjectType {
+	switch t {
+	case eventmention.ObjectType_ObjectPost:
+		return object.ObjectTypePost
+	case eventmention.ObjectType_ObjectComment:
+		return object.ObjectTypeComment
+	default:
+		return 0
+	}
+}
+
 func (h *RemindEventsHandler) HandleLikeCreated(ctx context.Context, msg *eventlike.LikeCreatedMessage) error {
-	if msg.IsCancel || msg.BaseMessage.LikeType == eventlike.LikeType_LikeUser ||
+	// downvotes are anonymous and never remind the author
+	if msg.IsCancel || msg.IsDown || msg.BaseMessage.LikeType == eventlike.LikeType_LikeUser ||
 		msg.BaseMessage.LikeType == eventlike.LikeType_LikeNo {
 		return nil
 	}
 
+	recipients, err := h.ru.Unmuted(ctx, []int64{msg.TargetUserID}, msg.UserID)
+	if err != nil || len(recipients) == 0 {
+		return err
+	}
+
+	// reactions are reminded apart from likes, carrying the reaction as content
+	act := action.ActionLike
+	if msg.Reaction != "" {
+		act = action.ActionReact
+	}
 	createdAt := msg.CreatedAt.AsTime()
 	return h.ru.Create(ctx, &remind.CreateReq{
 		RecipientID:  msg.TargetUserID,
 		SenderIDs:    []int64{msg.UserID},
-		Action:       action.ActionLike,
+		Action:       act,
 		ObjectID:     msg.LikingID,
 		ObjectType:   fromLikeType(msg.BaseMessage.LikeType),
+		Content:      msg.Reaction,
 		LastReadTime: &createdAt,
 	})
 }
+
+// HandleCommentCreated 提醒帖子作者、被回复评论的作者和回复对象，
+// 回复评论时提醒挂在被回复的评论上，否则挂在帖子上
+func (h *RemindEventsHandler) HandleCommentCreated(ctx context.Context, msg *eventcomment.CommentCreatedMessage) error {
+	type target struct {
+		objectID   int64
+		objectType object.ObjectType
+	}
+	onArea := target{objectID: msg.ObjectID, objectType: object.ObjectType(msg.ObjectType)}
+	onReplied := onArea
+	if msg.ParentID != 0 {
+		onReplied = target{objectID: msg.ParentID, objectType: object.ObjectTypeComment}
+	}
+
+	recipients := map[int64]target{}
+	order := []int64{}
+	add := func(userID int64, t target) {
+		if userID == 0 || userID == msg.AuthorID {
+			return
+		}
+		if _, ok := recipients[userID]; ok {
+			return
+		}
+		recipients[userID] = t
+		order = append(order, userID)
+	}
+	add(msg.ParentAuthorID, onReplied)
+	add(msg.ObjectAuthorID, onArea)
+	for _, userID := range msg.ToUserIDs {
+		add(userID, onReplied)
+	}
+	order, err := h.ru.Unmuted(ctx, order, msg.AuthorID)
+	if err != nil {
+		return err
+	}
+
+	createdAt := msg.CreatedAt.AsTime()
+	for _, userID := range order {
+		t := recipients[userID]
+		err := h.ru.Create(ctx, &remind.CreateReq{
+			RecipientID:  userID,
+			SenderIDs:    []int64{msg.AuthorID},
+			Action:       action.ActionReply,
+			ObjectID:     t.objectID,
+			ObjectType:   t.objectType,
+			LastReadTime: &createdAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HandleMentionCreated 提醒帖子或评论中提及的用户，提及自己不提醒
+func (h *RemindEventsHandler) HandleMentionCreated(ctx context.Context, msg *eventmention.MentionCreatedMessage) error {
+	objectType := fromMentionObjectType(msg.ObjectType)
+	if objectType == 0 {
+		return nil
+	}
+
+	mentioned, err := h.ru.Unmuted(ctx, msg.MentionedUserIDs, msg.UserID)
+	if err != nil {
+		return err
+	}
+
+	createdAt := msg.CreatedAt.AsTime()
+	for _, userID := range mentioned {
+		if userID == msg.UserID {
+			continue
+		}
+		err := h.ru.Create(ctx, &remind.CreateReq{
+			RecipientID:  userID,
+			SenderIDs:    []int64{msg.UserID},
+			Action:       action.ActionAt,
+			ObjectID:     msg.ObjectID,
+			ObjectType:   objectType,
+			LastReadTime: &createdAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HandleFollowRequested 提醒私密账号有新的关注请求，同一个账号的请求合并为一条提醒
+func (h *RemindEventsHandler) HandleFollowRequested(ctx context.Context, msg *events.FollowRequestedMessage) error {
+	recipients, err := h.ru.Unmuted(ctx, []int64{msg.TargetUserID}, msg.UserID)
+	if err != nil || len(recipients) == 0 {
+		return err
+	}
+
+	return h.ru.Create(ctx, &remind.CreateReq{
+		RecipientID:  msg.TargetUserID,
+		SenderIDs:    []int64{msg.UserID},
+		Action:       action.ActionFollowRequest,
+		ObjectID:     msg.TargetUserID,
+		ObjectType:   object.ObjectTypeUser,
+		LastReadTime: &msg.CreatedAt,
+	})
+}
+
+// HandleUserMuted 同步用户在 harmoni 中的静音关系
+func (h *RemindEventsHandler) HandleUserMuted(ctx context.Context, msg *events.UserMutedMessage) error {
+	return h.ru.Mute(ctx, msg.UserID, msg.TargetUserID, msg.IsCancel)
+}
@@ -2,12 +2,14 @@ package remind
 
 import (
 	"context"
+	"harmoni/app/notification/internal/entity/mute"
 	"harmoni/app/notification/internal/entity/notifyconfig"
 	"harmoni/app/notification/internal/entity/remind"
 	"harmoni/internal/pkg/paginator"
 	"harmoni/internal/types/iface"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/samber/lo"
 )
 
 const (
@@ -17,6 +19,7 @@ const (
 type RemindUsecase struct {
 	nr     remind.RemindRepository
 	cr     notifyconfig.NotifyConfigRepository
+	mr     mute.MuteRepository
 	tx     iface.Transaction
 	logger *log.Helper
 }
@@ -24,12 +27,14 @@ type RemindUsecase struct {
 func NewRemindUsecase(
 	cr notifyconfig.NotifyConfigRepository,
 	nr remind.RemindRepository,
+	mr mute.MuteRepository,
 	tx iface.Transaction,
 	logger log.Logger,
 ) *RemindUsecase {
 	return &RemindUsecase{
 		cr:     cr,
 		nr:     nr,
+		mr:     mr,
 		tx:     tx,
 		logger: log.NewHelper(log.With(logger, "module", "usecase/notification")),
 	}
@@ -48,6 +53,23 @@ func (u *RemindUsecase) Create(ctx context.Context, req *remind.CreateReq) error
 	})
 }
 
+// Mute 静音之后不再向用户发送对方产生的提醒
+func (u *RemindUsecase) Mute(ctx context.Context, userID, targetUserID int64, isCancel bool) error {
+	if isCancel {
+		return u.mr.Unmute(ctx, userID, targetUserID)
+	}
+	return u.mr.Mute(ctx, userID, targetUserID)
+}
+
+// Unmuted 去掉 recipientIDs 中静音了 senderID 的用户
+func (u *RemindUsecase) Unmuted(ctx context.Context, recipientIDs []int64, senderID int64) ([]int64, error) {
+	muters, err := u.mr.ListMuters(ctx, recipientIDs, senderID)
+	if err != nil {
+		return nil, err
+	}
+	return lo.Without(recipientIDs, muters...), nil
+}
+
 func (u *RemindUsecase) List(ctx context.Context, req *remind.ListReq) (*paginator.Page[*remind.Remind], error) {
 	req.SenderCount = showSenderCount
 	return u.nr.List(ctx, req)
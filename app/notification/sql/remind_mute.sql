@@ -0,0 +1,9 @@
+-- remind_mute 静音关系，静音者不再收到被静音用户的提醒，对应 po/notification/remind_mute.go
+CREATE TABLE IF NOT EXISTS `remind_mute` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `created_at` datetime NOT NULL,
+  `user_id` bigint(20) NOT NULL COMMENT '静音者',
+  `target_user_id` bigint(20) NOT NULL COMMENT '被静音的用户',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_remind_mute` (`user_id`, `target_user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -3,10 +3,12 @@ package action
 type Action int8
 
 const (
-	ActionNo    Action = iota
-	ActionReply        // 回复
-	ActionAt           // at
-	ActionLike         // 点赞
+	ActionNo            Action = iota
+	ActionReply                // 回复
+	ActionAt                   // at
+	ActionLike                 // 点赞
+	ActionFollowRequest        // 请求关注
+	ActionReact                // 表情回应
 )
 
 func (a Action) String() string {
@@ -19,6 +21,10 @@ func (a Action) String() string {
 		return "提到"
 	case ActionLike:
 		return "喜欢"
+	case ActionFollowRequest:
+		return "请求关注"
+	case ActionReact:
+		return "回应"
 	}
 	return "unknown"
 }
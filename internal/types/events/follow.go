@@ -0,0 +1,10 @@
+package events
+
+import "time"
+
+// FollowRequestedMessage 用户请求关注私密账号，通知服务据此提醒被请求的用户
+type FollowRequestedMessage struct {
+	UserID       int64     `json:"user_id,omitempty"`
+	TargetUserID int64     `json:"target_user_id,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
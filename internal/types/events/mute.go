@@ -0,0 +1,8 @@
+package events
+
+// UserMutedMessage 用户静音或取消静音另一个用户，通知服务据此过滤提醒
+type UserMutedMessage struct {
+	UserID       int64 `json:"user_id,omitempty"`
+	TargetUserID int64 `json:"target_user_id,omitempty"`
+	IsCancel     bool  `json:"is_cancel,omitempty"`
+}
@@ -3,4 +3,11 @@ package events
 const (
 	TopicLikeCreated = "like_created"
 	TopicLikeStore   = "like_store"
+
+	TopicCommentCreated = "comment_created"
+	TopicMentionCreated = "mention_created"
+
+	TopicUserMuted = "user_muted"
+
+	TopicFollowRequested = "follow_requested"
 )
@@ -6,6 +6,7 @@ const (
 	ObjectTypePost    ObjectType = iota + 1 // 帖子
 	ObjectTypeComment                       // 评论
 	ObjectTypeTag                           // 话题
+	ObjectTypeUser                          // 用户
 )
 
 func (o ObjectType) String() string {
@@ -16,6 +17,8 @@ func (o ObjectType) String() string {
 		return "评论"
 	case ObjectTypeTag:
 		return "话题"
+	case ObjectTypeUser:
+		return "用户"
 	}
 	return "unknown"
 }